@@ -10,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/api/handler"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/api/routes"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
@@ -54,22 +55,59 @@ func main() {
 	// Configura o modo do Gin
 	gin.SetMode(cfg.Server.GinMode)
 
-	// Configura o cliente Kubernetes
-	k8sClient, err := k8s.NewClient(&k8s.ClientConfig{
-		KubeconfigPath: cfg.K8s.KubeconfigPath,
-		InCluster:      cfg.K8s.InCluster,
-	})
-	if err != nil {
-		logger.Fatal("Erro ao criar cliente Kubernetes", err)
-	}
+	// Inicia os caches de informers; as análises aguardam a sincronização inicial
+	cacheCtx, stopCache := context.WithCancel(context.Background())
+	defer stopCache()
+
+	// Configura os clientes Kubernetes e Mimir de cada cluster
+	clusters := collector.NewClusterCollector(cfg.DefaultCluster)
+	var healthHandler *handler.HealthHandler
+	for _, cluster := range cfg.Clusters {
+		k8sClient, err := k8s.NewClient(&k8s.ClientConfig{
+			ClusterName:    cluster.Name,
+			KubeconfigPath: cluster.KubeconfigPath,
+			Context:        cluster.Context,
+			InCluster:      cluster.InCluster,
+			CacheResync:    cfg.K8s.CacheResync,
+		})
+		if err != nil {
+			logger.Fatal("Erro ao criar cliente Kubernetes", err, logger.NewField("cluster", cluster.Name))
+		}
+		k8sClient.StartCache(cacheCtx)
 
-	// Configura o cliente Mimir
-	mimirClient := mimir.NewClient(&mimir.ClientConfig{
-		BaseURL:     cfg.Mimir.URL,
-		ServiceName: cfg.Mimir.ServiceName,
-		Namespace:   cfg.Mimir.Namespace,
-		OrgID:       cfg.Mimir.OrgID,
-	})
+		backend, err := mimir.NewMetricsBackend(cluster.MetricsBackend)
+		if err != nil {
+			logger.Fatal("Erro ao configurar backend de métricas", err, logger.NewField("cluster", cluster.Name))
+		}
+
+		mimirClient := mimir.NewClient(&mimir.ClientConfig{
+			BaseURL:     cluster.MetricsURL,
+			ServiceName: cfg.Mimir.ServiceName,
+			Namespace:   cfg.Mimir.Namespace,
+			OrgID:       cluster.MimirOrgID,
+			Timeouts: mimir.TimeoutConfig{
+				Query:      cfg.Mimir.TimeoutQuery,
+				QueryRange: cfg.Mimir.TimeoutRange,
+				Connect:    cfg.Mimir.TimeoutConnect,
+			},
+			Retry: mimir.RetryConfig{
+				MaxRetries:     cfg.Mimir.RetryMax,
+				InitialBackoff: cfg.Mimir.RetryBackoff,
+				MaxBackoff:     cfg.Mimir.MaxBackoff,
+			},
+			CircuitBreaker: mimir.CircuitBreakerConfig{
+				MaxFailures:      cfg.Mimir.CBMaxFailures,
+				ResetTimeout:     cfg.Mimir.CBResetTimeout,
+				HalfOpenMaxCalls: cfg.Mimir.CBHalfOpenMax,
+			},
+			Backend: backend,
+		})
+
+		if cluster.Name == cfg.DefaultCluster {
+			healthHandler = handler.NewHealthHandler(k8sClient, mimirClient)
+		}
+		clusters.Register(cluster.Name, collector.NewK8sMimirCollector(k8sClient, mimirClient))
+	}
 
 	// Configura o cliente de preços
 	pricingClient := pricing.NewClient(&pricing.Config{
@@ -77,17 +115,25 @@ func main() {
 		Timeout:     cfg.Pricing.Timeout,
 	})
 
-	// Cria o coletor de métricas
-	metricsCollector := collector.NewK8sMimirCollector(k8sClient, mimirClient)
-
-	// Cria o serviço de análise
-	analyzerService := analyzer.NewService(metricsCollector, pricingClient)
+	// Cria o serviço de análise; o coletor direciona cada requisição ao cluster selecionado
+	analyzerService := analyzer.NewService(clusters, pricingClient, &analyzer.Config{
+		MaxConcurrency:        cfg.Analyzer.MaxConcurrency,
+		GroupLabel:            cfg.Analyzer.GroupLabel,
+		TopOffenders:          cfg.Analyzer.TopOffenders,
+		HPAUtilizationCeiling: float64(cfg.Analyzer.HPAUtilizationCeiling),
+		ThrottlingThreshold:   float64(cfg.Analyzer.ThrottlingThreshold),
+		CPULimitPolicy:        cfg.Analyzer.CPULimitPolicy,
+		CPULimitRatio:         cfg.Analyzer.CPULimitRatio,
+		MemoryLimitRatio:      cfg.Analyzer.MemoryLimitRatio,
+		QuotaHeadroom:         float64(cfg.Analyzer.QuotaHeadroom),
+		WorkloadTimeout:       cfg.Analyzer.WorkloadTimeout,
+	})
 
 	// Configura o router
 	router := gin.New() // Usa gin.New() ao invés de gin.Default() para configurar middlewares manualmente
 
 	// Configura as rotas
-	routes.SetupRoutes(router, k8sClient, mimirClient, analyzerService)
+	routes.SetupRoutes(router, clusters, analyzerService, healthHandler, cfg.Server.RequestTimeout, cfg.Server.AggregateRequestTimeout)
 
 	// Configura o servidor
 	srv := &http.Server{
@@ -95,7 +141,7 @@ func main() {
 		Handler:           router,
 		ReadHeaderTimeout: 20 * time.Second,
 		ReadTimeout:       1 * time.Minute,
-		WriteTimeout:      2 * time.Minute,
+		WriteTimeout:      cfg.Server.WriteTimeout(),
 		IdleTimeout:       30 * time.Second,
 		MaxHeaderBytes:    1 << 20, // 1MB
 	}
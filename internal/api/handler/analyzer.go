@@ -9,6 +9,7 @@ import (
 
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -31,7 +32,29 @@ type GetMetricsRequest struct {
 	Period    string `form:"period" binding:"required"`
 }
 
-// AnalyzeResources analisa os recursos de um deployment
+// workloadFromParams extrai o tipo e o nome do workload dos parâmetros da rota.
+// Suporta a rota /resources/:kind/:name/analysis e a rota legada
+// /resources/:deployment/analysis, que assume o tipo Deployment. O gin exige o mesmo nome de
+// wildcard na mesma posição, então a rota legada é registrada como /resources/:kind/analysis
+// e o nome do Deployment chega no parâmetro kind.
+func workloadFromParams(c *gin.Context) (types.WorkloadKind, string, error) {
+	name := c.Param("name")
+	if name == "" {
+		name = c.Param("kind")
+		if name == "" {
+			return "", "", errors.NewInvalidConfigurationError("workload", "nome não especificado")
+		}
+		return types.WorkloadKindDeployment, name, nil
+	}
+
+	kind, ok := types.ParseWorkloadKind(c.Param("kind"))
+	if !ok {
+		return "", "", errors.NewInvalidConfigurationError("kind", "tipo de workload não suportado: "+c.Param("kind"))
+	}
+	return kind, name, nil
+}
+
+// AnalyzeResources analisa os recursos de um workload
 func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 	// Extrai e valida parâmetros
 	var req GetMetricsRequest
@@ -46,10 +69,9 @@ func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 		return
 	}
 
-	deployment := c.Param("deployment")
-	if deployment == "" {
-		err := errors.NewInvalidConfigurationError("deployment", "nome não especificado")
-		logger.Error("Deployment não especificado", err)
+	kind, name, err := workloadFromParams(c)
+	if err != nil {
+		logger.Error("Workload inválido", err)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -58,7 +80,8 @@ func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 
 	logger.Info("Requisição recebida",
 		logger.NewField("namespace", req.Namespace),
-		logger.NewField("deployment", deployment),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 		logger.NewField("period", req.Period),
 	)
 
@@ -79,19 +102,23 @@ func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 	// Obtém métricas
 	logger.Info("Obtendo métricas",
 		logger.NewField("namespace", req.Namespace),
-		logger.NewField("deployment", deployment),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 		logger.NewField("period", period),
 	)
 
-	metricsResponse, err := h.resourceAnalyzer.GetMetrics(c.Request.Context(), req.Namespace, deployment, period)
+	metricsResponse, err := h.resourceAnalyzer.GetMetrics(c.Request.Context(), req.Namespace, kind, name, period)
 	if err != nil {
 		logger.Error("Erro ao obter métricas", err,
 			logger.NewField("namespace", req.Namespace),
-			logger.NewField("deployment", deployment),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
 		)
 		status := http.StatusInternalServerError
 		if errors.IsResourceNotFound(err) {
 			status = http.StatusNotFound
+		} else if errors.IsUnavailableMetrics(err) {
+			status = http.StatusServiceUnavailable
 		}
 		c.JSON(status, gin.H{
 			"error": err.Error(),
@@ -99,19 +126,9 @@ func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 		return
 	}
 
-	// Obtém tendências
-	logger.Info("Obtendo tendências")
-	trendsResponse, err := h.resourceAnalyzer.GetTrends(c.Request.Context(), req.Namespace, deployment, period)
-	if err != nil {
-		logger.Error("Erro ao obter tendências", err,
-			logger.NewField("namespace", req.Namespace),
-			logger.NewField("deployment", deployment),
-		)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
-		return
-	}
+	// Calcula tendências a partir das métricas já coletadas
+	logger.Info("Calculando tendências")
+	trendsResponse := h.resourceAnalyzer.CalculateTrends(metricsResponse, period)
 
 	// Analisa recursos
 	logger.Info("Analisando recursos")
@@ -123,7 +140,8 @@ func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 	if err != nil {
 		logger.Error("Erro ao calcular custos", err,
 			logger.NewField("namespace", req.Namespace),
-			logger.NewField("deployment", deployment),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -148,7 +166,8 @@ func (h *AnalyzerHandler) AnalyzeResources(c *gin.Context) {
 
 	logger.Info("Enviando resposta",
 		logger.NewField("namespace", req.Namespace),
-		logger.NewField("deployment", deployment),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 		logger.NewField("alerts_count", len(alerts)),
 	)
 
@@ -15,25 +15,25 @@ import (
 
 // MockResourceAnalyzer implementa a interface ResourceAnalyzer para testes
 type MockResourceAnalyzer struct {
-	GetMetricsFunc       func(ctx context.Context, namespace, deployment string, period time.Duration) (*types.MetricsResponse, error)
-	GetTrendsFunc        func(ctx context.Context, namespace, deployment string, period time.Duration) (*types.TrendsResponse, error)
+	GetMetricsFunc       func(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error)
+	CalculateTrendsFunc  func(metrics *types.MetricsResponse, period time.Duration) *types.TrendsResponse
 	AnalyzeResourcesFunc func(current *types.CurrentMetrics, historical *types.HistoricalMetrics) *types.ResourceAnalysis
 	CalculateCostsFunc   func(ctx context.Context, current *types.CurrentMetrics, analysis *types.ResourceRecommendationAnalysis) (*types.CostAnalysis, error)
 	GenerateAlertsFunc   func(current *types.CurrentMetrics, historical *types.HistoricalMetrics) []types.Alert
 }
 
-func (m *MockResourceAnalyzer) GetMetrics(ctx context.Context, namespace, deployment string, period time.Duration) (*types.MetricsResponse, error) {
+func (m *MockResourceAnalyzer) GetMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error) {
 	if m.GetMetricsFunc != nil {
-		return m.GetMetricsFunc(ctx, namespace, deployment, period)
+		return m.GetMetricsFunc(ctx, namespace, kind, name, period)
 	}
 	return nil, nil
 }
 
-func (m *MockResourceAnalyzer) GetTrends(ctx context.Context, namespace, deployment string, period time.Duration) (*types.TrendsResponse, error) {
-	if m.GetTrendsFunc != nil {
-		return m.GetTrendsFunc(ctx, namespace, deployment, period)
+func (m *MockResourceAnalyzer) CalculateTrends(metrics *types.MetricsResponse, period time.Duration) *types.TrendsResponse {
+	if m.CalculateTrendsFunc != nil {
+		return m.CalculateTrendsFunc(metrics, period)
 	}
-	return nil, nil
+	return nil
 }
 
 func (m *MockResourceAnalyzer) AnalyzeResources(current *types.CurrentMetrics, historical *types.HistoricalMetrics) *types.ResourceAnalysis {
@@ -75,7 +75,7 @@ func TestAnalyzerHandler_AnalyzeResources(t *testing.T) {
 			deployment: "test-app",
 			period:     "24h",
 			setupMock: func(m *MockResourceAnalyzer) {
-				m.GetMetricsFunc = func(ctx context.Context, namespace, deployment string, period time.Duration) (*types.MetricsResponse, error) {
+				m.GetMetricsFunc = func(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error) {
 					return &types.MetricsResponse{
 						Current: &types.CurrentMetrics{
 							CPU:    &types.ResourceMetrics{Usage: 500, Request: 1000},
@@ -84,11 +84,11 @@ func TestAnalyzerHandler_AnalyzeResources(t *testing.T) {
 						Historical: &types.HistoricalMetrics{},
 					}, nil
 				}
-				m.GetTrendsFunc = func(ctx context.Context, namespace, deployment string, period time.Duration) (*types.TrendsResponse, error) {
+				m.CalculateTrendsFunc = func(metrics *types.MetricsResponse, period time.Duration) *types.TrendsResponse {
 					return &types.TrendsResponse{
 						CPU:    &types.TrendMetrics{Trend: 0.5},
 						Memory: &types.TrendMetrics{Trend: 0.3},
-					}, nil
+					}
 				}
 				m.AnalyzeResourcesFunc = func(current *types.CurrentMetrics, historical *types.HistoricalMetrics) *types.ResourceAnalysis {
 					return &types.ResourceAnalysis{Status: "normal"}
@@ -141,7 +141,7 @@ func TestAnalyzerHandler_AnalyzeResources(t *testing.T) {
 			handler := NewAnalyzerHandler(mock)
 
 			router := gin.New()
-			router.GET("/resources/:deployment/analysis", handler.AnalyzeResources)
+			router.GET("/resources/:kind/analysis", handler.AnalyzeResources)
 
 			// Criar request
 			url := "/resources/" + tt.deployment + "/analysis?namespace=" + tt.namespace
@@ -159,3 +159,105 @@ func TestAnalyzerHandler_AnalyzeResources(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzerHandler_AnalyzeResources_TrendsReuseMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	metrics := &types.MetricsResponse{
+		Current:    &types.CurrentMetrics{},
+		Historical: &types.HistoricalMetrics{},
+	}
+	calls := 0
+	mock := &MockResourceAnalyzer{
+		GetMetricsFunc: func(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error) {
+			calls++
+			return metrics, nil
+		},
+		CalculateTrendsFunc: func(received *types.MetricsResponse, period time.Duration) *types.TrendsResponse {
+			// As tendências usam as métricas já coletadas, sem uma nova coleta
+			assert.Same(t, metrics, received)
+			assert.Equal(t, 24*time.Hour, period)
+			return &types.TrendsResponse{CPU: &types.TrendMetrics{Trend: 0.5}}
+		},
+	}
+
+	router := gin.New()
+	router.GET("/resources/:kind/analysis", NewAnalyzerHandler(mock).AnalyzeResources)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/resources/test-app/analysis?namespace=default&period=24h", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAnalyzerHandler_AnalyzeResources_WorkloadKind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedKind   types.WorkloadKind
+		expectedName   string
+		expectedStatus int
+	}{
+		{
+			name:           "Sucesso - StatefulSet",
+			url:            "/resources/statefulsets/kafka/analysis?namespace=default&period=24h",
+			expectedKind:   types.WorkloadKindStatefulSet,
+			expectedName:   "kafka",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Sucesso - DaemonSet com abreviação",
+			url:            "/resources/ds/node-agent/analysis?namespace=kube-system&period=1h",
+			expectedKind:   types.WorkloadKindDaemonSet,
+			expectedName:   "node-agent",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Sucesso - Rota legada de Deployment",
+			url:            "/resources/api/analysis?namespace=default&period=24h",
+			expectedKind:   types.WorkloadKindDeployment,
+			expectedName:   "api",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Erro - Tipo de workload inválido",
+			url:            "/resources/cronjobs/backup/analysis?namespace=default&period=24h",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKind types.WorkloadKind
+			var gotName string
+			mock := &MockResourceAnalyzer{
+				GetMetricsFunc: func(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error) {
+					gotKind = kind
+					gotName = name
+					return &types.MetricsResponse{
+						Current:    &types.CurrentMetrics{},
+						Historical: &types.HistoricalMetrics{},
+					}, nil
+				},
+			}
+			handler := NewAnalyzerHandler(mock)
+
+			router := gin.New()
+			router.GET("/resources/:kind/:name/analysis", handler.AnalyzeResources)
+			router.GET("/resources/:kind/analysis", handler.AnalyzeResources)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedKind, gotKind)
+				assert.Equal(t, tt.expectedName, gotName)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// ClusterHandler é o handler para o relatório do cluster
+type ClusterHandler struct {
+	clusterAnalyzer analyzer.ClusterAnalyzer
+}
+
+// NewClusterHandler cria uma nova instância do ClusterHandler
+func NewClusterHandler(clusterAnalyzer analyzer.ClusterAnalyzer) *ClusterHandler {
+	return &ClusterHandler{
+		clusterAnalyzer: clusterAnalyzer,
+	}
+}
+
+// ClusterReportRequest representa o request para o relatório do cluster
+type ClusterReportRequest struct {
+	Period  string `form:"period" binding:"required"`
+	GroupBy string `form:"groupBy"`
+	Top     int    `form:"top" binding:"min=0"`
+}
+
+// GetReport gera o relatório de inventário e showback do cluster
+func (h *ClusterHandler) GetReport(c *gin.Context) {
+	var req ClusterReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Parâmetros inválidos", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil {
+		err = errors.NewInvalidConfigurationError("period", "período inválido")
+		logger.Error("Período inválido", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Requisição de relatório do cluster recebida",
+		logger.NewField("period", period),
+		logger.NewField("group_by", req.GroupBy),
+		logger.NewField("top", req.Top),
+	)
+
+	report, err := h.clusterAnalyzer.GenerateClusterReport(c.Request.Context(), period, req.GroupBy, req.Top)
+	if err != nil {
+		logger.Error("Erro ao gerar relatório do cluster", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Enviando resposta",
+		logger.NewField("workloads", report.Summary.Workloads),
+		logger.NewField("failed", report.Summary.Failed),
+	)
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockClusterAnalyzer implementa a interface ClusterAnalyzer para testes
+type MockClusterAnalyzer struct {
+	GenerateClusterReportFunc func(ctx context.Context, period time.Duration, groupBy string, top int) (*types.ClusterReport, error)
+}
+
+func (m *MockClusterAnalyzer) GenerateClusterReport(ctx context.Context, period time.Duration, groupBy string, top int) (*types.ClusterReport, error) {
+	if m.GenerateClusterReportFunc != nil {
+		return m.GenerateClusterReportFunc(ctx, period, groupBy, top)
+	}
+	return nil, nil
+}
+
+func TestClusterHandler_GetReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{name: "Sucesso - Relatório com agrupamento", query: "?period=24h&groupBy=squad&top=5", expectedStatus: http.StatusOK},
+		{name: "Erro - Período não informado", query: "", expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Período inválido", query: "?period=invalid", expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Top negativo", query: "?period=24h&top=-1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnalyzer := &MockClusterAnalyzer{
+				GenerateClusterReportFunc: func(ctx context.Context, period time.Duration, groupBy string, top int) (*types.ClusterReport, error) {
+					assert.Equal(t, 24*time.Hour, period)
+					assert.Equal(t, "squad", groupBy)
+					assert.Equal(t, 5, top)
+					return &types.ClusterReport{
+						GroupBy: groupBy,
+						Groups:  []*types.GroupSummary{{Name: "payments", Workloads: 2}},
+					}, nil
+				},
+			}
+
+			router := gin.New()
+			router.GET("/cluster/report", NewClusterHandler(mockAnalyzer).GetReport)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/cluster/report"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if w.Code == http.StatusOK {
+				var response types.ClusterReport
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "squad", response.GroupBy)
+				assert.Len(t, response.Groups, 1)
+			}
+		})
+	}
+}
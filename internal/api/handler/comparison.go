@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/api/middleware"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// ComparisonHandler é o handler para comparação de workloads entre clusters
+type ComparisonHandler struct {
+	clusterComparer analyzer.ClusterComparer
+	clusters        middleware.ClusterRegistry
+}
+
+// NewComparisonHandler cria uma nova instância do ComparisonHandler
+func NewComparisonHandler(clusterComparer analyzer.ClusterComparer, clusters middleware.ClusterRegistry) *ComparisonHandler {
+	return &ComparisonHandler{
+		clusterComparer: clusterComparer,
+		clusters:        clusters,
+	}
+}
+
+// ComparisonRequest representa o request para comparação entre clusters
+type ComparisonRequest struct {
+	Namespace string `form:"namespace" binding:"required"`
+	Period    string `form:"period" binding:"required"`
+	Clusters  string `form:"clusters" binding:"required"` // separados por vírgula (ex: prod-us,staging)
+}
+
+// parseClusters separa a lista de clusters, ignorando espaços e itens vazios
+func parseClusters(value string) []string {
+	var clusters []string
+	for _, cluster := range strings.Split(value, ",") {
+		if cluster = strings.TrimSpace(cluster); cluster != "" {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// CompareClusters compara o mesmo workload entre os clusters informados
+func (h *ComparisonHandler) CompareClusters(c *gin.Context) {
+	var req ComparisonRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Parâmetros inválidos", err,
+			logger.NewField("namespace", req.Namespace),
+			logger.NewField("clusters", req.Clusters),
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	kind, name, err := workloadFromParams(c)
+	if err != nil {
+		logger.Error("Workload inválido", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil {
+		err = errors.NewInvalidConfigurationError("period", "período inválido")
+		logger.Error("Período inválido", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	clusters := parseClusters(req.Clusters)
+	for _, cluster := range clusters {
+		if !h.clusters.HasCluster(cluster) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "cluster não configurado: " + cluster,
+			})
+			return
+		}
+	}
+
+	logger.Info("Requisição de comparação entre clusters recebida",
+		logger.NewField("namespace", req.Namespace),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+		logger.NewField("clusters", clusters),
+		logger.NewField("period", period),
+	)
+
+	comparison, err := h.clusterComparer.CompareClusters(c.Request.Context(), clusters, req.Namespace, kind, name, period)
+	if err != nil {
+		logger.Error("Erro ao comparar clusters", err,
+			logger.NewField("namespace", req.Namespace),
+			logger.NewField("name", name),
+		)
+		status := http.StatusInternalServerError
+		if errors.IsInvalidConfiguration(err) {
+			status = http.StatusBadRequest
+		} else if errors.IsResourceNotFound(err) {
+			status = http.StatusNotFound
+		} else if errors.IsUnavailableMetrics(err) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Enviando resposta",
+		logger.NewField("name", name),
+		logger.NewField("clusters", len(comparison.Clusters)),
+		logger.NewField("findings", len(comparison.Findings)),
+	)
+
+	c.JSON(http.StatusOK, comparison)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockClusterComparer implementa a interface ClusterComparer para testes
+type MockClusterComparer struct {
+	CompareClustersFunc func(ctx context.Context, clusters []string, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.ClusterComparison, error)
+}
+
+func (m *MockClusterComparer) CompareClusters(ctx context.Context, clusters []string, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.ClusterComparison, error) {
+	if m.CompareClustersFunc != nil {
+		return m.CompareClustersFunc(ctx, clusters, namespace, kind, name, period)
+	}
+	return nil, nil
+}
+
+func TestComparisonHandler_CompareClusters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	clusters := collector.NewClusterCollector("prod-us")
+	clusters.Register("prod-us", nil)
+	clusters.Register("staging", nil)
+
+	tests := []struct {
+		name           string
+		query          string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Sucesso - Comparação entre clusters", query: "?namespace=default&period=24h&clusters=prod-us, staging", expectedStatus: http.StatusOK},
+		{name: "Erro - Clusters não informados", query: "?namespace=default&period=24h", expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Período inválido", query: "?namespace=default&period=invalid&clusters=prod-us,staging", expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Cluster não configurado", query: "?namespace=default&period=24h&clusters=prod-us,prod-eu", expectedStatus: http.StatusNotFound},
+		{
+			name:           "Erro - Apenas um cluster",
+			query:          "?namespace=default&period=24h&clusters=prod-us",
+			err:            errors.NewInvalidConfigurationError("clusters", "informe ao menos dois clusters"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockComparer := &MockClusterComparer{
+				CompareClustersFunc: func(ctx context.Context, clusters []string, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.ClusterComparison, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					assert.Equal(t, []string{"prod-us", "staging"}, clusters)
+					assert.Equal(t, types.WorkloadKindStatefulSet, kind)
+					assert.Equal(t, "kafka", name)
+					return &types.ClusterComparison{
+						Name:     name,
+						Baseline: clusters[0],
+						Clusters: []*types.ClusterWorkload{{Cluster: "prod-us"}, {Cluster: "staging"}},
+					}, nil
+				},
+			}
+
+			router := gin.New()
+			router.GET("/compare/:kind/:name", NewComparisonHandler(mockComparer, clusters).CompareClusters)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/compare/statefulsets/kafka"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response types.ClusterComparison
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "prod-us", response.Baseline)
+				assert.Len(t, response.Clusters, 2)
+			}
+		})
+	}
+}
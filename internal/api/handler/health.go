@@ -6,6 +6,8 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/k8s"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/mimir"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/response"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/version"
@@ -38,16 +40,22 @@ type Status struct {
 	Status  string `json:"status" example:"healthy"`
 	Message string `json:"message,omitempty" example:"conectado com sucesso"`
 	Error   string `json:"error,omitempty" example:"timeout ao conectar"`
+	// CircuitBreaker é o estado do circuit breaker da dependência, quando existir
+	CircuitBreaker string `json:"circuit_breaker,omitempty" example:"closed"`
+	// Cache é o estado do cache de informers do Kubernetes (disabled, syncing ou synced)
+	Cache string `json:"cache,omitempty" example:"synced"`
 }
 
 // K8sClient interface para o cliente Kubernetes
 type K8sClient interface {
 	CheckConnection(ctx context.Context) error
+	CacheState() (string, error)
 }
 
 // MimirClient interface para o cliente Mimir
 type MimirClient interface {
 	CheckConnection(ctx context.Context) error
+	CircuitBreakerState() string
 }
 
 // HealthHandler é o handler para health check
@@ -112,29 +120,48 @@ func (h *HealthHandler) Check(c *gin.Context) {
 func (h *HealthHandler) checkDependencies(ctx context.Context) map[string]Status {
 	dependencies := make(map[string]Status)
 
-	// Verifica Kubernetes
+	// Verifica Kubernetes; com falhas na sincronização do cache, as leituras vão direto à API
+	cache, syncErr := h.k8sClient.CacheState()
 	if err := h.k8sClient.CheckConnection(ctx); err != nil {
 		dependencies["kubernetes"] = Status{
 			Status: "unhealthy",
 			Error:  err.Error(),
+			Cache:  cache,
+		}
+	} else if cache == k8s.CacheSyncing && syncErr != nil {
+		dependencies["kubernetes"] = Status{
+			Status:  "degraded",
+			Message: "cache de informers não sincronizado; leituras direto na API",
+			Error:   syncErr.Error(),
+			Cache:   cache,
 		}
 	} else {
 		dependencies["kubernetes"] = Status{
 			Status:  "healthy",
 			Message: "conectado ao cluster",
+			Cache:   cache,
 		}
 	}
 
-	// Verifica Mimir
+	// Verifica Mimir; com o circuit breaker aberto, as consultas falham mesmo com o serviço acessível
+	breaker := h.mimirClient.CircuitBreakerState()
 	if err := h.mimirClient.CheckConnection(ctx); err != nil {
 		dependencies["mimir"] = Status{
-			Status: "unhealthy",
-			Error:  err.Error(),
+			Status:         "unhealthy",
+			Error:          err.Error(),
+			CircuitBreaker: breaker,
+		}
+	} else if breaker == mimir.CircuitOpen {
+		dependencies["mimir"] = Status{
+			Status:         "degraded",
+			Message:        "circuit breaker aberto após falhas consecutivas",
+			CircuitBreaker: breaker,
 		}
 	} else {
 		dependencies["mimir"] = Status{
-			Status:  "healthy",
-			Message: "conectado ao serviço",
+			Status:         "healthy",
+			Message:        "conectado ao serviço",
+			CircuitBreaker: breaker,
 		}
 	}
 
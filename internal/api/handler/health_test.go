@@ -3,10 +3,13 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/k8s"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/mimir"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +17,8 @@ import (
 // MockK8sClient implementa a interface K8sClient para testes
 type MockK8sClient struct {
 	CheckConnectionFunc func(ctx context.Context) error
+	Cache               string
+	CacheErr            error
 }
 
 func (m *MockK8sClient) CheckConnection(ctx context.Context) error {
@@ -23,9 +28,17 @@ func (m *MockK8sClient) CheckConnection(ctx context.Context) error {
 	return nil
 }
 
+func (m *MockK8sClient) CacheState() (string, error) {
+	if m.Cache == "" {
+		return k8s.CacheSynced, nil
+	}
+	return m.Cache, m.CacheErr
+}
+
 // MockMimirClient implementa a interface MimirClient para testes
 type MockMimirClient struct {
 	CheckConnectionFunc func(ctx context.Context) error
+	BreakerState        string
 }
 
 func (m *MockMimirClient) CheckConnection(ctx context.Context) error {
@@ -35,6 +48,13 @@ func (m *MockMimirClient) CheckConnection(ctx context.Context) error {
 	return nil
 }
 
+func (m *MockMimirClient) CircuitBreakerState() string {
+	if m.BreakerState == "" {
+		return mimir.CircuitClosed
+	}
+	return m.BreakerState
+}
+
 func TestHealthHandler_Check(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -77,6 +97,51 @@ func TestHealthHandler_Check(t *testing.T) {
 				mimir := deps["mimir"].(map[string]interface{})
 				assert.Equal(t, "healthy", mimir["status"])
 				assert.Equal(t, "conectado ao serviço", mimir["message"])
+				assert.Equal(t, "closed", mimir["circuit_breaker"])
+			},
+		},
+		{
+			name: "Degradado - Circuit breaker do Mimir aberto",
+			setupMocks: func(k8s *MockK8sClient, mimir *MockMimirClient) {
+				mimir.BreakerState = "open"
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, w.Code)
+
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, "degraded", data["status"])
+
+				deps := data["dependencies"].(map[string]interface{})
+				mimir := deps["mimir"].(map[string]interface{})
+				assert.Equal(t, "degraded", mimir["status"])
+				assert.Equal(t, "open", mimir["circuit_breaker"])
+			},
+		},
+		{
+			name: "Degradado - Cache de informers sem sincronizar",
+			setupMocks: func(k8s *MockK8sClient, mimir *MockMimirClient) {
+				k8s.Cache = "syncing"
+				k8s.CacheErr = errors.New("pods is forbidden")
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, "degraded", data["status"])
+
+				deps := data["dependencies"].(map[string]interface{})
+				k8s := deps["kubernetes"].(map[string]interface{})
+				assert.Equal(t, "degraded", k8s["status"])
+				assert.Equal(t, "syncing", k8s["cache"])
+				assert.Equal(t, "pods is forbidden", k8s["error"])
 			},
 		},
 		{
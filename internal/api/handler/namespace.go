@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// NamespaceHandler é o handler para análise agregada de namespaces
+type NamespaceHandler struct {
+	namespaceAnalyzer analyzer.NamespaceAnalyzer
+}
+
+// NewNamespaceHandler cria uma nova instância do NamespaceHandler
+func NewNamespaceHandler(namespaceAnalyzer analyzer.NamespaceAnalyzer) *NamespaceHandler {
+	return &NamespaceHandler{
+		namespaceAnalyzer: namespaceAnalyzer,
+	}
+}
+
+// NamespaceAnalysisRequest representa o request para análise de namespace
+type NamespaceAnalysisRequest struct {
+	Period string `form:"period" binding:"required"`
+}
+
+// AnalyzeNamespace analisa todos os workloads de um namespace
+func (h *NamespaceHandler) AnalyzeNamespace(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	var req NamespaceAnalysisRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Parâmetros inválidos", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil {
+		err = errors.NewInvalidConfigurationError("period", "período inválido")
+		logger.Error("Período inválido", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Requisição de análise de namespace recebida",
+		logger.NewField("namespace", namespace),
+		logger.NewField("period", period),
+	)
+
+	analysis, err := h.namespaceAnalyzer.AnalyzeNamespace(c.Request.Context(), namespace, period)
+	if err != nil {
+		logger.Error("Erro ao analisar namespace", err,
+			logger.NewField("namespace", namespace),
+		)
+		status := http.StatusInternalServerError
+		if errors.IsResourceNotFound(err) {
+			status = http.StatusNotFound
+		} else if errors.IsUnavailableMetrics(err) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Enviando resposta",
+		logger.NewField("namespace", namespace),
+		logger.NewField("workloads", analysis.Summary.Workloads),
+		logger.NewField("failed", analysis.Summary.Failed),
+	)
+
+	c.JSON(http.StatusOK, analysis)
+}
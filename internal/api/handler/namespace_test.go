@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockNamespaceAnalyzer implementa a interface NamespaceAnalyzer para testes
+type MockNamespaceAnalyzer struct {
+	AnalyzeNamespaceFunc func(ctx context.Context, namespace string, period time.Duration) (*types.NamespaceAnalysis, error)
+}
+
+func (m *MockNamespaceAnalyzer) AnalyzeNamespace(ctx context.Context, namespace string, period time.Duration) (*types.NamespaceAnalysis, error) {
+	if m.AnalyzeNamespaceFunc != nil {
+		return m.AnalyzeNamespaceFunc(ctx, namespace, period)
+	}
+	return nil, nil
+}
+
+func TestNamespaceHandler_AnalyzeNamespace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockNamespaceAnalyzer)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Sucesso - Análise do namespace",
+			query: "?period=24h",
+			setupMock: func(m *MockNamespaceAnalyzer) {
+				m.AnalyzeNamespaceFunc = func(ctx context.Context, namespace string, period time.Duration) (*types.NamespaceAnalysis, error) {
+					assert.Equal(t, "default", namespace)
+					assert.Equal(t, 24*time.Hour, period)
+					return &types.NamespaceAnalysis{
+						Namespace: namespace,
+						Summary:   types.NamespaceSummary{Workloads: 1, Analyzed: 1},
+						Workloads: []*types.WorkloadSummary{
+							{WorkloadRef: types.WorkloadRef{Kind: types.WorkloadKindDeployment, Name: "api"}},
+						},
+					}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response types.NamespaceAnalysis
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "default", response.Namespace)
+				assert.Len(t, response.Workloads, 1)
+				assert.Equal(t, "api", response.Workloads[0].Name)
+			},
+		},
+		{
+			name:           "Erro - Período não informado",
+			query:          "",
+			setupMock:      func(m *MockNamespaceAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Erro - Período inválido",
+			query:          "?period=invalid",
+			setupMock:      func(m *MockNamespaceAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Erro - Namespace não encontrado",
+			query: "?period=24h",
+			setupMock: func(m *MockNamespaceAnalyzer) {
+				m.AnalyzeNamespaceFunc = func(ctx context.Context, namespace string, period time.Duration) (*types.NamespaceAnalysis, error) {
+					return nil, errors.NewResourceNotFoundError("deployments", "erro ao listar deployments")
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnalyzer := &MockNamespaceAnalyzer{}
+			tt.setupMock(mockAnalyzer)
+
+			router := gin.New()
+			router.GET("/namespaces/:namespace/analysis", NewNamespaceHandler(mockAnalyzer).AnalyzeNamespace)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/namespaces/default/analysis"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
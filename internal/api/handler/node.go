@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// NodeHandler é o handler para análise de capacidade dos nodes
+type NodeHandler struct {
+	nodeAnalyzer analyzer.NodeAnalyzer
+}
+
+// NewNodeHandler cria uma nova instância do NodeHandler
+func NewNodeHandler(nodeAnalyzer analyzer.NodeAnalyzer) *NodeHandler {
+	return &NodeHandler{
+		nodeAnalyzer: nodeAnalyzer,
+	}
+}
+
+// NodeAnalysisRequest representa o request para análise de nodes
+type NodeAnalysisRequest struct {
+	Period string `form:"period" binding:"required"`
+}
+
+// AnalyzeNodes analisa a capacidade dos nodes do cluster
+func (h *NodeHandler) AnalyzeNodes(c *gin.Context) {
+	var req NodeAnalysisRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Parâmetros inválidos", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil {
+		err = errors.NewInvalidConfigurationError("period", "período inválido")
+		logger.Error("Período inválido", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Requisição de análise de nodes recebida",
+		logger.NewField("period", period),
+	)
+
+	analysis, err := h.nodeAnalyzer.AnalyzeNodes(c.Request.Context(), period)
+	if err != nil {
+		logger.Error("Erro ao analisar nodes", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Enviando resposta",
+		logger.NewField("nodes", analysis.Summary.Nodes),
+	)
+
+	c.JSON(http.StatusOK, analysis)
+}
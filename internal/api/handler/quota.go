@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaHandler é o handler para análise de ResourceQuotas e LimitRanges
+type QuotaHandler struct {
+	quotaAnalyzer analyzer.QuotaAnalyzer
+}
+
+// NewQuotaHandler cria uma nova instância do QuotaHandler
+func NewQuotaHandler(quotaAnalyzer analyzer.QuotaAnalyzer) *QuotaHandler {
+	return &QuotaHandler{
+		quotaAnalyzer: quotaAnalyzer,
+	}
+}
+
+// AnalyzeQuotas analisa os ResourceQuotas e LimitRanges de um namespace
+func (h *QuotaHandler) AnalyzeQuotas(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	var req NamespaceAnalysisRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Parâmetros inválidos", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil {
+		err = errors.NewInvalidConfigurationError("period", "período inválido")
+		logger.Error("Período inválido", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Requisição de análise de quotas recebida",
+		logger.NewField("namespace", namespace),
+		logger.NewField("period", period),
+	)
+
+	analysis, err := h.quotaAnalyzer.AnalyzeQuotas(c.Request.Context(), namespace, period)
+	if err != nil {
+		logger.Error("Erro ao analisar quotas", err,
+			logger.NewField("namespace", namespace),
+		)
+		status := http.StatusInternalServerError
+		if errors.IsResourceNotFound(err) {
+			status = http.StatusNotFound
+		} else if errors.IsUnavailableMetrics(err) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Enviando resposta",
+		logger.NewField("namespace", namespace),
+		logger.NewField("quotas", len(analysis.Quotas)),
+		logger.NewField("limit_ranges", len(analysis.LimitRanges)),
+	)
+
+	c.JSON(http.StatusOK, analysis)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// SimulationHandler é o handler para simulação de configurações de HPA
+type SimulationHandler struct {
+	hpaSimulator analyzer.HPASimulator
+}
+
+// NewSimulationHandler cria uma nova instância do SimulationHandler
+func NewSimulationHandler(hpaSimulator analyzer.HPASimulator) *SimulationHandler {
+	return &SimulationHandler{
+		hpaSimulator: hpaSimulator,
+	}
+}
+
+// SimulateHPAQuery representa os parâmetros de query da simulação de HPA
+type SimulateHPAQuery struct {
+	// Kind é o tipo do workload (padrão: deployments)
+	Kind string `form:"kind"`
+}
+
+// SimulateHPARequest representa a configuração proposta enviada no corpo da simulação de HPA
+type SimulateHPARequest struct {
+	Period        string             `json:"period" binding:"required"`
+	CPURequest    float64            `json:"cpuRequest" binding:"required,gt=0"` // em milicores
+	MemoryRequest float64            `json:"memoryRequest" binding:"gte=0"`      // em Mi, opcional
+	TargetCPU     int32              `json:"targetCPUUtilization" binding:"required,gt=0"`
+	MinReplicas   int                `json:"minReplicas" binding:"required,gte=1"`
+	MaxReplicas   int                `json:"maxReplicas" binding:"required,gtefield=MinReplicas"`
+	Behavior      *types.HPABehavior `json:"behavior"`
+}
+
+// SimulateHPA reproduz o histórico de um workload com a configuração de HPA proposta
+func (h *SimulationHandler) SimulateHPA(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("deployment")
+
+	var query SimulateHPAQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("Parâmetros inválidos", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	kind := types.WorkloadKindDeployment
+	if query.Kind != "" {
+		parsed, ok := types.ParseWorkloadKind(query.Kind)
+		if !ok {
+			err := errors.NewInvalidConfigurationError("kind", "tipo de workload não suportado: "+query.Kind)
+			logger.Error("Workload inválido", err)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		kind = parsed
+	}
+
+	var req SimulateHPARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Corpo da requisição inválido", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("name", name),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetros inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil {
+		err = errors.NewInvalidConfigurationError("period", "período inválido")
+		logger.Error("Período inválido", err,
+			logger.NewField("period", req.Period),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		logger.Error("Configuração proposta inválida", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("name", name),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Requisição de simulação de HPA recebida",
+		logger.NewField("namespace", namespace),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+		logger.NewField("period", period),
+	)
+
+	input := types.HPASimulationInput{
+		Settings: types.HPASettings{
+			TargetCPU:   req.TargetCPU,
+			MinReplicas: req.MinReplicas,
+			MaxReplicas: req.MaxReplicas,
+			CPURequest:  req.CPURequest,
+		},
+		MemoryRequest: req.MemoryRequest,
+		Behavior:      req.Behavior,
+	}
+
+	simulation, err := h.hpaSimulator.SimulateHPA(c.Request.Context(), namespace, kind, name, period, input)
+	if err != nil {
+		logger.Error("Erro ao simular HPA", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		// A configuração proposta já foi validada; erros de configuração aqui vêm do backend de métricas
+		status := http.StatusInternalServerError
+		switch {
+		case errors.IsResourceNotFound(err):
+			status = http.StatusNotFound
+		case errors.IsInvalidMetrics(err):
+			status = http.StatusUnprocessableEntity
+		case errors.IsUnavailableMetrics(err):
+			status = http.StatusServiceUnavailable
+		case errors.IsInvalidConfiguration(err):
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Enviando resposta",
+		logger.NewField("namespace", namespace),
+		logger.NewField("name", name),
+		logger.NewField("time_at_max_seconds", simulation.Proposed.TimeAtMaxSeconds),
+	)
+
+	c.JSON(http.StatusOK, simulation)
+}
+
+// validate valida o request, o alvo de utilização e o mínimo e máximo de réplicas propostos
+func (r SimulateHPARequest) validate() error {
+	switch {
+	case r.CPURequest <= 0:
+		return errors.NewInvalidConfigurationError("cpuRequest", "request de CPU deve ser maior que zero")
+	case r.MemoryRequest < 0:
+		return errors.NewInvalidConfigurationError("memoryRequest", "request de memória não pode ser negativo")
+	case r.TargetCPU <= 0:
+		return errors.NewInvalidConfigurationError("targetCPUUtilization", "alvo de utilização deve ser maior que zero")
+	case r.MinReplicas < 1:
+		return errors.NewInvalidConfigurationError("minReplicas", "mínimo de réplicas deve ser maior que zero")
+	case r.MaxReplicas < r.MinReplicas:
+		return errors.NewInvalidConfigurationError("maxReplicas", "máximo de réplicas deve ser maior ou igual ao mínimo")
+	}
+	return nil
+}
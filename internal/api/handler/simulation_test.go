@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockHPASimulator implementa a interface HPASimulator para testes
+type MockHPASimulator struct {
+	SimulateHPAFunc func(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration, input types.HPASimulationInput) (*types.HPASimulation, error)
+}
+
+func (m *MockHPASimulator) SimulateHPA(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration, input types.HPASimulationInput) (*types.HPASimulation, error) {
+	if m.SimulateHPAFunc != nil {
+		return m.SimulateHPAFunc(ctx, namespace, kind, name, period, input)
+	}
+	return nil, nil
+}
+
+func TestSimulationHandler_SimulateHPA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validBody := `{"period":"24h","cpuRequest":500,"targetCPUUtilization":70,"minReplicas":2,"maxReplicas":6}`
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		err            error
+		expectedKind   types.WorkloadKind
+		expectedStatus int
+	}{
+		{name: "Sucesso - Deployment", path: "/resources/default/api/simulate/hpa", body: validBody, expectedKind: types.WorkloadKindDeployment, expectedStatus: http.StatusOK},
+		{name: "Sucesso - StatefulSet", path: "/resources/default/api/simulate/hpa?kind=statefulsets", body: validBody, expectedKind: types.WorkloadKindStatefulSet, expectedStatus: http.StatusOK},
+		{name: "Erro - Tipo inválido", path: "/resources/default/api/simulate/hpa?kind=jobs", body: validBody, expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Máximo menor que mínimo", path: "/resources/default/api/simulate/hpa", body: `{"period":"24h","cpuRequest":500,"targetCPUUtilization":70,"minReplicas":4,"maxReplicas":2}`, expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Período inválido", path: "/resources/default/api/simulate/hpa", body: `{"period":"invalid","cpuRequest":500,"targetCPUUtilization":70,"minReplicas":2,"maxReplicas":6}`, expectedStatus: http.StatusBadRequest},
+		{name: "Erro - Workload não encontrado", path: "/resources/default/api/simulate/hpa", body: validBody, err: errors.NewResourceNotFoundError("deployment", "não encontrado"), expectedKind: types.WorkloadKindDeployment, expectedStatus: http.StatusNotFound},
+		{name: "Erro - Falha na consulta ao Mimir", path: "/resources/default/api/simulate/hpa", body: validBody, err: errors.NewInvalidConfigurationError("mimir", "query failed"), expectedKind: types.WorkloadKindDeployment, expectedStatus: http.StatusBadGateway},
+		{name: "Erro - Mimir indisponível", path: "/resources/default/api/simulate/hpa", body: validBody, err: errors.NewUnavailableMetricsError("mimir", "timeout"), expectedKind: types.WorkloadKindDeployment, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Erro - Histórico insuficiente", path: "/resources/default/api/simulate/hpa", body: validBody, err: errors.NewInvalidMetricsError("cpu", "histórico insuficiente"), expectedKind: types.WorkloadKindDeployment, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSimulator := &MockHPASimulator{
+				SimulateHPAFunc: func(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration, input types.HPASimulationInput) (*types.HPASimulation, error) {
+					assert.Equal(t, "default", namespace)
+					assert.Equal(t, tt.expectedKind, kind)
+					assert.Equal(t, "api", name)
+					assert.Equal(t, 24*time.Hour, period)
+					assert.Equal(t, types.HPASettings{TargetCPU: 70, MinReplicas: 2, MaxReplicas: 6, CPURequest: 500}, input.Settings)
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &types.HPASimulation{
+						Namespace: namespace,
+						Kind:      kind,
+						Name:      name,
+						Proposed:  &types.HPAReplay{Settings: input.Settings, TimeAtMaxSeconds: 600},
+					}, nil
+				},
+			}
+
+			router := gin.New()
+			router.POST("/resources/:namespace/:deployment/simulate/hpa", NewSimulationHandler(mockSimulator).SimulateHPA)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if w.Code == http.StatusOK {
+				var response types.HPASimulation
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, int64(600), response.Proposed.TimeAtMaxSeconds)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/gin-gonic/gin"
+)
+
+// ClusterParam é o parâmetro de query que seleciona o cluster analisado
+const ClusterParam = "cluster"
+
+// ClusterRegistry informa os clusters configurados
+type ClusterRegistry interface {
+	HasCluster(cluster string) bool
+	DefaultCluster() string
+}
+
+// ClusterSelector lê o parâmetro cluster e o propaga no contexto da requisição, direcionando a
+// coleta ao cluster escolhido. Sem o parâmetro, usa o cluster padrão; clusters não configurados
+// retornam 404.
+func ClusterSelector(registry ClusterRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cluster := c.Query(ClusterParam)
+		if cluster == "" {
+			cluster = registry.DefaultCluster()
+		}
+		if !registry.HasCluster(cluster) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"error": "cluster não configurado: " + cluster,
+			})
+			return
+		}
+
+		c.Set("Cluster", cluster)
+		c.Request = c.Request.WithContext(collector.WithCluster(c.Request.Context(), cluster))
+		c.Next()
+	}
+}
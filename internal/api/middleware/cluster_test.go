@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterSelector(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	clusters := collector.NewClusterCollector("staging")
+	clusters.Register("staging", nil)
+	clusters.Register("prod-us", nil)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedCluster string
+	}{
+		{name: "Sucesso - Cluster padrão", query: "", expectedStatus: http.StatusOK, expectedCluster: "staging"},
+		{name: "Sucesso - Cluster selecionado", query: "?cluster=prod-us", expectedStatus: http.StatusOK, expectedCluster: "prod-us"},
+		{name: "Erro - Cluster não configurado", query: "?cluster=prod-eu", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster string
+			router := gin.New()
+			router.Use(ClusterSelector(clusters))
+			router.GET("/test", func(c *gin.Context) {
+				cluster = collector.ClusterFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/test"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedCluster, cluster)
+		})
+	}
+}
@@ -3,40 +3,74 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/api/handler"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/api/middleware"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/analyzer"
-	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/k8s"
-	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/mimir"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupRoutes configura todas as rotas da API
-func SetupRoutes(router *gin.Engine, k8sClient *k8s.Client, mimirClient *mimir.Client, analyzerService analyzer.ResourceAnalyzer) {
+// As rotas de análise aceitam o parâmetro cluster, que seleciona um dos clusters configurados.
+// O prazo de cada requisição é repassado às coletas pelo contexto: requestTimeout nas rotas de
+// um workload e aggregateTimeout nas análises agregadas, que analisam vários workloads.
+func SetupRoutes(router *gin.Engine, clusters middleware.ClusterRegistry, analyzerService analyzer.Analyzer, healthHandler *handler.HealthHandler, requestTimeout, aggregateTimeout time.Duration) {
 	// Configura middlewares globais
 	router.Use(middleware.RequestLogger())
 	router.Use(middleware.ErrorLogger())
 	router.Use(middleware.RecoveryLogger())
+	withTimeout := middleware.RequestTimeout(requestTimeout)
+	withAggregateTimeout := middleware.RequestTimeout(aggregateTimeout)
 
 	// Configura os handlers
 	analyzerHandler := handler.NewAnalyzerHandler(analyzerService)
+	namespaceHandler := handler.NewNamespaceHandler(analyzerService)
+	quotaHandler := handler.NewQuotaHandler(analyzerService)
+	clusterHandler := handler.NewClusterHandler(analyzerService)
+	nodeHandler := handler.NewNodeHandler(analyzerService)
+	simulationHandler := handler.NewSimulationHandler(analyzerService)
+	comparisonHandler := handler.NewComparisonHandler(analyzerService, clusters)
 
 	// Grupo de rotas v1
 	v1 := router.Group("/api/v1")
 	{
+		// Rotas de análise, direcionadas ao cluster do parâmetro cluster (ex: ?cluster=prod-us)
+		analysis := v1.Group("", middleware.ClusterSelector(clusters))
+
 		// Endpoints de recursos
-		resources := v1.Group("/resources")
+		resources := analysis.Group("/resources", withTimeout)
 		{
-			// Análise de recursos
-			resources.GET("/:deployment/analysis", analyzerHandler.AnalyzeResources)
+			// Análise de recursos por tipo de workload
+			// (ex: /resources/deployments/api/analysis, /resources/statefulsets/kafka/analysis)
+			resources.GET("/:kind/:name/analysis", analyzerHandler.AnalyzeResources)
+
+			// Rota legada de análise de Deployments (ex: /resources/api/analysis?namespace=default)
+			resources.GET("/:kind/analysis", analyzerHandler.AnalyzeResources)
+
+			// Replay do histórico com uma configuração de HPA proposta
+			// (ex: POST /resources/default/api/simulate/hpa?kind=statefulsets)
+			resources.POST("/:namespace/:deployment/simulate/hpa", simulationHandler.SimulateHPA)
 		}
 
-		// Health check
-		v1.GET("/health", func(c *gin.Context) {
-			c.JSON(200, gin.H{
-				"status": "ok",
-			})
-		})
+		// Análise agregada de todos os workloads de um namespace
+		analysis.GET("/namespaces/:namespace/analysis", withAggregateTimeout, namespaceHandler.AnalyzeNamespace)
+
+		// Folga e recomendação dos ResourceQuotas e padrões dos LimitRanges de um namespace
+		analysis.GET("/namespaces/:namespace/quotas", withTimeout, quotaHandler.AnalyzeQuotas)
+
+		// Inventário e showback de todos os namespaces do cluster
+		analysis.GET("/cluster/report", withAggregateTimeout, clusterHandler.GetReport)
+
+		// Capacidade dos nodes e simulação de bin-packing
+		analysis.GET("/nodes/analysis", withAggregateTimeout, nodeHandler.AnalyzeNodes)
+
+		// Comparação do mesmo workload entre clusters, que seleciona os clusters pelo parâmetro clusters
+		// (ex: /compare/deployments/api?namespace=default&clusters=prod-us,staging&period=24h)
+		v1.GET("/compare/:kind/:name", withTimeout, comparisonHandler.CompareClusters)
+
+		// Health check, com a conexão e o circuit breaker das dependências do cluster padrão
+		v1.GET("/health", withTimeout, healthHandler.Check)
 	}
 }
@@ -72,6 +72,15 @@ func NewInvalidConfigurationError(resource, message string) error {
 	}
 }
 
+// NewUnavailableMetricsError creates a new unavailable metrics error
+func NewUnavailableMetricsError(resource, message string) error {
+	return &ResourceError{
+		Resource: resource,
+		Message:  message,
+		Err:      ErrUnavailableMetrics,
+	}
+}
+
 // IsResourceNotFound checks if the error is of type ErrResourceNotFound
 func IsResourceNotFound(err error) bool {
 	return errors.Is(err, ErrResourceNotFound)
@@ -77,6 +77,13 @@ func TestNewInvalidConfigurationError(t *testing.T) {
 	}
 }
 
+func TestNewUnavailableMetricsError(t *testing.T) {
+	got := NewUnavailableMetricsError("kubernetes", "cache not synced")
+	if !errors.Is(got, ErrUnavailableMetrics) {
+		t.Error("NewUnavailableMetricsError() did not return an ErrUnavailableMetrics")
+	}
+}
+
 func TestErrorChecks(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -279,6 +279,25 @@ func TestCalculateUtilizationTrend(t *testing.T) {
 	}
 }
 
+func TestCalculateTrends(t *testing.T) {
+	// Sem collector: as tendências vêm apenas das métricas informadas
+	service := &Service{}
+	metrics := &types.MetricsResponse{
+		Historical: &types.HistoricalMetrics{
+			CPU:    []*types.ResourceMetrics{{Utilization: 40}, {Utilization: 50}, {Utilization: 60}, {Utilization: 70}},
+			Memory: []*types.ResourceMetrics{{Utilization: 50}, {Utilization: 50}},
+			Pods:   []*types.PodMetrics{{Utilization: 2}, {Utilization: 2}, {Utilization: 4}, {Utilization: 6}},
+		},
+	}
+
+	trends := service.CalculateTrends(metrics, 24*time.Hour)
+
+	assert.InDelta(t, 20, trends.CPU.Trend, 0.1)
+	assert.Equal(t, float64(0), trends.Memory.Trend)
+	assert.InDelta(t, 50, trends.Pods.Trend, 0.1)
+	assert.Equal(t, "24h0m0s", trends.CPU.Period)
+}
+
 func TestGenerateCPURecommendation(t *testing.T) {
 	tests := []struct {
 		name       string
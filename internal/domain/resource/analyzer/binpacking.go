@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+)
+
+// bin representa a capacidade livre de um node durante a simulação de bin-packing
+type bin struct {
+	node   types.NodeCapacity
+	cpu    float64
+	memory float64
+	opened bool
+}
+
+// fits verifica se o pod cabe na capacidade livre do bin
+func (b *bin) fits(pod types.NodePod) bool {
+	return pod.CPURequest <= b.cpu && pod.MemoryRequest <= b.memory
+}
+
+// place reserva a capacidade do pod no bin
+func (b *bin) place(pod types.NodePod) {
+	b.cpu -= pod.CPURequest
+	b.memory -= pod.MemoryRequest
+	b.opened = true
+}
+
+// simulateBinPacking calcula quantos nodes cada pool precisa para acomodar os pods informados,
+// usando first-fit decreasing. Os pods permanecem no pool onde estão agendados e os pods de
+// DaemonSets são tratados como overhead fixo dos nodes. Quando os nodes atuais não bastam,
+// nodes adicionais com o formato do maior node do pool são considerados.
+func simulateBinPacking(nodes []types.NodeCapacity, pods []types.NodePod) []*types.PoolBinPacking {
+	nodePools := make(map[string]string, len(nodes))
+	overhead := make(map[string]*types.NodePod, len(nodes))
+	for _, node := range nodes {
+		nodePools[node.Name] = node.Pool
+		overhead[node.Name] = &types.NodePod{}
+	}
+
+	// Separa os pods por pool; DaemonSets ocupam capacidade em todos os nodes
+	podsByPool := make(map[string][]types.NodePod)
+	for _, pod := range pods {
+		pool, ok := nodePools[pod.Node]
+		if !ok {
+			continue
+		}
+		if pod.Owner != nil && pod.Owner.Kind == types.WorkloadKindDaemonSet {
+			overhead[pod.Node].CPURequest += pod.CPURequest
+			overhead[pod.Node].MemoryRequest += pod.MemoryRequest
+			continue
+		}
+		podsByPool[pool] = append(podsByPool[pool], pod)
+	}
+
+	binsByPool := make(map[string][]*bin)
+	var poolNames []string
+	for _, node := range nodes {
+		if _, ok := binsByPool[node.Pool]; !ok {
+			poolNames = append(poolNames, node.Pool)
+		}
+		binsByPool[node.Pool] = append(binsByPool[node.Pool], &bin{
+			node:   node,
+			cpu:    node.CPU.Allocatable - overhead[node.Name].CPURequest,
+			memory: node.Memory.Allocatable - overhead[node.Name].MemoryRequest,
+		})
+	}
+	sort.Strings(poolNames)
+
+	results := make([]*types.PoolBinPacking, 0, len(poolNames))
+	for _, pool := range poolNames {
+		results = append(results, packPool(pool, binsByPool[pool], podsByPool[pool]))
+	}
+	return results
+}
+
+// packPool executa o first-fit decreasing de um pool
+func packPool(pool string, bins []*bin, pods []types.NodePod) *types.PoolBinPacking {
+	// Maiores nodes primeiro, para que os nodes liberados sejam os menores
+	sort.SliceStable(bins, func(i, j int) bool {
+		if bins[i].node.CPU.Allocatable != bins[j].node.CPU.Allocatable {
+			return bins[i].node.CPU.Allocatable > bins[j].node.CPU.Allocatable
+		}
+		return bins[i].node.Memory.Allocatable > bins[j].node.Memory.Allocatable
+	})
+	largest := *bins[0]
+
+	// Maiores pods primeiro, pelo recurso dominante em relação ao maior node
+	share := func(pod types.NodePod) float64 {
+		return max(pod.CPURequest/largest.node.CPU.Allocatable, pod.MemoryRequest/largest.node.Memory.Allocatable)
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		return share(pods[i]) > share(pods[j])
+	})
+
+	result := &types.PoolBinPacking{
+		Pool:         pool,
+		CurrentNodes: len(bins),
+	}
+
+	var extra []*bin
+	for _, pod := range pods {
+		if placeFirstFit(bins, pod, true) || placeFirstFit(extra, pod, true) || placeFirstFit(bins, pod, false) {
+			continue
+		}
+
+		// Os nodes atuais não bastam: considera um novo node com o formato do maior
+		node := largest
+		node.opened = false
+		if !node.fits(pod) {
+			result.UnplacedPods++
+			continue
+		}
+		node.place(pod)
+		extra = append(extra, &node)
+	}
+
+	for _, b := range bins {
+		if b.opened {
+			result.RequiredNodes++
+			continue
+		}
+		result.ReleasedCPU += b.node.CPU.Allocatable
+		result.ReleasedMemory += b.node.Memory.Allocatable
+	}
+	result.RequiredNodes += len(extra)
+	result.RemovableNodes = max(0, result.CurrentNodes-result.RequiredNodes)
+
+	return result
+}
+
+// placeFirstFit coloca o pod no primeiro bin com capacidade, considerando apenas bins já
+// abertos (opened=true) ou ainda vazios (opened=false)
+func placeFirstFit(bins []*bin, pod types.NodePod, opened bool) bool {
+	for _, b := range bins {
+		if b.opened == opened && b.fits(pod) {
+			b.place(pod)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNode(name, pool string, cpu, memory float64) types.NodeCapacity {
+	node := types.NodeCapacity{Name: name, Pool: pool, Schedulable: true}
+	node.CPU.Allocatable = cpu
+	node.Memory.Allocatable = memory
+	return node
+}
+
+func newPod(name, node string, kind types.WorkloadKind, cpu, memory float64) types.NodePod {
+	return types.NodePod{
+		Namespace:     "default",
+		Name:          name,
+		Node:          node,
+		Owner:         &types.WorkloadRef{Kind: kind, Namespace: "default", Name: name},
+		CPURequest:    cpu,
+		MemoryRequest: memory,
+	}
+}
+
+func TestSimulateBinPacking(t *testing.T) {
+	nodes := []types.NodeCapacity{
+		newNode("a", "general", 4000, 16384),
+		newNode("b", "general", 4000, 16384),
+		newNode("c", "general", 4000, 16384),
+		newNode("gpu-1", "gpu", 8000, 32768),
+	}
+	pods := []types.NodePod{
+		newPod("api", "a", types.WorkloadKindDeployment, 1500, 2048),
+		newPod("web", "b", types.WorkloadKindDeployment, 1000, 2048),
+		newPod("worker", "c", types.WorkloadKindDeployment, 1000, 4096),
+		newPod("ds-a", "a", types.WorkloadKindDaemonSet, 200, 256),
+		newPod("ds-b", "b", types.WorkloadKindDaemonSet, 200, 256),
+		newPod("ds-c", "c", types.WorkloadKindDaemonSet, 200, 256),
+		newPod("train", "gpu-1", types.WorkloadKindStatefulSet, 6000, 24576),
+	}
+
+	pools := simulateBinPacking(nodes, pods)
+
+	assert.Len(t, pools, 2)
+	general := pools[0]
+	assert.Equal(t, "general", general.Pool)
+	assert.Equal(t, 3, general.CurrentNodes)
+	// 3500m cabem em um node de 4000m com 200m de overhead de DaemonSet
+	assert.Equal(t, 1, general.RequiredNodes)
+	assert.Equal(t, 2, general.RemovableNodes)
+	assert.Equal(t, float64(8000), general.ReleasedCPU)
+
+	gpu := pools[1]
+	assert.Equal(t, "gpu", gpu.Pool)
+	assert.Equal(t, 1, gpu.RequiredNodes)
+	assert.Equal(t, 0, gpu.RemovableNodes)
+}
+
+func TestSimulateBinPacking_AdditionalNodes(t *testing.T) {
+	nodes := []types.NodeCapacity{newNode("a", "general", 2000, 4096)}
+	pods := []types.NodePod{
+		newPod("api", "a", types.WorkloadKindDeployment, 1500, 1024),
+		newPod("web", "a", types.WorkloadKindDeployment, 1500, 1024),
+		newPod("huge", "a", types.WorkloadKindDeployment, 3000, 1024),
+	}
+
+	pools := simulateBinPacking(nodes, pods)
+
+	assert.Len(t, pools, 1)
+	assert.Equal(t, 2, pools[0].RequiredNodes)
+	assert.Equal(t, 0, pools[0].RemovableNodes)
+	assert.Equal(t, 1, pools[0].UnplacedPods)
+}
+
+func TestAggregateNodePools(t *testing.T) {
+	a := newNode("a", "general", 4000, 8192)
+	a.CPU.Stranded = 100
+	a.Pods = 3
+	b := newNode("b", "general", 2000, 4096)
+	b.CPU.Stranded = 50
+	c := newNode("c", "batch", 8000, 16384)
+
+	pools := aggregateNodePools([]types.NodeCapacity{a, b, c})
+
+	assert.Len(t, pools, 2)
+	assert.Equal(t, "batch", pools[0].Name)
+	assert.Equal(t, "general", pools[1].Name)
+	assert.Equal(t, 2, pools[1].Nodes)
+	assert.Equal(t, 3, pools[1].Pods)
+	assert.Equal(t, float64(6000), pools[1].CPU.Allocatable)
+	assert.Equal(t, float64(150), pools[1].CPU.Stranded)
+}
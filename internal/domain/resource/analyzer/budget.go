@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+)
+
+// Frações do tempo restante da requisição reservadas a cada etapa de GetMetrics. Cada etapa
+// recebe uma fração do que sobrou ao começar, de modo que uma consulta lenta falha dentro da
+// sua parte do prazo sem consumir o tempo das etapas seguintes. O cálculo de custos usa o
+// tempo que restar.
+const (
+	currentMetricsBudget   = 0.25
+	historicalBudget       = 0.5
+	containerHistoryBudget = 0.5
+	oomKillsBudget         = 1.0 / 3
+	throttlingBudget       = 0.5
+)
+
+// withBudget retorna um contexto com prazo igual a share do tempo restante até o prazo de ctx.
+// Sem prazo em ctx, nenhum prazo é definido.
+func withBudget(ctx context.Context, share float64) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	budget := time.Duration(float64(time.Until(deadline)) * share)
+	return context.WithDeadline(ctx, time.Now().Add(budget))
+}
+
+// budgetError converte a falha de uma etapa que esgotou a sua parte do prazo em
+// ErrUnavailableMetrics, para que o handler responda com indisponibilidade
+func budgetError(ctx context.Context, stage string, err error) error {
+	if ctx.Err() == context.DeadlineExceeded && !errors.IsUnavailableMetrics(err) {
+		return errors.NewUnavailableMetricsError(stage, "time budget exceeded: "+err.Error())
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 40*time.Second)
+	defer cancel()
+
+	stageCtx, stageCancel := withBudget(ctx, 0.25)
+	defer stageCancel()
+	deadline, ok := stageCtx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(10*time.Second), deadline, time.Second)
+
+	// Sem prazo na requisição, a etapa também não tem prazo
+	stageCtx, stageCancel = withBudget(context.Background(), 0.25)
+	defer stageCancel()
+	_, ok = stageCtx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestBudgetError(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err := budgetError(expired, "historical_metrics", fmt.Errorf("context deadline exceeded"))
+	assert.True(t, errors.IsUnavailableMetrics(err))
+
+	notFound := errors.NewResourceNotFoundError("deployment", "not found")
+	assert.Equal(t, notFound, budgetError(context.Background(), "workload_config", notFound))
+}
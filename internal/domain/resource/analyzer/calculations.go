@@ -2,6 +2,9 @@ package analyzer
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
 )
 
 // Funções de cálculo de distribuição
@@ -32,10 +35,137 @@ func calculateCPUDistribution(usage float64) map[string]float64 {
 }
 
 // Funções de consulta
-func buildCPUHistoricalQuery(namespace, deployment string) string {
-	return fmt.Sprintf(`sum(rate(container_cpu_usage_seconds_total{namespace="%s",pod=~"%s-.*"}[5m])) * 1000`, namespace, deployment)
+
+// buildWorkloadPodsQuery retorna uma série com valor 1 por pod (namespace, pod) controlado pelo workload,
+// resolvida pelos owner references exportados pelo kube-state-metrics. Pods de um Deployment são os
+// dos ReplicaSets que ele controla (kube_pod_owner → kube_replicaset_owner). Com window, considera os
+// owners vistos na janela, para consultas instantâneas que cobrem um período.
+func buildWorkloadPodsQuery(namespace string, kind types.WorkloadKind, name, window string) string {
+	overWindow := func(selector string) string {
+		if window == "" {
+			return selector
+		}
+		return fmt.Sprintf("max_over_time(%s[%s])", selector, window)
+	}
+
+	if kind == types.WorkloadKindDeployment {
+		podOwner := overWindow(fmt.Sprintf(`kube_pod_owner{namespace="%s",owner_kind="ReplicaSet"}`, namespace))
+		replicaSetOwner := overWindow(fmt.Sprintf(`kube_replicaset_owner{namespace="%s",owner_kind="Deployment",owner_name="%s"}`, namespace, name))
+		return fmt.Sprintf(`max by (namespace, pod) (label_replace(%s, "replicaset", "$1", "owner_name", "(.*)") * on (namespace, replicaset) group_left max by (namespace, replicaset) (%s))`,
+			podOwner, replicaSetOwner)
+	}
+	return fmt.Sprintf(`max by (namespace, pod) (%s)`,
+		overWindow(fmt.Sprintf(`kube_pod_owner{namespace="%s",owner_kind="%s",owner_name="%s"}`, namespace, kind, name)))
+}
+
+// workloadSeries restringe as séries de expr aos pods retornados por pods
+func workloadSeries(expr, pods string) string {
+	return fmt.Sprintf("%s * on (namespace, pod) group_left %s", expr, pods)
+}
+
+// buildCPUHistoricalQuery retorna a query de uso total de CPU dos pods do workload, em milicores
+func buildCPUHistoricalQuery(namespace string, kind types.WorkloadKind, name string) string {
+	series := fmt.Sprintf(`rate(container_cpu_usage_seconds_total{namespace="%s"}[5m])`, namespace)
+	return fmt.Sprintf(`sum(%s) * 1000`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, "")))
+}
+
+// buildMemoryHistoricalQuery retorna a query de uso total de memória dos pods do workload, em Mi
+func buildMemoryHistoricalQuery(namespace string, kind types.WorkloadKind, name string) string {
+	series := fmt.Sprintf(`container_memory_working_set_bytes{namespace="%s"}`, namespace)
+	return fmt.Sprintf(`sum(%s) / (1024 * 1024)`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, "")))
+}
+
+// buildNodeCPUAverageQuery retorna a query de uso médio de CPU de um node no período, em milicores
+func buildNodeCPUAverageQuery(node string, period time.Duration) string {
+	series := nodeSeries(`rate(node_cpu_seconds_total{mode!="idle"}[5m])`, node)
+	return fmt.Sprintf(`avg_over_time(sum(%s)[%s:5m]) * 1000`, series, promDuration(period))
+}
+
+// buildNodeMemoryAverageQuery retorna a query de uso médio de memória de um node no período, em Mi
+func buildNodeMemoryAverageQuery(node string, period time.Duration) string {
+	return fmt.Sprintf(`avg_over_time((sum(%s) - sum(%s))[%s:5m]) / (1024 * 1024)`,
+		nodeSeries("node_memory_MemTotal_bytes", node), nodeSeries("node_memory_MemAvailable_bytes", node), promDuration(period))
+}
+
+// nodeSeries restringe as séries do node-exporter em expr às do node, associando o label instance
+// ao nome do node pelo nodename de node_uname_info (o instance costuma ser o IP do pod do exporter)
+func nodeSeries(expr, node string) string {
+	return fmt.Sprintf(`%s * on (instance) group_left node_uname_info{nodename="%s"}`, expr, node)
+}
+
+// promDuration formata uma duração no formato aceito pelo PromQL
+func promDuration(period time.Duration) string {
+	return fmt.Sprintf("%ds", int64(period.Seconds()))
+}
+
+// buildContainerCPUHistoricalQuery retorna a query de uso médio de CPU por pod de um container, em milicores
+func buildContainerCPUHistoricalQuery(namespace string, kind types.WorkloadKind, name, container string) string {
+	series := fmt.Sprintf(`rate(container_cpu_usage_seconds_total{namespace="%s",container="%s"}[5m])`, namespace, container)
+	return fmt.Sprintf(`avg(%s) * 1000`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, "")))
+}
+
+// buildContainerMemoryHistoricalQuery retorna a query de uso médio de memória por pod de um container, em Mi
+func buildContainerMemoryHistoricalQuery(namespace string, kind types.WorkloadKind, name, container string) string {
+	series := fmt.Sprintf(`container_memory_working_set_bytes{namespace="%s",container="%s"}`, namespace, container)
+	return fmt.Sprintf(`avg(%s) / (1024 * 1024)`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, "")))
+}
+
+// buildContainersCPUHistoricalQuery retorna a query de uso médio de CPU por pod de cada container do
+// workload, em milicores, com uma série por container
+func buildContainersCPUHistoricalQuery(namespace string, kind types.WorkloadKind, name string) string {
+	series := fmt.Sprintf(`rate(container_cpu_usage_seconds_total{namespace="%s",container!="",container!="POD"}[5m])`, namespace)
+	return fmt.Sprintf(`avg by (container) (%s) * 1000`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, "")))
+}
+
+// buildContainersMemoryHistoricalQuery retorna a query de uso médio de memória por pod de cada
+// container do workload, em Mi, com uma série por container
+func buildContainersMemoryHistoricalQuery(namespace string, kind types.WorkloadKind, name string) string {
+	series := fmt.Sprintf(`container_memory_working_set_bytes{namespace="%s",container!="",container!="POD"}`, namespace)
+	return fmt.Sprintf(`avg by (container) (%s) / (1024 * 1024)`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, "")))
+}
+
+// buildContainerOOMKilledQuery retorna a query que indica se algum pod do workload teve o container
+// encerrado por OOMKilled no período (1 quando houve, 0 ou vazio caso contrário)
+func buildContainerOOMKilledQuery(namespace string, kind types.WorkloadKind, name, container string, period time.Duration) string {
+	window := promDuration(period)
+	series := fmt.Sprintf(`max_over_time(kube_pod_container_status_last_terminated_reason{namespace="%s",container="%s",reason="OOMKilled"}[%s])`,
+		namespace, container, window)
+	return fmt.Sprintf(`max(%s)`, workloadSeries(series, buildWorkloadPodsQuery(namespace, kind, name, window)))
+}
+
+// buildContainerCPUThrottlingQuery retorna a query do percentual de períodos do CFS com throttling
+// de um container no período, somando todos os pods do workload
+func buildContainerCPUThrottlingQuery(namespace string, kind types.WorkloadKind, name, container string, period time.Duration) string {
+	selector := fmt.Sprintf(`namespace="%s",container="%s"`, namespace, container)
+	window := promDuration(period)
+	pods := buildWorkloadPodsQuery(namespace, kind, name, window)
+	throttled := fmt.Sprintf(`increase(container_cpu_cfs_throttled_periods_total{%s}[%s])`, selector, window)
+	periods := fmt.Sprintf(`increase(container_cpu_cfs_periods_total{%s}[%s])`, selector, window)
+	return fmt.Sprintf(`sum(%s) / sum(%s) * 100`, workloadSeries(throttled, pods), workloadSeries(periods, pods))
+}
+
+// buildNamespaceCPURequestsPeakQuery retorna a query da soma máxima dos requests de CPU dos pods
+// não finalizados do namespace no período, em milicores
+func buildNamespaceCPURequestsPeakQuery(namespace string, period time.Duration) string {
+	return fmt.Sprintf(`max_over_time(%s[%s:5m]) * 1000`, namespaceRequestsSum(namespace, "cpu"), promDuration(period))
+}
+
+// buildNamespaceMemoryRequestsPeakQuery retorna a query da soma máxima dos requests de memória dos
+// pods não finalizados do namespace no período, em Mi
+func buildNamespaceMemoryRequestsPeakQuery(namespace string, period time.Duration) string {
+	return fmt.Sprintf(`max_over_time(%s[%s:5m]) / (1024 * 1024)`, namespaceRequestsSum(namespace, "memory"), promDuration(period))
+}
+
+// namespaceRequestsSum retorna a soma dos requests de um recurso dos pods Pending ou Running do
+// namespace, que é o total contabilizado pelo ResourceQuota
+func namespaceRequestsSum(namespace, resource string) string {
+	return fmt.Sprintf(`sum(kube_pod_container_resource_requests{namespace="%[1]s",resource="%[2]s"} * on (namespace, pod) group_left () (max by (namespace, pod) (kube_pod_status_phase{namespace="%[1]s",phase=~"Pending|Running"}) == 1))`,
+		namespace, resource)
 }
 
-func buildMemoryHistoricalQuery(namespace, deployment string) string {
-	return fmt.Sprintf(`sum(container_memory_working_set_bytes{namespace="%s",pod=~"%s-.*"}) / (1024 * 1024)`, namespace, deployment)
+// buildNamespacePodsPeakQuery retorna a query do número máximo de pods não finalizados do namespace no período,
+// que são os pods contabilizados pelo ResourceQuota
+func buildNamespacePodsPeakQuery(namespace string, period time.Duration) string {
+	return fmt.Sprintf(`max_over_time(count(kube_pod_status_phase{namespace="%s",phase=~"Pending|Running"} == 1)[%s:5m])`,
+		namespace, promDuration(period))
 }
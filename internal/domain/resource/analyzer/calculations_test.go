@@ -2,6 +2,8 @@ package analyzer
 
 import (
 	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
 )
 
 func TestCalculateCPUDistribution(t *testing.T) {
@@ -58,64 +60,78 @@ func TestCalculateCPUDistribution(t *testing.T) {
 	}
 }
 
-func TestBuildCPUHistoricalQuery(t *testing.T) {
+func TestBuildWorkloadPodsQuery(t *testing.T) {
 	tests := []struct {
-		name       string
-		namespace  string
-		deployment string
-		want       string
+		name     string
+		kind     types.WorkloadKind
+		workload string
+		window   string
+		want     string
 	}{
 		{
-			name:       "query básica",
-			namespace:  "default",
-			deployment: "nginx",
-			want:       `sum(rate(container_cpu_usage_seconds_total{namespace="default",pod=~"nginx-.*"}[5m])) * 1000`,
+			name:     "deployment resolvido pelos replicasets",
+			kind:     types.WorkloadKindDeployment,
+			workload: "api",
+			want:     `max by (namespace, pod) (label_replace(kube_pod_owner{namespace="default",owner_kind="ReplicaSet"}, "replicaset", "$1", "owner_name", "(.*)") * on (namespace, replicaset) group_left max by (namespace, replicaset) (kube_replicaset_owner{namespace="default",owner_kind="Deployment",owner_name="api"}))`,
+		},
+		{
+			name:     "statefulset é o owner direto dos pods",
+			kind:     types.WorkloadKindStatefulSet,
+			workload: "db",
+			want:     `max by (namespace, pod) (kube_pod_owner{namespace="default",owner_kind="StatefulSet",owner_name="db"})`,
 		},
 		{
-			name:       "namespace e deployment com caracteres especiais",
-			namespace:  "prod-env",
-			deployment: "web-app",
-			want:       `sum(rate(container_cpu_usage_seconds_total{namespace="prod-env",pod=~"web-app-.*"}[5m])) * 1000`,
+			name:     "owners vistos na janela",
+			kind:     types.WorkloadKindDaemonSet,
+			workload: "agent",
+			window:   "3600s",
+			want:     `max by (namespace, pod) (max_over_time(kube_pod_owner{namespace="default",owner_kind="DaemonSet",owner_name="agent"}[3600s]))`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildCPUHistoricalQuery(tt.namespace, tt.deployment)
+			got := buildWorkloadPodsQuery("default", tt.kind, tt.workload, tt.window)
 			if got != tt.want {
-				t.Errorf("buildCPUHistoricalQuery() = %v, expected %v", got, tt.want)
+				t.Errorf("buildWorkloadPodsQuery() = %v, expected %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestBuildCPUHistoricalQuery(t *testing.T) {
+	pods := `max by (namespace, pod) (kube_pod_owner{namespace="prod-env",owner_kind="StatefulSet",owner_name="web-app"})`
+	want := `sum(rate(container_cpu_usage_seconds_total{namespace="prod-env"}[5m]) * on (namespace, pod) group_left ` + pods + `) * 1000`
+
+	if got := buildCPUHistoricalQuery("prod-env", types.WorkloadKindStatefulSet, "web-app"); got != want {
+		t.Errorf("buildCPUHistoricalQuery() = %v, expected %v", got, want)
+	}
+}
+
 func TestBuildMemoryHistoricalQuery(t *testing.T) {
-	tests := []struct {
-		name       string
-		namespace  string
-		deployment string
-		want       string
-	}{
-		{
-			name:       "query básica",
-			namespace:  "default",
-			deployment: "nginx",
-			want:       `sum(container_memory_working_set_bytes{namespace="default",pod=~"nginx-.*"}) / (1024 * 1024)`,
-		},
-		{
-			name:       "namespace e deployment com caracteres especiais",
-			namespace:  "prod-env",
-			deployment: "web-app",
-			want:       `sum(container_memory_working_set_bytes{namespace="prod-env",pod=~"web-app-.*"}) / (1024 * 1024)`,
-		},
+	pods := `max by (namespace, pod) (kube_pod_owner{namespace="prod-env",owner_kind="StatefulSet",owner_name="web-app"})`
+	want := `sum(container_memory_working_set_bytes{namespace="prod-env"} * on (namespace, pod) group_left ` + pods + `) / (1024 * 1024)`
+
+	if got := buildMemoryHistoricalQuery("prod-env", types.WorkloadKindStatefulSet, "web-app"); got != want {
+		t.Errorf("buildMemoryHistoricalQuery() = %v, expected %v", got, want)
 	}
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := buildMemoryHistoricalQuery(tt.namespace, tt.deployment)
-			if got != tt.want {
-				t.Errorf("buildMemoryHistoricalQuery() = %v, expected %v", got, tt.want)
-			}
-		})
+func TestBuildContainerHistoricalQueries(t *testing.T) {
+	pods := buildWorkloadPodsQuery("default", types.WorkloadKindDeployment, "nginx", "")
+
+	wantCPU := `avg(rate(container_cpu_usage_seconds_total{namespace="default",container="istio-proxy"}[5m]) * on (namespace, pod) group_left ` + pods + `) * 1000`
+	if got := buildContainerCPUHistoricalQuery("default", types.WorkloadKindDeployment, "nginx", "istio-proxy"); got != wantCPU {
+		t.Errorf("buildContainerCPUHistoricalQuery() = %v, expected %v", got, wantCPU)
+	}
+
+	wantMemory := `avg(container_memory_working_set_bytes{namespace="default",container="istio-proxy"} * on (namespace, pod) group_left ` + pods + `) / (1024 * 1024)`
+	if got := buildContainerMemoryHistoricalQuery("default", types.WorkloadKindDeployment, "nginx", "istio-proxy"); got != wantMemory {
+		t.Errorf("buildContainerMemoryHistoricalQuery() = %v, expected %v", got, wantMemory)
+	}
+
+	wantByContainer := `avg by (container) (rate(container_cpu_usage_seconds_total{namespace="default",container!="",container!="POD"}[5m]) * on (namespace, pod) group_left ` + pods + `) * 1000`
+	if got := buildContainersCPUHistoricalQuery("default", types.WorkloadKindDeployment, "nginx"); got != wantByContainer {
+		t.Errorf("buildContainersCPUHistoricalQuery() = %v, expected %v", got, wantByContainer)
 	}
 }
@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// unlabeledGroup agrupa workloads sem o label de agrupamento
+const unlabeledGroup = "unlabeled"
+
+// GenerateClusterReport gera o relatório de inventário e showback do cluster
+func (s *Service) GenerateClusterReport(ctx context.Context, period time.Duration, groupBy string, top int) (*types.ClusterReport, error) {
+	if groupBy == "" {
+		groupBy = s.groupLabel
+	}
+	if top <= 0 {
+		top = s.topOffenders
+	}
+
+	logger.Info("Starting cluster report",
+		logger.NewField("period", period),
+		logger.NewField("group_by", groupBy),
+		logger.NewField("top", top),
+	)
+
+	namespaces, err := s.metricsCollector.ListNamespaces(ctx)
+	if err != nil {
+		logger.Error("Failed to list namespaces", err)
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	// Enumera os workloads de todos os namespaces; falhas de listagem não interrompem o relatório
+	var workloads []types.WorkloadRef
+	var errs []types.WorkloadError
+	namespaceLabels := make(map[string]map[string]string, len(namespaces))
+	for _, ns := range namespaces {
+		namespaceLabels[ns.Name] = ns.Labels
+
+		nsWorkloads, err := s.metricsCollector.ListWorkloads(ctx, ns.Name)
+		if err != nil {
+			logger.Error("Failed to list namespace workloads", err,
+				logger.NewField("namespace", ns.Name),
+			)
+			errs = append(errs, types.WorkloadError{Namespace: ns.Name, Error: err.Error()})
+			continue
+		}
+		workloads = append(workloads, nsWorkloads...)
+	}
+
+	summaries, workloadErrs := s.analyzeWorkloads(ctx, workloads, period)
+	errs = append(errs, workloadErrs...)
+
+	analyzed := make([]*types.WorkloadSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		if summary != nil {
+			analyzed = append(analyzed, summary)
+		}
+	}
+	sort.SliceStable(analyzed, func(i, j int) bool {
+		return analyzed[i].Costs.SavingsMonthly > analyzed[j].Costs.SavingsMonthly
+	})
+
+	report := &types.ClusterReport{
+		Cluster:  collector.ClusterFromContext(ctx),
+		Period:   period.String(),
+		Currency: "BRL",
+		GroupBy:  groupBy,
+		Summary:  summarizeNamespace(analyzed),
+		Namespaces: groupWorkloads(analyzed, func(w *types.WorkloadSummary) string {
+			return w.Namespace
+		}),
+		Groups: groupWorkloads(analyzed, func(w *types.WorkloadSummary) string {
+			return groupLabelValue(w, namespaceLabels[w.Namespace], groupBy)
+		}),
+		TopOffenders: analyzed[:min(top, len(analyzed))],
+		Errors:       errs,
+	}
+	report.Summary.Workloads = len(workloads)
+	report.Summary.Failed = len(workloadErrs)
+
+	logger.Info("Cluster report completed",
+		logger.NewField("namespaces", len(namespaces)),
+		logger.NewField("workloads", report.Summary.Workloads),
+		logger.NewField("failed", report.Summary.Failed),
+		logger.NewField("savings_monthly", report.Summary.Costs.SavingsMonthly),
+	)
+
+	return report, nil
+}
+
+// groupLabelValue retorna o valor do label de agrupamento do workload,
+// usando o label do namespace quando o workload não o possui
+func groupLabelValue(workload *types.WorkloadSummary, namespaceLabels map[string]string, label string) string {
+	if value := workload.Labels[label]; value != "" {
+		return value
+	}
+	if value := namespaceLabels[label]; value != "" {
+		return value
+	}
+	return unlabeledGroup
+}
+
+// groupWorkloads agrega os workloads pela chave informada, ordenando os grupos pela economia mensal
+func groupWorkloads(workloads []*types.WorkloadSummary, key func(*types.WorkloadSummary) string) []*types.GroupSummary {
+	groups := make([]*types.GroupSummary, 0)
+	byName := make(map[string]*types.GroupSummary)
+	for _, w := range workloads {
+		name := key(w)
+		group, ok := byName[name]
+		if !ok {
+			group = &types.GroupSummary{Name: name}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+		group.Workloads++
+		addTotals(&group.CPU, w.CPU)
+		addTotals(&group.Memory, w.Memory)
+		addCosts(&group.Costs, w.Costs)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Costs.SavingsMonthly > groups[j].Costs.SavingsMonthly
+	})
+	return groups
+}
@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateClusterReport(t *testing.T) {
+	collector := &mockCollector{
+		namespaces: []types.NamespaceRef{
+			{Name: "payments", Labels: map[string]string{"team": "billing"}},
+			{Name: "web"},
+		},
+		workloads: []types.WorkloadRef{
+			{Kind: types.WorkloadKindDeployment, Namespace: "payments", Name: "checkout"},
+			{Kind: types.WorkloadKindDeployment, Namespace: "payments", Name: "ledger", Labels: map[string]string{"team": "finance"}},
+			{Kind: types.WorkloadKindDeployment, Namespace: "web", Name: "frontend"},
+		},
+		configs: map[string]*types.K8sDeploymentConfig{},
+		metrics: map[string]*types.K8sMetrics{},
+	}
+	collector.configs["checkout"], collector.metrics["checkout"] = newWorkloadFixture(1000, 100, 1024, 100, 2)
+	collector.configs["ledger"], collector.metrics["ledger"] = newWorkloadFixture(4000, 100, 4096, 100, 1)
+	collector.configs["frontend"], collector.metrics["frontend"] = newWorkloadFixture(500, 100, 512, 100, 1)
+
+	service := NewService(collector, pricing.NewClient(&pricing.Config{}), nil)
+
+	report, err := service.GenerateClusterReport(context.Background(), 24*time.Hour, "", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultGroupLabel, report.GroupBy)
+	assert.Equal(t, 3, report.Summary.Workloads)
+	assert.Equal(t, 3, report.Summary.Analyzed)
+	assert.Equal(t, float64(2000+4000+500), report.Summary.CPU.Requested)
+
+	// Agrupamento por namespace
+	assert.Len(t, report.Namespaces, 2)
+	assert.Equal(t, "payments", report.Namespaces[0].Name)
+	assert.Equal(t, 2, report.Namespaces[0].Workloads)
+
+	// Agrupamento por label: label do workload tem precedência sobre o do namespace
+	groups := make(map[string]*types.GroupSummary)
+	for _, group := range report.Groups {
+		groups[group.Name] = group
+	}
+	assert.Len(t, groups, 3)
+	assert.Equal(t, 1, groups["billing"].Workloads)
+	assert.Equal(t, 1, groups["finance"].Workloads)
+	assert.Equal(t, 1, groups[unlabeledGroup].Workloads)
+
+	// Maiores desperdícios
+	assert.Len(t, report.TopOffenders, 2)
+	assert.Equal(t, "ledger", report.TopOffenders[0].Name)
+}
@@ -0,0 +1,221 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+const (
+	// FindingOversizedRequests identifica requests copiados de um cluster com uso muito maior
+	FindingOversizedRequests = "oversized_requests"
+
+	// copiedRequestsTolerance é a diferença máxima entre requests considerados copiados
+	copiedRequestsTolerance = 0.1
+	// oversizedUsageRatio é a razão máxima entre o uso do cluster e o uso do cluster de
+	// referência para que requests equivalentes sejam sinalizados
+	oversizedUsageRatio = 0.5
+)
+
+// CompareClusters analisa o mesmo workload em cada cluster e o compara lado a lado
+func (s *Service) CompareClusters(ctx context.Context, clusters []string, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.ClusterComparison, error) {
+	if len(clusters) < 2 {
+		return nil, errors.NewInvalidConfigurationError("clusters", "informe ao menos dois clusters")
+	}
+	seen := make(map[string]bool, len(clusters))
+	for _, cluster := range clusters {
+		if seen[cluster] {
+			return nil, errors.NewInvalidConfigurationError("clusters", "cluster repetido: "+cluster)
+		}
+		seen[cluster] = true
+	}
+
+	logger.Info("Starting cluster comparison",
+		logger.NewField("namespace", namespace),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+		logger.NewField("clusters", clusters),
+		logger.NewField("period", period),
+	)
+
+	responses := make([]*types.MetricsResponse, len(clusters))
+	failures := runPool(ctx, clusters, s.maxConcurrency, func(i int, cluster string) error {
+		var err error
+		responses[i], err = s.GetMetrics(collector.WithCluster(ctx, cluster), namespace, kind, name, period)
+		return err
+	})
+
+	result := &types.ClusterComparison{
+		Namespace: namespace,
+		Kind:      kind,
+		Name:      name,
+		Period:    period.String(),
+		Currency:  "BRL",
+		Clusters:  make([]*types.ClusterWorkload, 0, len(clusters)),
+	}
+	for i, cluster := range clusters {
+		if failures[i] != nil {
+			logger.Error("Failed to analyze workload in cluster", failures[i],
+				logger.NewField("cluster", cluster),
+				logger.NewField("name", name),
+			)
+			result.Errors = append(result.Errors, types.ClusterError{Cluster: cluster, Error: failures[i].Error()})
+			continue
+		}
+		result.Clusters = append(result.Clusters, summarizeClusterWorkload(cluster, responses[i]))
+	}
+
+	if len(result.Clusters) == 0 {
+		return nil, fmt.Errorf("failed to analyze workload in all clusters: %w", failures[0])
+	}
+
+	baseline := result.Clusters[0]
+	result.Baseline = baseline.Cluster
+	for _, workload := range result.Clusters[1:] {
+		workload.Difference = compareWorkloads(baseline, workload)
+	}
+	result.Findings = findOversizedRequests(result.Clusters)
+
+	logger.Info("Cluster comparison completed",
+		logger.NewField("name", name),
+		logger.NewField("analyzed", len(result.Clusters)),
+		logger.NewField("failed", len(result.Errors)),
+		logger.NewField("findings", len(result.Findings)),
+	)
+
+	return result, nil
+}
+
+// summarizeClusterWorkload resume a análise de um workload em um cluster, por réplica
+func summarizeClusterWorkload(cluster string, response *types.MetricsResponse) *types.ClusterWorkload {
+	current := response.Current
+	replicas := float64(current.Pods.Running)
+
+	workload := &types.ClusterWorkload{
+		Cluster:  cluster,
+		Replicas: current.Pods.Running,
+		CPU: types.ReplicaResources{
+			Requested:   current.Deployment.Config.CPU.Request,
+			Used:        current.Analysis.CPU.Usage.Current.Average,
+			Recommended: current.Deployment.Config.CPU.Request,
+		},
+		Memory: types.ReplicaResources{
+			Requested:   current.Deployment.Config.Memory.Request,
+			Used:        current.Analysis.Memory.Usage.Current.Average,
+			Recommended: current.Deployment.Config.Memory.Request,
+		},
+		CPUAction:    "maintain",
+		MemoryAction: "maintain",
+	}
+
+	if response.Analysis != nil {
+		workload.CPU.Recommended = suggestedOrCurrent(response.Analysis.CPU, workload.CPU.Requested)
+		workload.Memory.Recommended = suggestedOrCurrent(response.Analysis.Memory, workload.Memory.Requested)
+		workload.CPUAction = recommendedAction(response.Analysis.CPU)
+		workload.MemoryAction = recommendedAction(response.Analysis.Memory)
+	}
+
+	if response.Costs != nil {
+		workload.Costs.CurrentMonthly = response.Costs.Current.Monthly.Total * replicas
+		workload.Costs.RecommendedMonthly = response.Costs.Recommended.Monthly.Total * replicas
+		workload.Costs.SavingsMonthly = response.Costs.Savings.Total * replicas
+	}
+
+	return workload
+}
+
+// recommendedAction retorna a ação da recomendação, ou "maintain" quando não há recomendação
+func recommendedAction(recommendation *types.ResourceRecommendation) string {
+	if recommendation == nil || recommendation.Recommendation == nil {
+		return "maintain"
+	}
+	return recommendation.Recommendation.Action
+}
+
+// compareWorkloads calcula a diferença de um cluster para o baseline
+func compareWorkloads(baseline, workload *types.ClusterWorkload) *types.ComparisonDifference {
+	return &types.ComparisonDifference{
+		Replicas: workload.Replicas - baseline.Replicas,
+		CPU:      subtractResources(workload.CPU, baseline.CPU),
+		Memory:   subtractResources(workload.Memory, baseline.Memory),
+		Costs: types.CostSummary{
+			CurrentMonthly:     workload.Costs.CurrentMonthly - baseline.Costs.CurrentMonthly,
+			RecommendedMonthly: workload.Costs.RecommendedMonthly - baseline.Costs.RecommendedMonthly,
+			SavingsMonthly:     workload.Costs.SavingsMonthly - baseline.Costs.SavingsMonthly,
+		},
+	}
+}
+
+// subtractResources retorna a - b
+func subtractResources(a, b types.ReplicaResources) types.ReplicaResources {
+	return types.ReplicaResources{
+		Requested:   a.Requested - b.Requested,
+		Used:        a.Used - b.Used,
+		Recommended: a.Recommended - b.Recommended,
+	}
+}
+
+// findOversizedRequests sinaliza clusters cujos requests por réplica equivalem (ou superam) os de
+// outro cluster com uso ao menos duas vezes maior, e cuja recomendação é reduzir o recurso.
+// Para cada cluster e recurso, a referência é o cluster com maior uso entre os candidatos.
+func findOversizedRequests(workloads []*types.ClusterWorkload) []types.ComparisonFinding {
+	var findings []types.ComparisonFinding
+	for _, workload := range workloads {
+		if finding, ok := oversizedFinding(workload, workloads, "cpu", workload.CPUAction, func(w *types.ClusterWorkload) types.ReplicaResources { return w.CPU }); ok {
+			findings = append(findings, finding)
+		}
+		if finding, ok := oversizedFinding(workload, workloads, "memory", workload.MemoryAction, func(w *types.ClusterWorkload) types.ReplicaResources { return w.Memory }); ok {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// oversizedFinding avalia um recurso de um cluster contra os demais clusters
+func oversizedFinding(workload *types.ClusterWorkload, workloads []*types.ClusterWorkload, resource, action string, value func(*types.ClusterWorkload) types.ReplicaResources) (types.ComparisonFinding, bool) {
+	if action != "decrease" {
+		return types.ComparisonFinding{}, false
+	}
+
+	own := value(workload)
+	var reference *types.ClusterWorkload
+	for _, other := range workloads {
+		if other == workload {
+			continue
+		}
+		candidate := value(other)
+		if candidate.Requested <= 0 || candidate.Used <= 0 || own.Requested < candidate.Requested*(1-copiedRequestsTolerance) {
+			continue
+		}
+		if own.Used > candidate.Used*oversizedUsageRatio {
+			continue
+		}
+		if reference == nil || candidate.Used > value(reference).Used {
+			reference = other
+		}
+	}
+	if reference == nil {
+		return types.ComparisonFinding{}, false
+	}
+
+	ref := value(reference)
+	return types.ComparisonFinding{
+		Type:               FindingOversizedRequests,
+		Cluster:            workload.Cluster,
+		Reference:          reference.Cluster,
+		Resource:           resource,
+		Requested:          own.Requested,
+		ReferenceRequested: ref.Requested,
+		Used:               own.Used,
+		ReferenceUsed:      ref.Used,
+		Suggested:          own.Recommended,
+		Message: fmt.Sprintf("Requests de %s em %s (%.0f) equivalem aos de %s (%.0f) com uso %.0f%% menor; recomendado %.0f",
+			resource, workload.Cluster, own.Requested, reference.Cluster, ref.Requested,
+			(1-own.Used/ref.Used)*100, own.Recommended),
+	}, true
+}
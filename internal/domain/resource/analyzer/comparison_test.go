@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+// newClusterFixture cria um mockCollector com um único workload "api"
+func newClusterFixture(cpuRequest, cpuAvg, memoryRequest, memoryAvg float64, running int) *mockCollector {
+	config, metrics := newWorkloadFixture(cpuRequest, cpuAvg, memoryRequest, memoryAvg, running)
+	return &mockCollector{
+		configs: map[string]*types.K8sDeploymentConfig{"api": config},
+		metrics: map[string]*types.K8sMetrics{"api": metrics},
+	}
+}
+
+func TestCompareClusters(t *testing.T) {
+	clusters := collector.NewClusterCollector("prod-us")
+	clusters.Register("prod-us", newClusterFixture(1000, 800, 2048, 1600, 3))
+	// Staging com os requests de produção copiados e uso bem menor
+	clusters.Register("staging", newClusterFixture(1000, 100, 2048, 200, 1))
+
+	service := NewService(clusters, pricing.NewClient(&pricing.Config{}), nil)
+
+	comparison, err := service.CompareClusters(context.Background(), []string{"prod-us", "staging", "prod-eu"}, "default", types.WorkloadKindDeployment, "api", 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "prod-us", comparison.Baseline)
+	assert.Len(t, comparison.Clusters, 2)
+	if assert.Len(t, comparison.Errors, 1) {
+		assert.Equal(t, "prod-eu", comparison.Errors[0].Cluster)
+	}
+
+	prod, staging := comparison.Clusters[0], comparison.Clusters[1]
+	assert.Nil(t, prod.Difference)
+	assert.Equal(t, float64(1000), staging.CPU.Requested)
+	assert.Equal(t, float64(100), staging.CPU.Used)
+	assert.Equal(t, "decrease", staging.CPUAction)
+	assert.Less(t, staging.CPU.Recommended, staging.CPU.Requested)
+
+	if assert.NotNil(t, staging.Difference) {
+		assert.Equal(t, -2, staging.Difference.Replicas)
+		assert.Equal(t, float64(0), staging.Difference.CPU.Requested)
+		assert.Equal(t, float64(-700), staging.Difference.CPU.Used)
+		assert.InDelta(t, staging.Costs.CurrentMonthly-prod.Costs.CurrentMonthly, staging.Difference.Costs.CurrentMonthly, 0.0001)
+	}
+
+	// Apenas staging é sinalizado, em CPU e memória, tendo produção como referência
+	if assert.Len(t, comparison.Findings, 2) {
+		for _, finding := range comparison.Findings {
+			assert.Equal(t, FindingOversizedRequests, finding.Type)
+			assert.Equal(t, "staging", finding.Cluster)
+			assert.Equal(t, "prod-us", finding.Reference)
+		}
+		assert.Equal(t, "cpu", comparison.Findings[0].Resource)
+		assert.Equal(t, "memory", comparison.Findings[1].Resource)
+	}
+}
+
+func TestCompareClusters_InvalidClusters(t *testing.T) {
+	service := NewService(collector.NewClusterCollector("prod-us"), nil, nil)
+
+	_, err := service.CompareClusters(context.Background(), []string{"prod-us"}, "default", types.WorkloadKindDeployment, "api", time.Hour)
+	assert.True(t, errors.IsInvalidConfiguration(err))
+
+	_, err = service.CompareClusters(context.Background(), []string{"prod-us", "prod-us"}, "default", types.WorkloadKindDeployment, "api", time.Hour)
+	assert.True(t, errors.IsInvalidConfiguration(err))
+}
+
+func TestFindOversizedRequests(t *testing.T) {
+	workload := func(cluster string, requested, used float64, action string) *types.ClusterWorkload {
+		return &types.ClusterWorkload{
+			Cluster:      cluster,
+			CPU:          types.ReplicaResources{Requested: requested, Used: used, Recommended: used * 1.2},
+			CPUAction:    action,
+			MemoryAction: "maintain",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		workloads []*types.ClusterWorkload
+		want      int
+	}{
+		{
+			name:      "requests copiados com uso menor",
+			workloads: []*types.ClusterWorkload{workload("prod", 1000, 800, "maintain"), workload("staging", 1000, 100, "decrease")},
+			want:      1,
+		},
+		{
+			name:      "requests já reduzidos",
+			workloads: []*types.ClusterWorkload{workload("prod", 1000, 800, "maintain"), workload("staging", 500, 100, "decrease")},
+		},
+		{
+			name:      "uso equivalente",
+			workloads: []*types.ClusterWorkload{workload("prod", 1000, 300, "decrease"), workload("staging", 1000, 250, "decrease")},
+		},
+		{
+			name:      "recomendação mantém o request",
+			workloads: []*types.ClusterWorkload{workload("prod", 1000, 800, "maintain"), workload("staging", 1000, 100, "maintain")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, findOversizedRequests(tt.workloads), tt.want)
+		})
+	}
+}
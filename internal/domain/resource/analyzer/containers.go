@@ -0,0 +1,204 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// buildContainerMetrics combina a configuração e o uso atual de cada container do workload.
+// A ordem segue o pod template; containers com uso mas fora do template (ex: sidecars injetados)
+// são adicionados ao final, sem request/limit.
+func buildContainerMetrics(config *types.K8sDeploymentConfig, metrics *types.K8sMetrics) []*types.ContainerMetrics {
+	containers := make([]*types.ContainerMetrics, 0, len(config.Containers))
+	byName := make(map[string]*types.ContainerMetrics, len(config.Containers))
+
+	for _, c := range config.Containers {
+		container := &types.ContainerMetrics{Name: c.Name}
+		container.CPU.Request = c.CPU.Request
+		container.CPU.Limit = c.CPU.Limit
+		container.Memory.Request = c.Memory.Request
+		container.Memory.Limit = c.Memory.Limit
+		containers = append(containers, container)
+		byName[c.Name] = container
+	}
+
+	for _, usage := range metrics.Containers {
+		container, ok := byName[usage.Name]
+		if !ok {
+			container = &types.ContainerMetrics{Name: usage.Name}
+			containers = append(containers, container)
+			byName[usage.Name] = container
+		}
+		container.CPU.Usage.Current = types.UsageStats{Average: usage.CPU.Average, Peak: usage.CPU.Peak}
+		container.Memory.Usage.Current = types.UsageStats{Average: usage.Memory.Average, Peak: usage.Memory.Peak}
+	}
+
+	return containers
+}
+
+// summarizeHistorical calcula média e pico de uma série histórica. Amostras ausentes (lacunas,
+// NaN, ±Inf) são ignoradas em vez de contadas como zero.
+func summarizeHistorical(values []types.QueryResult) types.UsageStats {
+	var sum, peak float64
+	var count int
+	for _, v := range values {
+		if v.Missing {
+			continue
+		}
+		sum += v.Value
+		count++
+		if v.Value > peak {
+			peak = v.Value
+		}
+	}
+	if count == 0 {
+		return types.UsageStats{}
+	}
+	return types.UsageStats{
+		Average: sum / float64(count),
+		Peak:    peak,
+	}
+}
+
+// collectContainerHistory obtém o uso histórico por pod de cada container, com uma query por
+// recurso agrupada por container. Containers sem série no período ficam sem uso histórico.
+func (s *Service) collectContainerHistory(ctx context.Context, namespace string, kind types.WorkloadKind, name string, containers []*types.ContainerMetrics, start, end time.Time, step time.Duration) error {
+	cpuResult, err := s.metricsCollector.QueryRangeSeries(ctx, buildContainersCPUHistoricalQuery(namespace, kind, name), start, end, step)
+	if err != nil {
+		return fmt.Errorf("failed to get historical CPU metrics by container: %w", err)
+	}
+	memoryResult, err := s.metricsCollector.QueryRangeSeries(ctx, buildContainersMemoryHistoricalQuery(namespace, kind, name), start, end, step)
+	if err != nil {
+		return fmt.Errorf("failed to get historical memory metrics by container: %w", err)
+	}
+
+	cpuByContainer := samplesByLabel(cpuResult.Series, "container")
+	memoryByContainer := samplesByLabel(memoryResult.Series, "container")
+	for _, container := range containers {
+		container.CPU.Usage.Historical = summarizeHistorical(cpuByContainer[container.Name])
+		container.Memory.Usage.Historical = summarizeHistorical(memoryByContainer[container.Name])
+
+		logger.Info("Container historical metrics collected",
+			logger.NewField("container", container.Name),
+			logger.NewField("cpu_historical_avg", container.CPU.Usage.Historical.Average),
+			logger.NewField("memory_historical_avg", container.Memory.Usage.Historical.Average),
+		)
+	}
+	return nil
+}
+
+// samplesByLabel indexa as amostras das séries pelo valor de um label
+func samplesByLabel(series []types.Series, label string) map[string][]types.QueryResult {
+	samples := make(map[string][]types.QueryResult, len(series))
+	for _, s := range series {
+		key := s.Labels[label]
+		samples[key] = append(samples[key], s.Samples...)
+	}
+	return samples
+}
+
+// recommendContainers calcula as recomendações de CPU e memória de cada container.
+// Containers com OOMKill no período nunca recebem sugestão de redução de memória.
+func recommendContainers(containers []*types.ContainerMetrics) []*types.ContainerRecommendation {
+	recommendations := make([]*types.ContainerRecommendation, 0, len(containers))
+	for _, container := range containers {
+		recommendation := &types.ContainerRecommendation{
+			Name:   container.Name,
+			CPU:    &types.ResourceRecommendation{Status: "insufficient_data"},
+			Memory: &types.ResourceRecommendation{Status: "insufficient_data"},
+		}
+		if cpuRec := recommendCPU(container.CPU.Usage.Current, container.CPU.Request); cpuRec != nil {
+			recommendation.CPU = cpuRec
+		}
+		if memRec := recommendMemory(container.Memory.Usage.Current, container.Memory.Request); memRec != nil {
+			recommendation.Memory = memRec
+		}
+		// Nunca sugere reduzir a memória de um container que sofreu OOMKill no período
+		if container.OOMKilled {
+			recommendation.Memory = protectMemory(recommendation.Memory, container.Memory.Request)
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations
+}
+
+// rollupRecommendation soma as recomendações dos containers, na mesma ordem de containers, em uma
+// recomendação do pod. Containers sem dados suficientes mantêm o request atual, que entra tanto no
+// total atual quanto no sugerido. Retorna nil se nenhum container tiver recomendação.
+func rollupRecommendation(containers []*types.ContainerMetrics, recommendations []*types.ContainerRecommendation,
+	resource func(*types.ContainerRecommendation) *types.ResourceRecommendation, request func(*types.ContainerMetrics) float64) *types.ResourceRecommendation {
+	var current, suggested float64
+	recommended := 0
+	for i, container := range recommendations {
+		rec := resource(container)
+		if rec == nil || rec.Recommendation == nil {
+			current += request(containers[i])
+			suggested += request(containers[i])
+			continue
+		}
+		current += rec.Recommendation.Current
+		suggested += rec.Recommendation.Suggested
+		recommended++
+	}
+	if recommended == 0 {
+		return nil
+	}
+
+	return &types.ResourceRecommendation{
+		Status: "optimized",
+		Recommendation: &types.ResourceSuggestion{
+			Current:   current,
+			Suggested: suggested,
+			Action:    determineAction(current, suggested),
+		},
+	}
+}
+
+// calculateContainerCosts calcula os custos mensais por pod de cada container.
+// cpuHourlyPrice é o preço por core/hora e memoryHourlyPrice o preço por GB/hora, já convertidos.
+func calculateContainerCosts(containers []*types.ContainerMetrics, recommendations []*types.ContainerRecommendation, cpuHourlyPrice, memoryHourlyPrice float64) []*types.ContainerCost {
+	recommendationsByName := make(map[string]*types.ContainerRecommendation, len(recommendations))
+	for _, rec := range recommendations {
+		recommendationsByName[rec.Name] = rec
+	}
+
+	costs := make([]*types.ContainerCost, 0, len(containers))
+	for _, container := range containers {
+		current := monthlyCost(container.CPU.Request, container.Memory.Request, cpuHourlyPrice, memoryHourlyPrice)
+
+		suggestedCPU, suggestedMemory := container.CPU.Request, container.Memory.Request
+		if rec, ok := recommendationsByName[container.Name]; ok {
+			suggestedCPU = suggestedOrCurrent(rec.CPU, container.CPU.Request)
+			suggestedMemory = suggestedOrCurrent(rec.Memory, container.Memory.Request)
+		}
+		recommended := monthlyCost(suggestedCPU, suggestedMemory, cpuHourlyPrice, memoryHourlyPrice)
+
+		savings := &types.ResourceCosts{
+			CPU:    current.CPU - recommended.CPU,
+			Memory: current.Memory - recommended.Memory,
+		}
+		savings.Total = savings.CPU + savings.Memory
+
+		costs = append(costs, &types.ContainerCost{
+			Name:        container.Name,
+			Current:     current,
+			Recommended: recommended,
+			Savings:     savings,
+		})
+	}
+	return costs
+}
+
+// monthlyCost calcula o custo mensal (730 horas) de um request de CPU (milicores) e memória (Mi)
+func monthlyCost(cpuMillicores, memoryMi, cpuHourlyPrice, memoryHourlyPrice float64) *types.ResourceCosts {
+	costs := &types.ResourceCosts{
+		CPU:    cpuMillicores / 1000 * cpuHourlyPrice * 730,
+		Memory: memoryMi / 1024 * memoryHourlyPrice * 730,
+	}
+	costs.Total = costs.CPU + costs.Memory
+	return costs
+}
@@ -0,0 +1,211 @@
+package analyzer
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContainerConfig(name string, cpuRequest, memoryRequest float64) types.ContainerConfig {
+	config := types.ContainerConfig{Name: name}
+	config.CPU.Request = cpuRequest
+	config.Memory.Request = memoryRequest
+	return config
+}
+
+func newContainerUsage(name string, cpuAvg, cpuPeak, memoryAvg, memoryPeak float64) types.ContainerUsage {
+	usage := types.ContainerUsage{Name: name}
+	usage.CPU.Average = cpuAvg
+	usage.CPU.Peak = cpuPeak
+	usage.Memory.Average = memoryAvg
+	usage.Memory.Peak = memoryPeak
+	return usage
+}
+
+func TestBuildContainerMetrics(t *testing.T) {
+	config := &types.K8sDeploymentConfig{
+		Containers: []types.ContainerConfig{
+			newContainerConfig("app", 500, 512),
+			newContainerConfig("istio-proxy", 100, 128),
+		},
+	}
+	metrics := &types.K8sMetrics{
+		Containers: []types.ContainerUsage{
+			newContainerUsage("istio-proxy", 20, 30, 60, 70),
+			newContainerUsage("app", 200, 300, 256, 300),
+			newContainerUsage("injected", 5, 5, 10, 10),
+		},
+	}
+
+	containers := buildContainerMetrics(config, metrics)
+
+	assert.Len(t, containers, 3)
+	assert.Equal(t, "app", containers[0].Name)
+	assert.Equal(t, float64(500), containers[0].CPU.Request)
+	assert.Equal(t, float64(200), containers[0].CPU.Usage.Current.Average)
+	assert.Equal(t, "istio-proxy", containers[1].Name)
+	assert.Equal(t, float64(100), containers[1].CPU.Request)
+	assert.Equal(t, float64(30), containers[1].CPU.Usage.Current.Peak)
+	assert.Equal(t, "injected", containers[2].Name)
+	assert.Equal(t, float64(0), containers[2].CPU.Request)
+}
+
+func TestSummarizeHistorical(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []types.QueryResult
+		expected types.UsageStats
+	}{
+		{
+			name:     "Deve calcular média e pico",
+			values:   []types.QueryResult{{Value: 100}, {Value: 300}, {Value: 200}},
+			expected: types.UsageStats{Average: 200, Peak: 300},
+		},
+		{
+			name:     "Deve ignorar amostras ausentes",
+			values:   []types.QueryResult{{Value: 100}, {Value: math.NaN(), Missing: true}, {Value: 300}, {Missing: true}},
+			expected: types.UsageStats{Average: 200, Peak: 300},
+		},
+		{
+			name:     "Deve retornar zero quando todas as amostras estão ausentes",
+			values:   []types.QueryResult{{Missing: true}, {Value: math.Inf(1), Missing: true}},
+			expected: types.UsageStats{},
+		},
+		{
+			name:     "Deve retornar zero para série vazia",
+			values:   []types.QueryResult{},
+			expected: types.UsageStats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, summarizeHistorical(tt.values))
+		})
+	}
+}
+
+func TestCalculateRecommendations_PerContainer(t *testing.T) {
+	service := &Service{}
+	current := &types.CurrentMetrics{
+		Pods: &types.PodMetrics{},
+		Containers: []*types.ContainerMetrics{
+			{Name: "app"},
+			{Name: "istio-proxy"},
+		},
+	}
+	current.Containers[0].CPU.Request = 1000
+	current.Containers[0].CPU.Usage.Current = types.UsageStats{Average: 200, Peak: 250}
+	current.Containers[0].Memory.Request = 1024
+	current.Containers[0].Memory.Usage.Current = types.UsageStats{Average: 300, Peak: 350}
+	current.Containers[1].CPU.Request = 100
+	current.Containers[1].CPU.Usage.Current = types.UsageStats{Average: 80, Peak: 150}
+	current.Containers[1].Memory.Request = 128
+	current.Containers[1].Memory.Usage.Current = types.UsageStats{Average: 60, Peak: 70}
+
+	// Total do pod
+	current.Deployment.Config.CPU.Request = 1100
+	current.Deployment.Config.Memory.Request = 1152
+	current.Analysis.CPU.Usage.Current.Average = 280
+	current.Analysis.CPU.Usage.Current.Peak = 400
+	current.Analysis.Memory.Usage.Current.Average = 360
+	current.Analysis.Memory.Usage.Current.Peak = 420
+
+	analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+	assert.Len(t, analysis.Containers, 2)
+	// app: max(200*1.3, 250*1.1)=275 -> 300m
+	assert.Equal(t, float64(300), analysis.Containers[0].CPU.Recommendation.Suggested)
+	assert.Equal(t, "decrease", analysis.Containers[0].CPU.Recommendation.Action)
+	// istio-proxy: pico 150*1.1=165 -> 200m, sidecar subprovisionado
+	assert.Equal(t, float64(200), analysis.Containers[1].CPU.Recommendation.Suggested)
+	assert.Equal(t, "increase", analysis.Containers[1].CPU.Recommendation.Action)
+
+	// Total do pod é a soma dos containers
+	assert.Equal(t, float64(1100), analysis.CPU.Recommendation.Current)
+	assert.Equal(t, float64(500), analysis.CPU.Recommendation.Suggested)
+	assert.Equal(t, float64(1152), analysis.Memory.Recommendation.Current)
+	assert.Equal(t, float64(512+128), analysis.Memory.Recommendation.Suggested)
+}
+
+func TestCalculateRecommendations_ContainerWithoutData(t *testing.T) {
+	service := &Service{}
+	current := &types.CurrentMetrics{
+		Pods: &types.PodMetrics{},
+		Containers: []*types.ContainerMetrics{
+			{Name: "app"},
+			{Name: "istio-proxy"},
+		},
+	}
+	current.Containers[0].CPU.Request = 1000
+	current.Containers[0].CPU.Usage.Current = types.UsageStats{Average: 200, Peak: 250}
+	current.Containers[0].Memory.Request = 1024
+	current.Containers[0].Memory.Usage.Current = types.UsageStats{Average: 300, Peak: 350}
+	// istio-proxy sem uso coletado
+	current.Containers[1].CPU.Request = 100
+	current.Containers[1].Memory.Request = 128
+
+	current.Deployment.Config.CPU.Request = 1100
+	current.Deployment.Config.Memory.Request = 1152
+
+	analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+	assert.Equal(t, "insufficient_data", analysis.Containers[1].CPU.Status)
+	// O request atual do container sem dados entra no total atual e no sugerido do pod
+	assert.Equal(t, float64(1100), analysis.CPU.Recommendation.Current)
+	assert.Equal(t, float64(300+100), analysis.CPU.Recommendation.Suggested)
+	assert.Equal(t, float64(1152), analysis.Memory.Recommendation.Current)
+	assert.Equal(t, float64(512+128), analysis.Memory.Recommendation.Suggested)
+}
+
+func TestCalculateContainerCosts(t *testing.T) {
+	containers := []*types.ContainerMetrics{{Name: "app"}}
+	containers[0].CPU.Request = 1000
+	containers[0].Memory.Request = 1024
+	recommendations := []*types.ContainerRecommendation{
+		{
+			Name:   "app",
+			CPU:    &types.ResourceRecommendation{Recommendation: &types.ResourceSuggestion{Current: 1000, Suggested: 500}},
+			Memory: &types.ResourceRecommendation{Status: "insufficient_data"},
+		},
+	}
+
+	costs := calculateContainerCosts(containers, recommendations, 1, 1)
+
+	assert.Len(t, costs, 1)
+	assert.InDelta(t, 730.0, costs[0].Current.CPU, 0.001)
+	assert.InDelta(t, 365.0, costs[0].Recommended.CPU, 0.001)
+	assert.InDelta(t, 730.0, costs[0].Recommended.Memory, 0.001)
+	assert.InDelta(t, 365.0, costs[0].Savings.Total, 0.001)
+}
+
+func TestCollectContainerHistory(t *testing.T) {
+	cpuQuery := buildContainersCPUHistoricalQuery("default", types.WorkloadKindDeployment, "api")
+	memoryQuery := buildContainersMemoryHistoricalQuery("default", types.WorkloadKindDeployment, "api")
+	collector := &mockCollector{
+		series: map[string][]types.Series{
+			cpuQuery: {
+				{Labels: map[string]string{"container": "app"}, Samples: []types.QueryResult{{Value: 100}, {Value: 300}}},
+				{Labels: map[string]string{"container": "istio-proxy"}, Samples: []types.QueryResult{{Value: 20}}},
+			},
+			memoryQuery: {
+				{Labels: map[string]string{"container": "app"}, Samples: []types.QueryResult{{Value: 256}}},
+			},
+		},
+	}
+	service := NewService(collector, nil, nil)
+	containers := []*types.ContainerMetrics{{Name: "app"}, {Name: "istio-proxy"}, {Name: "init"}}
+
+	err := service.collectContainerHistory(context.Background(), "default", types.WorkloadKindDeployment, "api", containers, time.Now().Add(-time.Hour), time.Now(), historicalStep)
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.UsageStats{Average: 200, Peak: 300}, containers[0].CPU.Usage.Historical)
+	assert.Equal(t, types.UsageStats{Average: 256, Peak: 256}, containers[0].Memory.Usage.Historical)
+	assert.Equal(t, types.UsageStats{Average: 20, Peak: 20}, containers[1].CPU.Usage.Historical)
+	assert.Equal(t, types.UsageStats{}, containers[1].Memory.Usage.Historical)
+	assert.Equal(t, types.UsageStats{}, containers[2].CPU.Usage.Historical, "container sem série")
+}
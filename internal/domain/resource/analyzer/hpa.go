@@ -0,0 +1,339 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+)
+
+const (
+	// hpaTolerance é a tolerância padrão do controlador do HPA: variações de até 10%
+	// em relação ao alvo não disparam escala
+	hpaTolerance = 0.1
+	// hpaMinTarget e hpaTargetStep definem os alvos de utilização avaliados na recomendação
+	hpaMinTarget  = 40
+	hpaTargetStep = 5
+)
+
+// scalingRules representa as regras de escala de uma direção já com os padrões do Kubernetes aplicados
+type scalingRules struct {
+	window       time.Duration
+	selectPolicy string
+	policies     []types.HPAScalingPolicy
+}
+
+// hpaController modela o algoritmo do controlador do HPA para replay de histórico:
+// desired = ceil(current * utilização / alvo), tolerância, janelas de estabilização,
+// políticas de escala e limites de réplicas
+type hpaController struct {
+	settings  types.HPASettings
+	scaleUp   scalingRules
+	scaleDown scalingRules
+	tolerance float64
+}
+
+// newHPAController cria o modelo do controlador com o behavior informado ou os padrões do Kubernetes
+func newHPAController(settings types.HPASettings, behavior *types.HPABehavior) *hpaController {
+	controller := &hpaController{
+		settings:  settings,
+		tolerance: hpaTolerance,
+		// Padrões do Kubernetes: sem estabilização para subir, 5 minutos para descer
+		scaleUp: scalingRules{
+			selectPolicy: "Max",
+			policies: []types.HPAScalingPolicy{
+				{Type: "Percent", Value: 100, PeriodSeconds: 15},
+				{Type: "Pods", Value: 4, PeriodSeconds: 15},
+			},
+		},
+		scaleDown: scalingRules{
+			window:       5 * time.Minute,
+			selectPolicy: "Max",
+			policies: []types.HPAScalingPolicy{
+				{Type: "Percent", Value: 100, PeriodSeconds: 15},
+			},
+		},
+	}
+
+	if behavior != nil {
+		applyScalingRules(&controller.scaleUp, behavior.ScaleUp)
+		applyScalingRules(&controller.scaleDown, behavior.ScaleDown)
+	}
+	return controller
+}
+
+// applyScalingRules sobrescreve os padrões com as regras configuradas no HPA
+func applyScalingRules(rules *scalingRules, configured *types.HPAScalingRules) {
+	if configured == nil {
+		return
+	}
+	if configured.StabilizationWindowSeconds != nil {
+		rules.window = time.Duration(*configured.StabilizationWindowSeconds) * time.Second
+	}
+	if configured.SelectPolicy != "" {
+		rules.selectPolicy = configured.SelectPolicy
+	}
+	if len(configured.Policies) > 0 {
+		rules.policies = configured.Policies
+	}
+}
+
+// recommendation é uma recomendação de réplicas registrada para as janelas de estabilização
+type recommendation struct {
+	timestamp int64
+	replicas  int
+}
+
+// replay reproduz a série de uso total de CPU contra o controlador. A utilização de cada amostra
+// é calculada com as réplicas decididas na amostra anterior, refletindo o atraso do HPA.
+func (c *hpaController) replay(samples []types.UsageSample, step time.Duration, keepSeries bool) *types.HPAReplay {
+	if len(samples) == 0 || c.settings.CPURequest <= 0 || c.settings.TargetCPU <= 0 {
+		return nil
+	}
+
+	result := &types.HPAReplay{Settings: c.settings}
+	if keepSeries {
+		result.Series = make([]types.HPAReplayPoint, 0, len(samples))
+	}
+
+	// Assume que o workload começa estável para o uso da primeira amostra
+	replicas := c.clamp(int(math.Ceil(samples[0].Value / c.capacityAtTarget())))
+	var history []recommendation
+	var sumUtilization, sumReplicas float64
+
+	for _, sample := range samples {
+		utilization := sample.Value / (float64(replicas) * c.settings.CPURequest) * 100
+
+		result.PeakUtilization = math.Max(result.PeakUtilization, utilization)
+		sumUtilization += utilization
+		sumReplicas += float64(replicas)
+		if replicas == c.settings.MaxReplicas {
+			result.TimeAtMaxSeconds += int64(step.Seconds())
+		}
+		if keepSeries {
+			result.Series = append(result.Series, types.HPAReplayPoint{
+				Timestamp:   sample.Timestamp,
+				Usage:       sample.Value,
+				Replicas:    replicas,
+				Utilization: utilization,
+			})
+		}
+
+		replicas, history = c.next(replicas, utilization, sample.Timestamp, step, history)
+	}
+
+	count := float64(len(samples))
+	result.AverageUtilization = sumUtilization / count
+	result.AverageReplicas = sumReplicas / count
+	result.ReplicaHours = sumReplicas * step.Hours()
+	return result
+}
+
+// next calcula as réplicas após uma iteração do controlador
+func (c *hpaController) next(replicas int, utilization float64, timestamp int64, step time.Duration, history []recommendation) (int, []recommendation) {
+	desired := replicas
+	ratio := utilization / float64(c.settings.TargetCPU)
+	if math.Abs(ratio-1) > c.tolerance {
+		desired = int(math.Ceil(float64(replicas) * ratio))
+	}
+	history = append(history, recommendation{timestamp: timestamp, replicas: desired})
+
+	// Janelas de estabilização: sobe para o menor valor recomendado na janela de subida
+	// e desce para o maior valor recomendado na janela de descida
+	upRecommendation, downRecommendation := desired, desired
+	maxWindow := max(c.scaleUp.window, c.scaleDown.window)
+	kept := history[:0]
+	for _, rec := range history {
+		age := time.Duration(timestamp-rec.timestamp) * time.Second
+		if age > maxWindow {
+			continue
+		}
+		kept = append(kept, rec)
+		if age <= c.scaleUp.window {
+			upRecommendation = min(upRecommendation, rec.replicas)
+		}
+		if age <= c.scaleDown.window {
+			downRecommendation = max(downRecommendation, rec.replicas)
+		}
+	}
+
+	stabilized := replicas
+	if stabilized < upRecommendation {
+		stabilized = upRecommendation
+	}
+	if stabilized > downRecommendation {
+		stabilized = downRecommendation
+	}
+
+	// Políticas de escala limitam a variação por período
+	if stabilized > replicas {
+		stabilized = min(stabilized, c.scaleUpLimit(replicas, step))
+	} else if stabilized < replicas {
+		stabilized = max(stabilized, c.scaleDownLimit(replicas, step))
+	}
+
+	return c.clamp(stabilized), kept
+}
+
+// scaleUpLimit retorna o máximo de réplicas permitido pelas políticas de subida no intervalo
+func (c *hpaController) scaleUpLimit(replicas int, step time.Duration) int {
+	if c.scaleUp.selectPolicy == "Disabled" {
+		return replicas
+	}
+
+	limits := make([]int, 0, len(c.scaleUp.policies))
+	for _, policy := range c.scaleUp.policies {
+		periods := policyPeriods(policy, step)
+		switch policy.Type {
+		case "Pods":
+			limits = append(limits, replicas+int(math.Ceil(float64(policy.Value)*periods)))
+		case "Percent":
+			limits = append(limits, int(math.Ceil(float64(replicas)*math.Pow(1+float64(policy.Value)/100, periods))))
+		}
+	}
+	return selectLimit(limits, c.scaleUp.selectPolicy == "Min", replicas)
+}
+
+// scaleDownLimit retorna o mínimo de réplicas permitido pelas políticas de descida no intervalo
+func (c *hpaController) scaleDownLimit(replicas int, step time.Duration) int {
+	if c.scaleDown.selectPolicy == "Disabled" {
+		return replicas
+	}
+
+	limits := make([]int, 0, len(c.scaleDown.policies))
+	for _, policy := range c.scaleDown.policies {
+		periods := policyPeriods(policy, step)
+		switch policy.Type {
+		case "Pods":
+			limits = append(limits, replicas-int(math.Ceil(float64(policy.Value)*periods)))
+		case "Percent":
+			limits = append(limits, int(math.Ceil(float64(replicas)*math.Pow(1-float64(policy.Value)/100, periods))))
+		}
+	}
+	// Na descida, a política que permite mais variação é a que resulta em menos réplicas
+	return selectLimit(limits, c.scaleDown.selectPolicy != "Min", replicas)
+}
+
+// policyPeriods retorna quantos períodos da política cabem no intervalo (no mínimo um)
+func policyPeriods(policy types.HPAScalingPolicy, step time.Duration) float64 {
+	if policy.PeriodSeconds <= 0 {
+		return 1
+	}
+	return math.Max(1, step.Seconds()/float64(policy.PeriodSeconds))
+}
+
+// selectLimit retorna o menor (lowest=true) ou o maior limite, ou o fallback quando não há limites
+func selectLimit(limits []int, lowest bool, fallback int) int {
+	if len(limits) == 0 {
+		return fallback
+	}
+	selected := limits[0]
+	for _, limit := range limits[1:] {
+		if lowest {
+			selected = min(selected, limit)
+		} else {
+			selected = max(selected, limit)
+		}
+	}
+	return selected
+}
+
+// capacityAtTarget retorna o uso total suportado por uma réplica no alvo de utilização, em milicores
+func (c *hpaController) capacityAtTarget() float64 {
+	return c.settings.CPURequest * float64(c.settings.TargetCPU) / 100
+}
+
+// clamp limita as réplicas a [MinReplicas, MaxReplicas]
+func (c *hpaController) clamp(replicas int) int {
+	return max(c.settings.MinReplicas, min(c.settings.MaxReplicas, replicas))
+}
+
+// recommendHPA avalia alvos de utilização e limites de réplicas pelo replay do histórico e
+// recomenda a configuração que mantém o pico de utilização projetado abaixo do teto com o
+// menor número de réplicas-hora
+func (s *Service) recommendHPA(current *types.CurrentMetrics, historical *types.HistoricalMetrics, cpuRequest float64) *types.HPARecommendation {
+	ceiling := s.hpaUtilizationCeiling
+	if ceiling <= 0 {
+		ceiling = DefaultHPAUtilizationCeiling
+	}
+
+	result := &types.HPARecommendation{
+		Status:             "insufficient_data",
+		UtilizationCeiling: ceiling,
+	}
+	if historical == nil || len(historical.CPUUsage) < 2 || cpuRequest <= 0 {
+		return result
+	}
+
+	step := replayStep(historical)
+
+	var behavior *types.HPABehavior
+	hpa := current.Deployment.Config.HPA.Spec
+	if hpa != nil {
+		behavior = hpa.Behavior
+		// Replay da configuração atual, quando o HPA escala por utilização de CPU
+		settings := types.HPASettings{
+			TargetCPU:   hpa.ResourceUtilizationTarget("cpu"),
+			MinReplicas: hpa.MinReplicas,
+			MaxReplicas: hpa.MaxReplicas,
+			CPURequest:  current.Deployment.Config.CPU.Request,
+		}
+		result.Current = newHPAController(settings, behavior).replay(historical.CPUUsage, step, false)
+	}
+
+	var peakUsage float64
+	for _, sample := range historical.CPUUsage {
+		peakUsage = math.Max(peakUsage, sample.Value)
+	}
+
+	// A utilização pode ficar até a tolerância acima do alvo sem disparar escala
+	for target := int32(hpaMinTarget); float64(target)*(1+hpaTolerance) <= ceiling; target += hpaTargetStep {
+		maxReplicas := max(1, int(math.Ceil(peakUsage/(cpuRequest*float64(target)/100))))
+
+		// Menor minReplicas que mantém o pico projetado abaixo do teto
+		minReplicas := sort.Search(maxReplicas, func(i int) bool {
+			settings := types.HPASettings{TargetCPU: target, MinReplicas: i + 1, MaxReplicas: maxReplicas, CPURequest: cpuRequest}
+			replay := newHPAController(settings, behavior).replay(historical.CPUUsage, step, false)
+			return replay != nil && replay.PeakUtilization <= ceiling
+		}) + 1
+		if minReplicas > maxReplicas {
+			continue
+		}
+		settings := types.HPASettings{TargetCPU: target, MinReplicas: minReplicas, MaxReplicas: maxReplicas, CPURequest: cpuRequest}
+		feasible := newHPAController(settings, behavior).replay(historical.CPUUsage, step, false)
+
+		// Em caso de empate, prefere o alvo mais alto
+		if result.Suggested == nil || feasible.ReplicaHours <= result.Suggested.ReplicaHours {
+			result.Suggested = feasible
+		}
+	}
+
+	if result.Suggested != nil {
+		result.Status = "optimized"
+	}
+	return result
+}
+
+// scalesOnCPU verifica se o HPA escala por utilização de CPU
+func scalesOnCPU(hpa *types.HPAConfig) bool {
+	return hpa != nil && hpa.ResourceUtilizationTarget("cpu") > 0
+}
+
+// replayStep retorna o intervalo entre as amostras do histórico usado no replay
+func replayStep(historical *types.HistoricalMetrics) time.Duration {
+	step := time.Duration(historical.Step) * time.Second
+	if step <= 0 {
+		step = 5 * time.Minute
+	}
+	return step
+}
+
+// desiredReplicas retorna as réplicas que a configuração mantém em regime para o uso total informado
+func desiredReplicas(settings types.HPASettings, usage float64) int {
+	controller := newHPAController(settings, nil)
+	if controller.capacityAtTarget() <= 0 {
+		return settings.MinReplicas
+	}
+	return controller.clamp(int(math.Ceil(usage / controller.capacityAtTarget())))
+}
@@ -0,0 +1,219 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// usageSeries gera uma série de uso com amostras a cada 5 minutos
+func usageSeries(values ...float64) []types.UsageSample {
+	samples := make([]types.UsageSample, len(values))
+	for i, value := range values {
+		samples[i] = types.UsageSample{Timestamp: int64(i * 300), Value: value}
+	}
+	return samples
+}
+
+func TestHPAControllerReplay(t *testing.T) {
+	step := 5 * time.Minute
+	settings := types.HPASettings{TargetCPU: 50, MinReplicas: 1, MaxReplicas: 10, CPURequest: 1000}
+
+	t.Run("uso constante mantém as réplicas no alvo", func(t *testing.T) {
+		replay := newHPAController(settings, nil).replay(usageSeries(2000, 2000, 2000), step, true)
+
+		assert.Equal(t, 4.0, replay.AverageReplicas)
+		assert.Equal(t, 50.0, replay.PeakUtilization)
+		assert.Equal(t, 1.0, replay.ReplicaHours)
+		assert.Len(t, replay.Series, 3)
+	})
+
+	t.Run("pico é absorvido com atraso de uma amostra", func(t *testing.T) {
+		replay := newHPAController(settings, nil).replay(usageSeries(1000, 4000, 4000), step, true)
+
+		// 2 réplicas decididas para 1000m recebem o pico de 4000m
+		assert.Equal(t, 2, replay.Series[1].Replicas)
+		assert.Equal(t, 200.0, replay.Series[1].Utilization)
+		assert.Equal(t, 8, replay.Series[2].Replicas)
+		assert.Equal(t, 200.0, replay.PeakUtilization)
+	})
+
+	t.Run("estabilização atrasa a redução de réplicas", func(t *testing.T) {
+		replay := newHPAController(settings, nil).replay(usageSeries(4000, 1000, 1000, 1000), step, true)
+
+		// A janela padrão de 5 minutos mantém a recomendação de 8 réplicas por uma amostra
+		assert.Equal(t, 8, replay.Series[1].Replicas)
+		assert.Equal(t, 8, replay.Series[2].Replicas)
+		assert.Equal(t, 2, replay.Series[3].Replicas)
+	})
+
+	t.Run("respeita o máximo de réplicas", func(t *testing.T) {
+		limited := settings
+		limited.MaxReplicas = 3
+		replay := newHPAController(limited, nil).replay(usageSeries(6000, 6000), step, false)
+
+		assert.Equal(t, 3.0, replay.AverageReplicas)
+		assert.Equal(t, int64(600), replay.TimeAtMaxSeconds)
+		assert.Equal(t, 200.0, replay.PeakUtilization)
+	})
+
+	t.Run("política desabilitada impede a subida", func(t *testing.T) {
+		behavior := &types.HPABehavior{ScaleUp: &types.HPAScalingRules{SelectPolicy: "Disabled"}}
+		replay := newHPAController(settings, behavior).replay(usageSeries(1000, 4000, 4000), step, true)
+
+		assert.Equal(t, 2, replay.Series[2].Replicas)
+	})
+
+	t.Run("política de pods limita a subida", func(t *testing.T) {
+		behavior := &types.HPABehavior{ScaleUp: &types.HPAScalingRules{
+			Policies: []types.HPAScalingPolicy{{Type: "Pods", Value: 1, PeriodSeconds: 300}},
+		}}
+		replay := newHPAController(settings, behavior).replay(usageSeries(1000, 4000, 4000), step, true)
+
+		assert.Equal(t, 3, replay.Series[2].Replicas)
+	})
+
+	t.Run("sem request não há replay", func(t *testing.T) {
+		empty := settings
+		empty.CPURequest = 0
+		assert.Nil(t, newHPAController(empty, nil).replay(usageSeries(1000), step, false))
+	})
+}
+
+func TestRecommendHPA(t *testing.T) {
+	service := NewService(nil, nil, nil)
+	historical := &types.HistoricalMetrics{
+		CPUUsage: usageSeries(1000, 1000, 3000, 3000, 1000, 1000),
+		Step:     300,
+	}
+
+	t.Run("recomenda configuração com pico abaixo do teto", func(t *testing.T) {
+		current := &types.CurrentMetrics{}
+		current.Deployment.Config.CPU.Request = 1000
+
+		result := service.recommendHPA(current, historical, 1000)
+
+		assert.Equal(t, "optimized", result.Status)
+		assert.Equal(t, DefaultHPAUtilizationCeiling, result.UtilizationCeiling)
+		assert.Nil(t, result.Current)
+		if assert.NotNil(t, result.Suggested) {
+			assert.LessOrEqual(t, result.Suggested.PeakUtilization, DefaultHPAUtilizationCeiling)
+			assert.LessOrEqual(t, float64(result.Suggested.Settings.TargetCPU)*(1+hpaTolerance), DefaultHPAUtilizationCeiling)
+			assert.GreaterOrEqual(t, result.Suggested.Settings.MaxReplicas, result.Suggested.Settings.MinReplicas)
+		}
+	})
+
+	t.Run("replay da configuração atual", func(t *testing.T) {
+		utilization := int32(80)
+		current := &types.CurrentMetrics{}
+		current.Deployment.Config.CPU.Request = 1000
+		current.Deployment.Config.HPA.Spec = &types.HPAConfig{
+			MinReplicas: 2,
+			MaxReplicas: 10,
+			Metrics: []types.HPAMetric{{
+				Type:   types.HPAMetricResource,
+				Name:   "cpu",
+				Target: types.HPAMetricValue{Type: types.HPATargetUtilization, AverageUtilization: &utilization},
+			}},
+		}
+
+		result := service.recommendHPA(current, historical, 500)
+
+		if assert.NotNil(t, result.Current) {
+			assert.Equal(t, int32(80), result.Current.Settings.TargetCPU)
+			assert.Equal(t, 1000.0, result.Current.Settings.CPURequest)
+			// Com 2 réplicas, o pico de 3000m chega a 150% antes da reação do HPA
+			assert.Equal(t, 150.0, result.Current.PeakUtilization)
+		}
+		if assert.NotNil(t, result.Suggested) {
+			assert.Equal(t, 500.0, result.Suggested.Settings.CPURequest)
+		}
+	})
+
+	t.Run("dados insuficientes", func(t *testing.T) {
+		current := &types.CurrentMetrics{}
+		result := service.recommendHPA(current, &types.HistoricalMetrics{CPUUsage: usageSeries(1000)}, 1000)
+
+		assert.Equal(t, "insufficient_data", result.Status)
+		assert.Nil(t, result.Suggested)
+	})
+}
+
+func TestCalculateRecommendations_HPA(t *testing.T) {
+	current := &types.CurrentMetrics{}
+	current.Deployment.Kind = types.WorkloadKindDeployment
+	current.Deployment.Config.CPU.Request = 1000
+	current.Deployment.Config.Memory.Request = 1024
+	current.Deployment.Config.HPA.MinReplicas = 1
+	current.Deployment.Config.HPA.MaxReplicas = 10
+	current.Pods = &types.PodMetrics{Running: 2}
+	current.Analysis.CPU.Usage.Current = types.UsageStats{Average: 500, Peak: 600}
+	current.Analysis.Memory.Usage.Current = types.UsageStats{Average: 512, Peak: 600}
+	historical := &types.HistoricalMetrics{
+		CPUUsage: usageSeries(1000, 1000, 2000, 1000),
+		Step:     300,
+	}
+
+	// Sem HPA, a sugestão é apenas uma proposta e as réplicas recomendadas não mudam
+	analysis := NewService(nil, nil, nil).CalculateRecommendations(current, historical)
+
+	if assert.NotNil(t, analysis.HPA) && assert.NotNil(t, analysis.HPA.Suggested) {
+		assert.Equal(t, "proposed", analysis.HPA.Status)
+		assert.Equal(t, &types.PodCount{Current: 2, Suggested: calculateSuggestedPods(current, historical), Min: 1, Max: 10}, analysis.Pods.Recommendation)
+	}
+
+	// Com HPA de CPU, as réplicas recomendadas seguem o HPA sugerido
+	utilization := int32(80)
+	current.Deployment.Config.HPA.Spec = &types.HPAConfig{
+		MinReplicas: 1,
+		MaxReplicas: 10,
+		Metrics: []types.HPAMetric{{
+			Type:   types.HPAMetricResource,
+			Name:   "cpu",
+			Target: types.HPAMetricValue{Type: types.HPATargetUtilization, AverageUtilization: &utilization},
+		}},
+	}
+	analysis = NewService(nil, nil, nil).CalculateRecommendations(current, historical)
+
+	if assert.NotNil(t, analysis.HPA) && assert.NotNil(t, analysis.HPA.Suggested) {
+		assert.Equal(t, "optimized", analysis.HPA.Status)
+		settings := analysis.HPA.Suggested.Settings
+		assert.Equal(t, settings.MinReplicas, analysis.Pods.Recommendation.Min)
+		assert.Equal(t, settings.MaxReplicas, analysis.Pods.Recommendation.Max)
+		assert.Equal(t, desiredReplicas(settings, 1000), analysis.Pods.Recommendation.Suggested)
+	}
+
+	// DaemonSets não recebem recomendação de HPA
+	current.Deployment.Kind = types.WorkloadKindDaemonSet
+	assert.Nil(t, NewService(nil, nil, nil).CalculateRecommendations(current, historical).HPA)
+}
+
+func TestCalculateRecommendations_KEDA(t *testing.T) {
+	current := &types.CurrentMetrics{}
+	current.Deployment.Kind = types.WorkloadKindDeployment
+	current.Deployment.Config.CPU.Request = 1000
+	current.Deployment.Config.Memory.Request = 1024
+	current.Deployment.Config.KEDA = &types.KEDAScaledObject{
+		Name:        "consumer",
+		MinReplicas: 0,
+		MaxReplicas: 20,
+		Triggers:    []types.KEDATrigger{{Type: "kafka"}},
+	}
+	current.Pods = &types.PodMetrics{Running: 6}
+	// Uso baixo de CPU não reduz as réplicas de um consumidor de fila
+	current.Analysis.CPU.Usage.Current = types.UsageStats{Average: 100, Peak: 150}
+	current.Analysis.Memory.Usage.Current = types.UsageStats{Average: 128, Peak: 150}
+	historical := &types.HistoricalMetrics{
+		CPUUsage: usageSeries(600, 600, 1200, 600),
+		Step:     300,
+	}
+
+	analysis := NewService(nil, nil, nil).CalculateRecommendations(current, historical)
+
+	assert.Equal(t, "keda", analysis.Pods.ScalingSource)
+	assert.Equal(t, "event_driven", analysis.HPA.Status)
+	assert.Nil(t, analysis.HPA.Suggested)
+	assert.Equal(t, &types.PodCount{Current: 6, Suggested: 6, Min: 0, Max: 20}, analysis.Pods.Recommendation)
+}
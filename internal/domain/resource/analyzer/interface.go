@@ -14,40 +14,38 @@ import (
 // Esta interface segue o princípio de segregação de interfaces do SOLID, fornecendo
 // métodos específicos para cada tipo de análise necessária.
 type ResourceAnalyzer interface {
-	// GetMetrics retorna métricas atuais e históricas de um deployment.
+	// GetMetrics retorna métricas atuais e históricas de um workload.
 	// Coleta dados de utilização de CPU (em milicores), memória (em Mi) e pods,
 	// incluindo configurações de HPA e limites de recursos.
 	//
 	// Parâmetros:
 	//   - ctx: Contexto da requisição
-	//   - namespace: Namespace do Kubernetes onde o deployment está localizado
-	//   - deployment: Nome do deployment
+	//   - namespace: Namespace do Kubernetes onde o workload está localizado
+	//   - kind: Tipo do workload (Deployment, StatefulSet, DaemonSet ou ReplicaSet)
+	//   - name: Nome do workload
 	//   - period: Período de tempo para análise histórica
 	//
 	// Retorna:
 	//   - MetricsResponse: Contém métricas atuais, históricas e metadados
 	//   - error: Erro em caso de falha na coleta
-	GetMetrics(ctx context.Context, namespace, deployment string, period time.Duration) (*types.MetricsResponse, error)
+	GetMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error)
 
-	// GetTrends analisa tendências de utilização de recursos ao longo do tempo.
-	// Calcula padrões de uso, sazonalidade e projeta tendências futuras.
+	// CalculateTrends analisa tendências de utilização de recursos ao longo do tempo.
+	// Usa o histórico já coletado por GetMetrics, sem novas consultas.
 	//
 	// Parâmetros:
-	//   - ctx: Contexto da requisição
-	//   - namespace: Namespace do Kubernetes
-	//   - deployment: Nome do deployment
+	//   - metrics: Métricas retornadas por GetMetrics
 	//   - period: Período para análise de tendências
 	//
 	// Retorna:
 	//   - TrendsResponse: Contém análises de tendência para CPU, memória e pods
-	//   - error: Erro em caso de falha na análise
-	GetTrends(ctx context.Context, namespace, deployment string, period time.Duration) (*types.TrendsResponse, error)
+	CalculateTrends(metrics *types.MetricsResponse, period time.Duration) *types.TrendsResponse
 
 	// AnalyzeResources realiza análise detalhada dos recursos atuais e históricos.
 	// Avalia eficiência, identifica gargalos e sugere otimizações.
 	//
 	// Parâmetros:
-	//   - current: Métricas atuais do deployment
+	//   - current: Métricas atuais do workload
 	//   - historical: Histórico de métricas para análise comparativa
 	//
 	// Retorna:
@@ -78,3 +76,125 @@ type ResourceAnalyzer interface {
 	//   - []Alert: Lista de alertas gerados
 	GenerateAlerts(current *types.CurrentMetrics, historical *types.HistoricalMetrics) []types.Alert
 }
+
+// NamespaceAnalyzer define a análise agregada de todos os workloads de um namespace.
+type NamespaceAnalyzer interface {
+	// AnalyzeNamespace analisa todos os workloads de um namespace com concorrência limitada
+	// e retorna um resumo agregado de recursos e custos. Os workloads são ordenados pela
+	// economia mensal potencial, do maior para o menor. Falhas em workloads individuais
+	// são reportadas em Errors sem interromper a análise.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//   - period: Período de tempo para análise histórica
+	//
+	// Retorna:
+	//   - NamespaceAnalysis: Resumo do namespace e ranking de workloads
+	//   - error: Erro em caso de falha na listagem dos workloads
+	AnalyzeNamespace(ctx context.Context, namespace string, period time.Duration) (*types.NamespaceAnalysis, error)
+}
+
+// QuotaAnalyzer define a análise de ResourceQuotas e LimitRanges de um namespace.
+type QuotaAnalyzer interface {
+	// AnalyzeQuotas reporta a folga de cada recurso dos ResourceQuotas do namespace e recomenda
+	// o tamanho das quotas de requests e pods a partir do uso máximo do namespace no período.
+	// Também sinaliza requests padrão de LimitRanges que super ou subdimensionam a maioria dos
+	// containers sem request explícito.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//   - period: Período de tempo para análise histórica
+	//
+	// Retorna:
+	//   - QuotaAnalysis: Uso e recomendação das quotas e análise dos padrões dos LimitRanges
+	//   - error: Erro em caso de falha na coleta das quotas ou do uso do namespace
+	AnalyzeQuotas(ctx context.Context, namespace string, period time.Duration) (*types.QuotaAnalysis, error)
+}
+
+// ClusterAnalyzer define o inventário e showback de todo o cluster.
+type ClusterAnalyzer interface {
+	// GenerateClusterReport analisa os workloads de todos os namespaces visíveis e agrega
+	// recursos requisitados e usados e custos atual e recomendado por namespace e por label.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - period: Período de tempo para análise histórica
+	//   - groupBy: Label usado no agrupamento (vazio usa o label configurado)
+	//   - top: Número de maiores desperdícios retornados (0 usa o valor configurado)
+	//
+	// Retorna:
+	//   - ClusterReport: Totais do cluster, agrupamentos e maiores desperdícios
+	//   - error: Erro em caso de falha na listagem dos namespaces
+	GenerateClusterReport(ctx context.Context, period time.Duration, groupBy string, top int) (*types.ClusterReport, error)
+}
+
+// NodeAnalyzer define a análise de capacidade dos nodes do cluster.
+type NodeAnalyzer interface {
+	// AnalyzeNodes compara a capacidade alocável de cada node com a soma dos requests e com
+	// o uso real, reportando capacidade ociosa e encalhada por node e por node pool. Também
+	// simula quantos nodes seriam necessários se todos os workloads adotassem os requests recomendados.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - period: Período usado no uso médio dos nodes e nas recomendações dos workloads
+	//
+	// Retorna:
+	//   - NodeAnalysis: Capacidade por node e pool e simulação de bin-packing
+	//   - error: Erro em caso de falha na coleta do inventário
+	AnalyzeNodes(ctx context.Context, period time.Duration) (*types.NodeAnalysis, error)
+}
+
+// HPASimulator define a simulação de configurações de HPA sobre o histórico de uso.
+type HPASimulator interface {
+	// SimulateHPA reproduz o histórico de uso de CPU do workload contra um modelo do controlador
+	// do HPA, com a configuração atual e com a configuração proposta (request de CPU, alvo de
+	// utilização e mínimo e máximo de réplicas), permitindo avaliar a mudança antes de aplicá-la.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//   - kind: Tipo do workload
+	//   - name: Nome do workload
+	//   - period: Período do histórico reproduzido
+	//   - input: Configuração proposta
+	//
+	// Retorna:
+	//   - HPASimulation: Séries de réplicas projetadas, tempo no máximo de réplicas e custos
+	//   - error: Erro de configuração inválida, histórico insuficiente ou falha na coleta
+	SimulateHPA(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration, input types.HPASimulationInput) (*types.HPASimulation, error)
+}
+
+// ClusterComparer define a comparação de um workload entre clusters.
+type ClusterComparer interface {
+	// CompareClusters analisa o mesmo workload em dois ou mais clusters e o compara lado a lado:
+	// uso, requests e recomendações por réplica e custos mensais, com as diferenças em relação
+	// ao primeiro cluster analisado. Sinaliza clusters com requests equivalentes aos de um cluster
+	// de uso muito maior cuja recomendação é reduzir o recurso (ex: staging com requests de produção).
+	// Falhas em clusters individuais são reportadas em Errors sem interromper a comparação.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - clusters: Clusters comparados; o primeiro é a referência das diferenças
+	//   - namespace: Namespace do Kubernetes
+	//   - kind: Tipo do workload
+	//   - name: Nome do workload
+	//   - period: Período de tempo para análise histórica
+	//
+	// Retorna:
+	//   - ClusterComparison: Análise por cluster, diferenças e requests superdimensionados
+	//   - error: Erro de configuração inválida ou falha em todos os clusters
+	CompareClusters(ctx context.Context, clusters []string, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.ClusterComparison, error)
+}
+
+// Analyzer agrupa todas as capacidades de análise expostas pela API.
+type Analyzer interface {
+	ResourceAnalyzer
+	NamespaceAnalyzer
+	QuotaAnalyzer
+	ClusterAnalyzer
+	NodeAnalyzer
+	HPASimulator
+	ClusterComparer
+}
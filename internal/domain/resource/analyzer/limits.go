@@ -0,0 +1,219 @@
+package analyzer
+
+import (
+	"math"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+)
+
+// memoryOvercommitRisk é a razão limit/request de memória do pod a partir da qual o par
+// recomendado é considerado arriscado: sob pressão no node, o uso acima do request leva a eviction
+const memoryOvercommitRisk = 1.5
+
+// limitPolicy define como os limits recomendados são derivados dos requests recomendados
+type limitPolicy struct {
+	// cpuRatio e memoryRatio são as razões limit/request; 0 preserva a razão atual do container
+	cpuRatio    float64
+	memoryRatio float64
+	// guaranteed mantém limit igual ao request para preservar a classe Guaranteed
+	guaranteed bool
+}
+
+// containerResources representa requests e limits de um container, em milicores e Mi
+type containerResources struct {
+	cpuRequest, cpuLimit       float64
+	memoryRequest, memoryLimit float64
+}
+
+// recommendLimits calcula os limits recomendados de cada container preservando a classe de QoS do pod.
+// Se a razão configurada tornaria Guaranteed um pod Burstable, os limits preservam a razão atual.
+func (s *Service) recommendLimits(containers []*types.ContainerMetrics, recommendations []*types.ContainerRecommendation) *types.QoSRecommendation {
+	current := qosClass(currentResources(containers))
+	policy := limitPolicy{
+		cpuRatio:    s.cpuLimitRatio,
+		memoryRatio: s.memoryLimitRatio,
+		guaranteed:  current == types.QoSClassGuaranteed,
+	}
+
+	s.applyLimitPolicy(containers, recommendations, policy)
+	suggested := qosClass(suggestedResources(containers, recommendations))
+	if current == types.QoSClassBurstable && suggested == types.QoSClassGuaranteed {
+		s.applyLimitPolicy(containers, recommendations, limitPolicy{})
+		suggested = qosClass(suggestedResources(containers, recommendations))
+	}
+
+	return &types.QoSRecommendation{Current: current, Suggested: suggested}
+}
+
+// applyLimitPolicy calcula os limits de CPU e memória de cada container com a política informada
+func (s *Service) applyLimitPolicy(containers []*types.ContainerMetrics, recommendations []*types.ContainerRecommendation, policy limitPolicy) {
+	for i, container := range containers {
+		recommendations[i].CPULimit = s.recommendCPULimit(container, recommendations[i].CPU, policy)
+		recommendations[i].MemoryLimit = recommendMemoryLimit(container, recommendations[i].Memory, policy)
+	}
+}
+
+// recommendMemoryLimit calcula a recomendação de limit de memória de um container.
+// O limit segue a razão limit/request da política e nunca é reduzido em containers que sofreram OOMKill.
+// Retorna nil quando o container não tem limit de memória.
+func recommendMemoryLimit(container *types.ContainerMetrics, request *types.ResourceRecommendation, policy limitPolicy) *types.ResourceRecommendation {
+	limit := container.Memory.Limit
+	if limit <= 0 {
+		return nil
+	}
+
+	status := "optimized"
+	suggested := limitForRequest(limit, container.Memory.Request, suggestedOrCurrent(request, container.Memory.Request), policy.memoryRatio, policy.guaranteed, 128)
+	if container.OOMKilled {
+		status = "oom_protected"
+		suggested = max(suggested, limit)
+	}
+
+	return &types.ResourceRecommendation{
+		Status: status,
+		Recommendation: &types.ResourceSuggestion{
+			Current:   limit,
+			Suggested: suggested,
+			Action:    determineAction(limit, suggested),
+		},
+	}
+}
+
+// limitForRequest calcula o limit para o request recomendado com a razão limit/request informada,
+// arredondado para cima no múltiplo de step. Sem razão, preserva a razão atual do container.
+// Em pods Guaranteed o limit é igual ao request.
+func limitForRequest(currentLimit, currentRequest, suggestedRequest, ratio float64, guaranteed bool, step float64) float64 {
+	if guaranteed {
+		return suggestedRequest
+	}
+	if ratio <= 0 {
+		ratio = 1
+		if currentRequest > 0 {
+			ratio = currentLimit / currentRequest
+		}
+	}
+	return math.Ceil(suggestedRequest*max(ratio, 1)/step) * step
+}
+
+// currentResources retorna requests e limits atuais dos containers.
+// Como no Kubernetes, um container com limit e sem request usa o limit como request.
+func currentResources(containers []*types.ContainerMetrics) []containerResources {
+	resources := make([]containerResources, 0, len(containers))
+	for _, c := range containers {
+		r := containerResources{
+			cpuRequest:    c.CPU.Request,
+			cpuLimit:      c.CPU.Limit,
+			memoryRequest: c.Memory.Request,
+			memoryLimit:   c.Memory.Limit,
+		}
+		if r.cpuRequest == 0 {
+			r.cpuRequest = r.cpuLimit
+		}
+		if r.memoryRequest == 0 {
+			r.memoryRequest = r.memoryLimit
+		}
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+// suggestedResources retorna requests e limits dos containers após as recomendações.
+// Recursos sem recomendação mantêm o valor atual; um limit removido fica zerado.
+func suggestedResources(containers []*types.ContainerMetrics, recommendations []*types.ContainerRecommendation) []containerResources {
+	resources := currentResources(containers)
+	for i := range resources {
+		rec := recommendations[i]
+		resources[i].cpuRequest = suggestedOrCurrent(rec.CPU, resources[i].cpuRequest)
+		resources[i].cpuLimit = suggestedOrCurrent(rec.CPULimit, resources[i].cpuLimit)
+		resources[i].memoryRequest = suggestedOrCurrent(rec.Memory, resources[i].memoryRequest)
+		resources[i].memoryLimit = suggestedOrCurrent(rec.MemoryLimit, resources[i].memoryLimit)
+	}
+	return resources
+}
+
+// qosClass determina a classe de QoS de um pod pelos requests e limits dos containers
+func qosClass(resources []containerResources) string {
+	if len(resources) == 0 {
+		return types.QoSClassBestEffort
+	}
+
+	guaranteed, bestEffort := true, true
+	for _, r := range resources {
+		if r.cpuRequest > 0 || r.cpuLimit > 0 || r.memoryRequest > 0 || r.memoryLimit > 0 {
+			bestEffort = false
+		}
+		if r.cpuLimit <= 0 || r.memoryLimit <= 0 || r.cpuRequest != r.cpuLimit || r.memoryRequest != r.memoryLimit {
+			guaranteed = false
+		}
+	}
+
+	switch {
+	case bestEffort:
+		return types.QoSClassBestEffort
+	case guaranteed:
+		return types.QoSClassGuaranteed
+	default:
+		return types.QoSClassBurstable
+	}
+}
+
+// projectRequestLimit calcula o custo mensal por pod e o risco do par request/limit recomendado.
+// cpuHourlyPrice é o preço por core/hora e memoryHourlyPrice o preço por GB/hora, já convertidos.
+// Retorna nil quando não há detalhe por container.
+func projectRequestLimit(containers []*types.ContainerMetrics, analysis *types.ResourceRecommendationAnalysis, cpuHourlyPrice, memoryHourlyPrice float64) *types.RequestLimitProjection {
+	if len(containers) == 0 || len(analysis.Containers) != len(containers) {
+		return nil
+	}
+
+	resources := suggestedResources(containers, analysis.Containers)
+	var cpuRequest, cpuLimit, memoryRequest, memoryLimit float64
+	cpuBounded, memoryBounded := true, true
+	for _, r := range resources {
+		cpuRequest += r.cpuRequest
+		memoryRequest += r.memoryRequest
+		cpuLimit += max(r.cpuLimit, r.cpuRequest)
+		memoryLimit += max(r.memoryLimit, r.memoryRequest)
+		cpuBounded = cpuBounded && r.cpuLimit > 0
+		memoryBounded = memoryBounded && r.memoryLimit > 0
+	}
+
+	projection := &types.RequestLimitProjection{
+		QoSClass: qosClass(resources),
+		Request:  monthlyCost(cpuRequest, memoryRequest, cpuHourlyPrice, memoryHourlyPrice),
+		Limit:    monthlyCost(cpuLimit, memoryLimit, cpuHourlyPrice, memoryHourlyPrice),
+		Risk:     types.RiskLow,
+	}
+	if cpuBounded && cpuRequest > 0 {
+		projection.Overcommit.CPU = cpuLimit / cpuRequest
+	}
+	if memoryBounded && memoryRequest > 0 {
+		projection.Overcommit.Memory = memoryLimit / memoryRequest
+	}
+
+	addRisk := func(factor, level string) {
+		projection.RiskFactors = append(projection.RiskFactors, factor)
+		if level == types.RiskHigh || projection.Risk == types.RiskLow {
+			projection.Risk = level
+		}
+	}
+	for _, container := range containers {
+		if container.OOMKilled {
+			addRisk("oom_history", types.RiskHigh)
+			break
+		}
+	}
+	for _, rec := range analysis.Containers {
+		if rec.CPULimit != nil && rec.CPULimit.Status == "throttled" && rec.CPULimit.Recommendation.Action != "remove" {
+			addRisk("cpu_throttling", types.RiskMedium)
+			break
+		}
+	}
+	if !memoryBounded {
+		addRisk("memory_unbounded", types.RiskMedium)
+	}
+	if projection.Overcommit.Memory > memoryOvercommitRisk {
+		addRisk("memory_overcommit", types.RiskMedium)
+	}
+
+	return projection
+}
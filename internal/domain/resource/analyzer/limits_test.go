@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLimitedContainer cria um container com requests, limits e uso atual de CPU e memória
+func newLimitedContainer(name string, cpuRequest, cpuLimit, memoryRequest, memoryLimit float64) *types.ContainerMetrics {
+	container := &types.ContainerMetrics{Name: name}
+	container.CPU.Request = cpuRequest
+	container.CPU.Limit = cpuLimit
+	container.CPU.Usage.Current = types.UsageStats{Average: 200, Peak: 250}
+	container.Memory.Request = memoryRequest
+	container.Memory.Limit = memoryLimit
+	container.Memory.Usage.Current = types.UsageStats{Average: 300, Peak: 350}
+	return container
+}
+
+func TestQoSClass(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []containerResources
+		expected  string
+	}{
+		{
+			name:      "Requests iguais aos limits",
+			resources: []containerResources{{cpuRequest: 500, cpuLimit: 500, memoryRequest: 512, memoryLimit: 512}},
+			expected:  types.QoSClassGuaranteed,
+		},
+		{
+			name: "Um container sem limit de memória",
+			resources: []containerResources{
+				{cpuRequest: 500, cpuLimit: 500, memoryRequest: 512, memoryLimit: 512},
+				{cpuRequest: 100, cpuLimit: 100, memoryRequest: 128},
+			},
+			expected: types.QoSClassBurstable,
+		},
+		{
+			name:      "Sem requests e limits",
+			resources: []containerResources{{}},
+			expected:  types.QoSClassBestEffort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, qosClass(tt.resources))
+		})
+	}
+}
+
+func TestCalculateRecommendations_Limits(t *testing.T) {
+	t.Run("Pod Guaranteed mantém limits iguais aos requests", func(t *testing.T) {
+		service := NewService(nil, nil, &Config{CPULimitRatio: 2, MemoryLimitRatio: 2})
+		current := &types.CurrentMetrics{
+			Pods:       &types.PodMetrics{},
+			Containers: []*types.ContainerMetrics{newLimitedContainer("app", 500, 500, 1024, 1024)},
+		}
+
+		analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+		container := analysis.Containers[0]
+		assert.Equal(t, container.CPU.Recommendation.Suggested, container.CPULimit.Recommendation.Suggested)
+		assert.Equal(t, container.Memory.Recommendation.Suggested, container.MemoryLimit.Recommendation.Suggested)
+		assert.Equal(t, &types.QoSRecommendation{Current: types.QoSClassGuaranteed, Suggested: types.QoSClassGuaranteed}, analysis.QoS)
+	})
+
+	t.Run("Razão configurada não torna Guaranteed um pod Burstable", func(t *testing.T) {
+		service := NewService(nil, nil, &Config{CPULimitRatio: 1, MemoryLimitRatio: 1})
+		current := &types.CurrentMetrics{
+			Pods:       &types.PodMetrics{},
+			Containers: []*types.ContainerMetrics{newLimitedContainer("app", 500, 1000, 512, 1024)},
+		}
+
+		analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+		// Preserva as razões atuais: CPU 300m x2, memória 512Mi x2
+		container := analysis.Containers[0]
+		assert.Equal(t, float64(600), container.CPULimit.Recommendation.Suggested)
+		assert.Equal(t, float64(1024), container.MemoryLimit.Recommendation.Suggested)
+		assert.Equal(t, &types.QoSRecommendation{Current: types.QoSClassBurstable, Suggested: types.QoSClassBurstable}, analysis.QoS)
+	})
+
+	t.Run("Limit de memória não é reduzido após OOMKill", func(t *testing.T) {
+		service := NewService(nil, nil, &Config{MemoryLimitRatio: 1})
+		container := newLimitedContainer("app", 500, 1000, 512, 2048)
+		container.OOMKilled = true
+		current := &types.CurrentMetrics{Pods: &types.PodMetrics{}, Containers: []*types.ContainerMetrics{container}}
+
+		analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+		assert.Equal(t, "oom_protected", analysis.Containers[0].MemoryLimit.Status)
+		assert.Equal(t, float64(2048), analysis.Containers[0].MemoryLimit.Recommendation.Suggested)
+	})
+}
+
+func TestProjectRequestLimit(t *testing.T) {
+	containers := []*types.ContainerMetrics{
+		newLimitedContainer("app", 1000, 2000, 1024, 2048),
+		newLimitedContainer("istio-proxy", 100, 0, 128, 0),
+	}
+	containers[0].OOMKilled = true
+	analysis := &types.ResourceRecommendationAnalysis{
+		Containers: []*types.ContainerRecommendation{
+			{
+				Name:        "app",
+				CPU:         &types.ResourceRecommendation{Recommendation: &types.ResourceSuggestion{Suggested: 500}},
+				CPULimit:    &types.ResourceRecommendation{Recommendation: &types.ResourceSuggestion{Suggested: 1000}},
+				Memory:      &types.ResourceRecommendation{Recommendation: &types.ResourceSuggestion{Suggested: 1024}},
+				MemoryLimit: &types.ResourceRecommendation{Recommendation: &types.ResourceSuggestion{Suggested: 2048}},
+			},
+			{Name: "istio-proxy"},
+		},
+	}
+
+	projection := projectRequestLimit(containers, analysis, 1, 1)
+
+	assert.Equal(t, types.QoSClassBurstable, projection.QoSClass)
+	// Request: 600m e 1152Mi; limit: 1100m e 2176Mi (o sidecar sem limit entra com o request)
+	assert.InDelta(t, 0.6*730, projection.Request.CPU, 0.001)
+	assert.InDelta(t, 1.1*730, projection.Limit.CPU, 0.001)
+	assert.InDelta(t, 2176.0/1024*730, projection.Limit.Memory, 0.001)
+	assert.Equal(t, float64(0), projection.Overcommit.CPU)
+	assert.Equal(t, types.RiskHigh, projection.Risk)
+	assert.Equal(t, []string{"oom_history", "memory_unbounded"}, projection.RiskFactors)
+}
@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// AnalyzeNamespace analisa todos os workloads de um namespace
+func (s *Service) AnalyzeNamespace(ctx context.Context, namespace string, period time.Duration) (*types.NamespaceAnalysis, error) {
+	logger.Info("Starting namespace analysis",
+		logger.NewField("namespace", namespace),
+		logger.NewField("period", period),
+		logger.NewField("max_concurrency", s.maxConcurrency),
+	)
+
+	workloads, err := s.metricsCollector.ListWorkloads(ctx, namespace)
+	if err != nil {
+		logger.Error("Failed to list workloads", err,
+			logger.NewField("namespace", namespace),
+		)
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	summaries, errs := s.analyzeWorkloads(ctx, workloads, period)
+
+	result := &types.NamespaceAnalysis{
+		Cluster:   collector.ClusterFromContext(ctx),
+		Namespace: namespace,
+		Period:    period.String(),
+		Currency:  "BRL",
+		Workloads: make([]*types.WorkloadSummary, 0, len(summaries)),
+		Errors:    errs,
+	}
+	for _, summary := range summaries {
+		if summary != nil {
+			result.Workloads = append(result.Workloads, summary)
+		}
+	}
+
+	// Maiores desperdícios primeiro
+	sort.SliceStable(result.Workloads, func(i, j int) bool {
+		return result.Workloads[i].Costs.SavingsMonthly > result.Workloads[j].Costs.SavingsMonthly
+	})
+
+	result.Summary = summarizeNamespace(result.Workloads)
+	result.Summary.Workloads = len(workloads)
+	result.Summary.Failed = len(errs)
+
+	logger.Info("Namespace analysis completed",
+		logger.NewField("namespace", namespace),
+		logger.NewField("workloads", result.Summary.Workloads),
+		logger.NewField("failed", result.Summary.Failed),
+		logger.NewField("savings_monthly", result.Summary.Costs.SavingsMonthly),
+	)
+
+	return result, nil
+}
+
+// analyzeWorkloads executa a análise de cada workload em um pool de maxConcurrency workers.
+// Cada workload tem o próprio prazo (workloadTimeout), contado ao sair da fila, para que as
+// etapas de GetMetrics dividam o tempo do workload e não o que resta da requisição.
+// Os resumos mantêm a ordem de entrada; workloads com falha ficam nil e são reportados em erros.
+func (s *Service) analyzeWorkloads(ctx context.Context, workloads []types.WorkloadRef, period time.Duration) ([]*types.WorkloadSummary, []types.WorkloadError) {
+	summaries := make([]*types.WorkloadSummary, len(workloads))
+
+	failures := runPool(ctx, workloads, s.maxConcurrency, func(i int, workload types.WorkloadRef) error {
+		workloadCtx, cancel := context.WithTimeout(ctx, s.workloadTimeout)
+		defer cancel()
+
+		response, err := s.GetMetrics(workloadCtx, workload.Namespace, workload.Kind, workload.Name, period)
+		if err != nil {
+			return err
+		}
+		summaries[i] = summarizeWorkload(workload, response)
+		return nil
+	})
+
+	var errs []types.WorkloadError
+	for i, err := range failures {
+		if err == nil {
+			continue
+		}
+		logger.Error("Failed to analyze workload", err,
+			logger.NewField("kind", workloads[i].Kind),
+			logger.NewField("name", workloads[i].Name),
+		)
+		errs = append(errs, types.WorkloadError{
+			Namespace: workloads[i].Namespace,
+			Kind:      workloads[i].Kind,
+			Name:      workloads[i].Name,
+			Error:     err.Error(),
+		})
+	}
+
+	return summaries, errs
+}
+
+// summarizeWorkload converte a análise por pod de um workload em totais considerando as réplicas em execução
+func summarizeWorkload(workload types.WorkloadRef, response *types.MetricsResponse) *types.WorkloadSummary {
+	current := response.Current
+	replicas := float64(current.Pods.Running)
+
+	summary := &types.WorkloadSummary{
+		WorkloadRef: workload,
+		Replicas:    current.Pods.Running,
+	}
+
+	summary.CPU.Requested = current.Deployment.Config.CPU.Request * replicas
+	summary.CPU.Used = current.Analysis.CPU.Usage.Current.Average * replicas
+	summary.Memory.Requested = current.Deployment.Config.Memory.Request * replicas
+	summary.Memory.Used = current.Analysis.Memory.Usage.Current.Average * replicas
+
+	if response.Analysis != nil {
+		summary.CPU.Recommended = suggestedOrCurrent(response.Analysis.CPU, current.Deployment.Config.CPU.Request) * replicas
+		summary.Memory.Recommended = suggestedOrCurrent(response.Analysis.Memory, current.Deployment.Config.Memory.Request) * replicas
+	}
+
+	if response.Costs != nil {
+		summary.Costs.CurrentMonthly = response.Costs.Current.Monthly.Total * replicas
+		summary.Costs.RecommendedMonthly = response.Costs.Recommended.Monthly.Total * replicas
+		summary.Costs.SavingsMonthly = response.Costs.Savings.Total * replicas
+	}
+
+	return summary
+}
+
+// summarizeNamespace soma os totais dos workloads analisados
+func summarizeNamespace(workloads []*types.WorkloadSummary) types.NamespaceSummary {
+	summary := types.NamespaceSummary{Analyzed: len(workloads)}
+	for _, w := range workloads {
+		addTotals(&summary.CPU, w.CPU)
+		addTotals(&summary.Memory, w.Memory)
+		addCosts(&summary.Costs, w.Costs)
+	}
+	return summary
+}
+
+// addTotals acumula totais de recurso
+func addTotals(total *types.ResourceTotals, value types.ResourceTotals) {
+	total.Requested += value.Requested
+	total.Used += value.Used
+	total.Recommended += value.Recommended
+}
+
+// addCosts acumula custos mensais
+func addCosts(total *types.CostSummary, value types.CostSummary) {
+	total.CurrentMonthly += value.CurrentMonthly
+	total.RecommendedMonthly += value.RecommendedMonthly
+	total.SavingsMonthly += value.SavingsMonthly
+}
@@ -0,0 +1,214 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCollector implementa a interface collector.Collector para testes
+type mockCollector struct {
+	workloads  []types.WorkloadRef
+	namespaces []types.NamespaceRef
+	inventory  *types.NodeInventory
+	quotas     *types.NamespaceQuotas
+	configs    map[string]*types.K8sDeploymentConfig
+	metrics    map[string]*types.K8sMetrics
+	history    []types.QueryResult
+	series     map[string][]types.Series
+	instant    map[string]float64
+	queryErr   error
+	delay      time.Duration
+
+	inFlight    int32
+	maxInFlight int32
+
+	// remaining registra o prazo restante de cada workload ao coletar as suas métricas
+	mu        sync.Mutex
+	remaining map[string]time.Duration
+}
+
+func (m *mockCollector) GetDeploymentMetrics(ctx context.Context, namespace, deployment string) (*types.K8sMetrics, error) {
+	return m.GetWorkloadMetrics(ctx, namespace, types.WorkloadKindDeployment, deployment)
+}
+
+func (m *mockCollector) GetDeploymentConfig(ctx context.Context, namespace, deployment string) (*types.K8sDeploymentConfig, error) {
+	return m.GetWorkloadConfig(ctx, namespace, types.WorkloadKindDeployment, deployment)
+}
+
+func (m *mockCollector) GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error) {
+	current := atomic.AddInt32(&m.inFlight, 1)
+	defer atomic.AddInt32(&m.inFlight, -1)
+	for {
+		peak := atomic.LoadInt32(&m.maxInFlight)
+		if current <= peak || atomic.CompareAndSwapInt32(&m.maxInFlight, peak, current) {
+			break
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		m.mu.Lock()
+		if m.remaining == nil {
+			m.remaining = make(map[string]time.Duration)
+		}
+		m.remaining[name] = time.Until(deadline)
+		m.mu.Unlock()
+	}
+	time.Sleep(m.delay)
+
+	metrics, ok := m.metrics[name]
+	if !ok {
+		return nil, fmt.Errorf("metrics not found for %s", name)
+	}
+	return metrics, nil
+}
+
+func (m *mockCollector) GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error) {
+	return m.configs[name], nil
+}
+
+func (m *mockCollector) ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error) {
+	var workloads []types.WorkloadRef
+	for _, w := range m.workloads {
+		if w.Namespace == namespace {
+			workloads = append(workloads, w)
+		}
+	}
+	return workloads, nil
+}
+
+func (m *mockCollector) ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error) {
+	return m.namespaces, nil
+}
+
+func (m *mockCollector) GetNodeInventory(ctx context.Context) (*types.NodeInventory, error) {
+	return m.inventory, nil
+}
+
+func (m *mockCollector) GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error) {
+	return m.quotas, nil
+}
+
+func (m *mockCollector) Query(ctx context.Context, query string) (*types.QueryResult, error) {
+	if m.queryErr != nil {
+		return nil, m.queryErr
+	}
+	return &types.QueryResult{Value: m.instant[query]}, nil
+}
+
+func (m *mockCollector) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.QueryRangeResult, error) {
+	return &types.QueryRangeResult{Values: m.history}, nil
+}
+
+func (m *mockCollector) QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error) {
+	return &types.SeriesResult{ResultType: types.ResultTypeVector, Series: m.series[query]}, nil
+}
+
+func (m *mockCollector) QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error) {
+	return &types.SeriesResult{ResultType: types.ResultTypeMatrix, Series: m.series[query]}, nil
+}
+
+// newWorkloadFixture cria configuração e métricas de um workload com um único container
+func newWorkloadFixture(cpuRequest, cpuAvg, memoryRequest, memoryAvg float64, running int) (*types.K8sDeploymentConfig, *types.K8sMetrics) {
+	config := &types.K8sDeploymentConfig{
+		Containers: []types.ContainerConfig{newContainerConfig("app", cpuRequest, memoryRequest)},
+	}
+	config.CPU.Request = cpuRequest
+	config.Memory.Request = memoryRequest
+	config.Pods.Replicas = running
+	config.Pods.MinReplicas = running
+	config.Pods.MaxReplicas = running
+
+	metrics := &types.K8sMetrics{
+		Containers: []types.ContainerUsage{newContainerUsage("app", cpuAvg, cpuAvg, memoryAvg, memoryAvg)},
+	}
+	metrics.CPU.Average = cpuAvg
+	metrics.CPU.Peak = cpuAvg
+	metrics.Memory.Average = memoryAvg
+	metrics.Memory.Peak = memoryAvg
+	metrics.Pods.Running = running
+	return config, metrics
+}
+
+func TestAnalyzeNamespace(t *testing.T) {
+	collector := &mockCollector{
+		workloads: []types.WorkloadRef{
+			{Kind: types.WorkloadKindDeployment, Namespace: "default", Name: "small"},
+			{Kind: types.WorkloadKindStatefulSet, Namespace: "default", Name: "big"},
+			{Kind: types.WorkloadKindDaemonSet, Namespace: "default", Name: "broken"},
+		},
+		configs: map[string]*types.K8sDeploymentConfig{},
+		metrics: map[string]*types.K8sMetrics{},
+		delay:   10 * time.Millisecond,
+	}
+	collector.configs["small"], collector.metrics["small"] = newWorkloadFixture(500, 100, 512, 100, 1)
+	collector.configs["big"], collector.metrics["big"] = newWorkloadFixture(2000, 100, 4096, 100, 3)
+	collector.configs["broken"] = collector.configs["small"]
+
+	service := NewService(collector, pricing.NewClient(&pricing.Config{}), &Config{MaxConcurrency: 2})
+
+	analysis, err := service.AnalyzeNamespace(context.Background(), "default", 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "default", analysis.Namespace)
+	assert.Equal(t, 3, analysis.Summary.Workloads)
+	assert.Equal(t, 2, analysis.Summary.Analyzed)
+	assert.Equal(t, 1, analysis.Summary.Failed)
+	assert.Len(t, analysis.Errors, 1)
+	assert.Equal(t, "broken", analysis.Errors[0].Name)
+
+	// Ranking pela economia mensal
+	assert.Len(t, analysis.Workloads, 2)
+	assert.Equal(t, "big", analysis.Workloads[0].Name)
+	assert.Equal(t, "small", analysis.Workloads[1].Name)
+	assert.Greater(t, analysis.Workloads[0].Costs.SavingsMonthly, analysis.Workloads[1].Costs.SavingsMonthly)
+
+	// Totais consideram as réplicas em execução
+	assert.Equal(t, 3, analysis.Workloads[0].Replicas)
+	assert.Equal(t, float64(6000), analysis.Workloads[0].CPU.Requested)
+	assert.Equal(t, float64(300), analysis.Workloads[0].CPU.Used)
+	assert.Equal(t, float64(6500), analysis.Summary.CPU.Requested)
+	assert.InDelta(t,
+		analysis.Workloads[0].Costs.SavingsMonthly+analysis.Workloads[1].Costs.SavingsMonthly,
+		analysis.Summary.Costs.SavingsMonthly, 0.0001)
+
+	// Concorrência limitada
+	assert.LessOrEqual(t, atomic.LoadInt32(&collector.maxInFlight), int32(2))
+}
+
+func TestAnalyzeWorkloads_WorkloadTimeout(t *testing.T) {
+	collector := &mockCollector{
+		configs: map[string]*types.K8sDeploymentConfig{},
+		metrics: map[string]*types.K8sMetrics{},
+		delay:   50 * time.Millisecond,
+	}
+	var workloads []types.WorkloadRef
+	for _, name := range []string{"a", "b", "c"} {
+		workloads = append(workloads, types.WorkloadRef{Kind: types.WorkloadKindDeployment, Namespace: "default", Name: name})
+		collector.configs[name], collector.metrics[name] = newWorkloadFixture(500, 100, 512, 100, 1)
+	}
+	service := NewService(collector, pricing.NewClient(&pricing.Config{}), &Config{MaxConcurrency: 1, WorkloadTimeout: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_, errs := service.analyzeWorkloads(ctx, workloads, 24*time.Hour)
+
+	assert.Empty(t, errs)
+	// Cada workload tem o próprio prazo ao sair da fila, e não o que resta da requisição
+	for _, name := range []string{"a", "b", "c"} {
+		assert.LessOrEqual(t, collector.remaining[name], time.Second, name)
+		assert.InDelta(t, float64(collector.remaining["a"]), float64(collector.remaining[name]), float64(30*time.Millisecond), name)
+	}
+}
+
+func TestNewService_DefaultConcurrency(t *testing.T) {
+	assert.Equal(t, DefaultMaxConcurrency, NewService(nil, nil, nil).maxConcurrency)
+	assert.Equal(t, DefaultMaxConcurrency, NewService(nil, nil, &Config{MaxConcurrency: 0}).maxConcurrency)
+	assert.Equal(t, 8, NewService(nil, nil, &Config{MaxConcurrency: 8}).maxConcurrency)
+}
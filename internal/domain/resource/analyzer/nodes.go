@@ -0,0 +1,202 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// AnalyzeNodes analisa a capacidade dos nodes e simula o bin-packing com os requests recomendados
+func (s *Service) AnalyzeNodes(ctx context.Context, period time.Duration) (*types.NodeAnalysis, error) {
+	logger.Info("Starting node analysis",
+		logger.NewField("period", period),
+	)
+
+	inventory, err := s.metricsCollector.GetNodeInventory(ctx)
+	if err != nil {
+		logger.Error("Failed to get node inventory", err)
+		return nil, fmt.Errorf("failed to get node inventory: %w", err)
+	}
+
+	// Uso médio no período a partir do node-exporter
+	s.collectNodeAverageUsage(ctx, inventory.Nodes, period)
+
+	result := &types.NodeAnalysis{
+		Cluster:  collector.ClusterFromContext(ctx),
+		Period:   period.String(),
+		Currency: "BRL",
+		Nodes:    inventory.Nodes,
+		Pools:    aggregateNodePools(inventory.Nodes),
+	}
+	result.Summary.Name = "cluster"
+	for _, node := range inventory.Nodes {
+		addNodeCapacity(&result.Summary, node)
+	}
+
+	if len(inventory.Nodes) == 0 {
+		return result, nil
+	}
+
+	// Aplica os requests recomendados aos pods de cada workload
+	pods, errs := s.recommendedPodRequests(ctx, inventory.Pods, period)
+	result.Errors = errs
+
+	simulation, err := s.simulateNodes(ctx, inventory.Nodes, pods)
+	if err != nil {
+		logger.Error("Failed to simulate bin-packing", err)
+		return nil, fmt.Errorf("failed to simulate bin-packing: %w", err)
+	}
+	result.Simulation = simulation
+
+	logger.Info("Node analysis completed",
+		logger.NewField("nodes", result.Summary.Nodes),
+		logger.NewField("required_nodes", simulation.RequiredNodes),
+		logger.NewField("monthly_savings", simulation.MonthlySavings),
+	)
+
+	return result, nil
+}
+
+// collectNodeAverageUsage obtém do Mimir o uso médio de cada node no período.
+// Falhas são registradas e o node segue apenas com o uso da metrics API.
+func (s *Service) collectNodeAverageUsage(ctx context.Context, nodes []types.NodeCapacity, period time.Duration) {
+	for i := range nodes {
+		node := &nodes[i]
+
+		cpuResult, err := s.metricsCollector.Query(ctx, buildNodeCPUAverageQuery(node.Name, period))
+		if err != nil {
+			logger.Error("Failed to get node CPU average usage", err,
+				logger.NewField("node", node.Name),
+			)
+		} else if !cpuResult.Missing {
+			node.CPU.AverageUsed = cpuResult.Value
+		}
+
+		memoryResult, err := s.metricsCollector.Query(ctx, buildNodeMemoryAverageQuery(node.Name, period))
+		if err != nil {
+			logger.Error("Failed to get node memory average usage", err,
+				logger.NewField("node", node.Name),
+			)
+		} else if !memoryResult.Missing {
+			node.Memory.AverageUsed = memoryResult.Value
+		}
+	}
+}
+
+// recommendedPodRequests analisa os workloads donos dos pods e substitui os requests de cada pod
+// pelos requests recomendados. Pods sem workload ou cuja análise falhou mantêm os requests atuais.
+func (s *Service) recommendedPodRequests(ctx context.Context, pods []types.NodePod, period time.Duration) ([]types.NodePod, []types.WorkloadError) {
+	seen := make(map[string]bool)
+	var workloads []types.WorkloadRef
+	for _, pod := range pods {
+		if pod.Owner == nil || seen[workloadKey(*pod.Owner)] {
+			continue
+		}
+		seen[workloadKey(*pod.Owner)] = true
+		workloads = append(workloads, *pod.Owner)
+	}
+
+	summaries, errs := s.analyzeWorkloads(ctx, workloads, period)
+
+	type podRequest struct{ cpu, memory float64 }
+	recommended := make(map[string]podRequest, len(summaries))
+	for i, summary := range summaries {
+		if summary == nil || summary.Replicas == 0 {
+			continue
+		}
+		replicas := float64(summary.Replicas)
+		recommended[workloadKey(workloads[i])] = podRequest{
+			cpu:    summary.CPU.Recommended / replicas,
+			memory: summary.Memory.Recommended / replicas,
+		}
+	}
+
+	result := make([]types.NodePod, len(pods))
+	for i, pod := range pods {
+		result[i] = pod
+		if pod.Owner == nil {
+			continue
+		}
+		if request, ok := recommended[workloadKey(*pod.Owner)]; ok {
+			result[i].CPURequest = request.cpu
+			result[i].MemoryRequest = request.memory
+		}
+	}
+	return result, errs
+}
+
+// workloadKey identifica um workload de forma única no cluster
+func workloadKey(ref types.WorkloadRef) string {
+	return ref.Namespace + "/" + string(ref.Kind) + "/" + ref.Name
+}
+
+// simulateNodes executa o bin-packing e calcula a economia mensal dos nodes removíveis
+func (s *Service) simulateNodes(ctx context.Context, nodes []types.NodeCapacity, pods []types.NodePod) (*types.BinPackingSimulation, error) {
+	prices, err := s.pricingClient.GetCurrentPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", err)
+	}
+	exchange, err := s.pricingClient.GetExchangeRate(ctx, "USD", "BRL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	simulation := &types.BinPackingSimulation{
+		Pools: simulateBinPacking(nodes, pods),
+	}
+	for _, pool := range simulation.Pools {
+		pool.MonthlySavings = monthlyCost(pool.ReleasedCPU, pool.ReleasedMemory,
+			prices.CPU.PerCore*exchange.Rate, prices.Memory.PerGB*exchange.Rate).Total
+
+		simulation.CurrentNodes += pool.CurrentNodes
+		simulation.RequiredNodes += pool.RequiredNodes
+		simulation.RemovableNodes += pool.RemovableNodes
+		simulation.MonthlySavings += pool.MonthlySavings
+	}
+	return simulation, nil
+}
+
+// aggregateNodePools agrega os nodes por node pool, ordenando os pools pelo nome
+func aggregateNodePools(nodes []types.NodeCapacity) []*types.NodePoolCapacity {
+	byName := make(map[string]*types.NodePoolCapacity)
+	pools := make([]*types.NodePoolCapacity, 0)
+	for _, node := range nodes {
+		pool, ok := byName[node.Pool]
+		if !ok {
+			pool = &types.NodePoolCapacity{Name: node.Pool}
+			byName[node.Pool] = pool
+			pools = append(pools, pool)
+		}
+		addNodeCapacity(pool, node)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Name < pools[j].Name
+	})
+	return pools
+}
+
+// addNodeCapacity acumula a capacidade de um node em um agregado
+func addNodeCapacity(total *types.NodePoolCapacity, node types.NodeCapacity) {
+	total.Nodes++
+	total.Pods += node.Pods
+	addNodeResource(&total.CPU, node.CPU)
+	addNodeResource(&total.Memory, node.Memory)
+}
+
+// addNodeResource soma os valores de um recurso.
+// Capacidade encalhada é calculada por node, portanto o total é a soma dos nodes.
+func addNodeResource(total *types.NodeResource, value types.NodeResource) {
+	total.Allocatable += value.Allocatable
+	total.Requested += value.Requested
+	total.Used += value.Used
+	total.AverageUsed += value.AverageUsed
+	total.Unallocated += value.Unallocated
+	total.Idle += value.Idle
+	total.Stranded += value.Stranded
+}
@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeNodes(t *testing.T) {
+	owner := &types.WorkloadRef{Kind: types.WorkloadKindDeployment, Namespace: "default", Name: "api"}
+	nodes := []types.NodeCapacity{
+		{Name: "node-1", Pool: "general"},
+		{Name: "node-2", Pool: "general"},
+	}
+	for i := range nodes {
+		nodes[i].CPU.Allocatable = 4000
+		nodes[i].Memory.Allocatable = 8192
+		nodes[i].CPU.Requested = 3000
+		nodes[i].Memory.Requested = 6144
+	}
+
+	collector := &mockCollector{
+		inventory: &types.NodeInventory{
+			Nodes: nodes,
+			Pods: []types.NodePod{
+				{Namespace: "default", Name: "api-1", Node: "node-1", Owner: owner, CPURequest: 3000, MemoryRequest: 6144},
+				{Namespace: "default", Name: "api-2", Node: "node-2", Owner: owner, CPURequest: 3000, MemoryRequest: 6144},
+			},
+		},
+		configs: map[string]*types.K8sDeploymentConfig{},
+		metrics: map[string]*types.K8sMetrics{},
+	}
+	// Uso médio de 500m e 1024Mi por pod: recomendação de 700m e 1536Mi
+	collector.configs["api"], collector.metrics["api"] = newWorkloadFixture(3000, 500, 6144, 1024, 2)
+
+	service := NewService(collector, pricing.NewClient(&pricing.Config{}), nil)
+
+	analysis, err := service.AnalyzeNodes(context.Background(), 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, analysis.Summary.Nodes)
+	assert.Equal(t, float64(8000), analysis.Summary.CPU.Allocatable)
+	assert.Len(t, analysis.Pools, 1)
+	assert.Empty(t, analysis.Errors)
+
+	// Com os requests recomendados os dois pods cabem em um único node
+	assert.Equal(t, 2, analysis.Simulation.CurrentNodes)
+	assert.Equal(t, 1, analysis.Simulation.RequiredNodes)
+	assert.Equal(t, 1, analysis.Simulation.RemovableNodes)
+	assert.Greater(t, analysis.Simulation.MonthlySavings, 0.0)
+}
+
+func TestBuildNodeAverageQueries(t *testing.T) {
+	assert.Equal(t,
+		`avg_over_time(sum(rate(node_cpu_seconds_total{mode!="idle"}[5m]) * on (instance) group_left node_uname_info{nodename="ip-10-0-0-1.ec2.internal"})[86400s:5m]) * 1000`,
+		buildNodeCPUAverageQuery("ip-10-0-0-1.ec2.internal", 24*time.Hour))
+	assert.Contains(t,
+		buildNodeMemoryAverageQuery("node-1", time.Hour),
+		`sum(node_memory_MemAvailable_bytes * on (instance) group_left node_uname_info{nodename="node-1"}))[3600s:5m]`)
+}
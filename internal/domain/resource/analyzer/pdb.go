@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+)
+
+// constrainReplicas valida uma sugestão de réplicas contra os PDBs do workload. Sugestões abaixo
+// do mínimo que permite uma interrupção voluntária são elevadas a esse mínimo; PDBs que não
+// permitem interrupções com nenhum número de réplicas são reportados sem alterar a sugestão.
+// Sugestões de zero réplicas (escala para zero) não têm pods a proteger e não são validadas.
+func constrainReplicas(pdbs []types.PDBConfig, recommendation string, suggested int) (int, []types.PDBConflict) {
+	if suggested <= 0 {
+		return suggested, nil
+	}
+
+	adjusted := suggested
+	var conflicts []types.PDBConflict
+	for _, pdb := range pdbs {
+		switch {
+		case pdb.BlocksDisruptions:
+			conflicts = append(conflicts, types.PDBConflict{
+				PDB:            pdb.Name,
+				Recommendation: recommendation,
+				Suggested:      suggested,
+				Message: fmt.Sprintf("PDB %s (%s) não permite interrupções voluntárias com nenhum número de réplicas; o drain de nodes fica bloqueado",
+					pdb.Name, pdbRule(pdb)),
+			})
+		case suggested < pdb.RequiredReplicas:
+			adjusted = max(adjusted, pdb.RequiredReplicas)
+			conflicts = append(conflicts, types.PDBConflict{
+				PDB:            pdb.Name,
+				Recommendation: recommendation,
+				Suggested:      suggested,
+				Required:       pdb.RequiredReplicas,
+				Message: fmt.Sprintf("%s sugerido (%d) bloquearia o drain de nodes pelo PDB %s (%s), que exige ao menos %d réplicas",
+					recommendation, suggested, pdb.Name, pdbRule(pdb), pdb.RequiredReplicas),
+			})
+		}
+	}
+
+	for i := range conflicts {
+		conflicts[i].Adjusted = adjusted
+	}
+	return adjusted, conflicts
+}
+
+// pdbRule descreve a regra de disponibilidade de um PDB
+func pdbRule(pdb types.PDBConfig) string {
+	if pdb.MinAvailable != "" {
+		return "minAvailable " + pdb.MinAvailable
+	}
+	if pdb.MaxUnavailable != "" {
+		return "maxUnavailable " + pdb.MaxUnavailable
+	}
+	return "sem restrição"
+}
+
+// constrainRecommendation valida uma recomendação de réplicas da análise de pods, marcando
+// a razão como pdb_constrained quando a sugestão é elevada
+func constrainRecommendation(pdbs []types.PDBConfig, name string, recommendation *types.Recommendation) []types.PDBConflict {
+	if recommendation == nil {
+		return nil
+	}
+	adjusted, conflicts := constrainReplicas(pdbs, name, int(recommendation.Suggested))
+	if adjusted > int(recommendation.Suggested) {
+		recommendation.Suggested = float64(adjusted)
+		recommendation.Reason = "pdb_constrained"
+	}
+	return conflicts
+}
+
+// constrainPodRecommendation valida as réplicas sugeridas e a configuração de HPA recomendada
+// contra os PDBs. Quando o minReplicas do HPA é elevado, o replay do histórico é refeito com a
+// configuração ajustada.
+func constrainPodRecommendation(current *types.CurrentMetrics, historical *types.HistoricalMetrics, analysis *types.ResourceRecommendationAnalysis) []types.PDBConflict {
+	pdbs := current.Deployment.Config.PDBs
+	if len(pdbs) == 0 {
+		return nil
+	}
+
+	var conflicts []types.PDBConflict
+	if analysis.HPA != nil && analysis.HPA.Suggested != nil {
+		suggested := analysis.HPA.Suggested
+		minReplicas, hpaConflicts := constrainReplicas(pdbs, "hpa.minReplicas", suggested.Settings.MinReplicas)
+		conflicts = append(conflicts, hpaConflicts...)
+		if minReplicas != suggested.Settings.MinReplicas {
+			settings := suggested.Settings
+			settings.MinReplicas = minReplicas
+			settings.MaxReplicas = max(settings.MaxReplicas, minReplicas)
+
+			var behavior *types.HPABehavior
+			if hpa := current.Deployment.Config.HPA.Spec; hpa != nil {
+				behavior = hpa.Behavior
+			}
+			if replay := newHPAController(settings, behavior).replay(historical.CPUUsage, replayStep(historical), false); replay != nil {
+				analysis.HPA.Suggested = replay
+			}
+			if pods := analysis.Pods.Recommendation; pods != nil {
+				pods.Min = settings.MinReplicas
+				pods.Max = settings.MaxReplicas
+				pods.Suggested = max(pods.Suggested, settings.MinReplicas)
+			}
+		}
+	}
+
+	if pods := analysis.Pods.Recommendation; pods != nil {
+		adjusted, podConflicts := constrainReplicas(pdbs, "replicas", pods.Suggested)
+		pods.Suggested = adjusted
+		conflicts = append(conflicts, podConflicts...)
+	}
+	return conflicts
+}
+
+// pdbAlerts gera alertas para as recomendações de réplicas da análise de pods que conflitam
+// com os PDBs do workload
+func pdbAlerts(current *types.CurrentMetrics, historical *types.HistoricalMetrics) []types.Alert {
+	pdbs := current.Deployment.Config.PDBs
+	if len(pdbs) == 0 || current.Pods == nil {
+		return nil
+	}
+
+	var historicalPods []*types.PodMetrics
+	if historical != nil {
+		historicalPods = historical.Pods
+	}
+	conflicts := append(
+		constrainRecommendation(pdbs, "replicas", generatePodsRecommendation(current.Pods, historicalPods)),
+		constrainRecommendation(pdbs, "minReplicas", generateMinReplicasRecommendation(current.Pods, historicalPods))...,
+	)
+
+	alerts := make([]types.Alert, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		alerts = append(alerts, types.Alert{
+			Type:        "pdb_conflict",
+			Severity:    "warning",
+			Message:     conflict.Message,
+			Resource:    "pods",
+			CurrentVal:  float64(conflict.Suggested),
+			Threshold:   float64(conflict.Required),
+			Occurrences: 1,
+		})
+	}
+	return alerts
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstrainReplicas(t *testing.T) {
+	pdbs := []types.PDBConfig{
+		{Name: "api", MinAvailable: "2", RequiredReplicas: 3},
+		{Name: "api-half", MinAvailable: "50%", RequiredReplicas: 2},
+	}
+
+	adjusted, conflicts := constrainReplicas(pdbs, "minReplicas", 1)
+	assert.Equal(t, 3, adjusted)
+	if assert.Len(t, conflicts, 2) {
+		assert.Equal(t, "api", conflicts[0].PDB)
+		assert.Equal(t, 3, conflicts[0].Required)
+		assert.Equal(t, 3, conflicts[1].Adjusted, "o ajuste considera todos os PDBs")
+	}
+
+	adjusted, conflicts = constrainReplicas(pdbs, "minReplicas", 3)
+	assert.Equal(t, 3, adjusted)
+	assert.Empty(t, conflicts)
+
+	adjusted, conflicts = constrainReplicas(pdbs, "replicas", 0)
+	assert.Equal(t, 0, adjusted, "escala para zero não é validada")
+	assert.Empty(t, conflicts)
+
+	blocking := []types.PDBConfig{{Name: "strict", MaxUnavailable: "0", BlocksDisruptions: true}}
+	adjusted, conflicts = constrainReplicas(blocking, "replicas", 4)
+	assert.Equal(t, 4, adjusted)
+	if assert.Len(t, conflicts, 1) {
+		assert.Zero(t, conflicts[0].Required)
+		assert.Contains(t, conflicts[0].Message, "maxUnavailable 0")
+	}
+}
+
+func TestPDBAlerts(t *testing.T) {
+	current := &types.CurrentMetrics{
+		Pods: &types.PodMetrics{Running: 2, Replicas: 2, MinReplicas: 2},
+	}
+	current.Deployment.Config.PDBs = []types.PDBConfig{{Name: "api", MinAvailable: "1", RequiredReplicas: 2}}
+	historical := &types.HistoricalMetrics{
+		Pods: []*types.PodMetrics{{Running: 1, Utilization: 10}, {Running: 2, Utilization: 10}},
+	}
+
+	alerts := pdbAlerts(current, historical)
+
+	// minReplicas sugerido (1) impede o drain; as réplicas sugeridas (2) atendem o PDB
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, "pdb_conflict", alerts[0].Type)
+		assert.Equal(t, "warning", alerts[0].Severity)
+		assert.Equal(t, float64(1), alerts[0].CurrentVal)
+		assert.Equal(t, float64(2), alerts[0].Threshold)
+	}
+
+	current.Deployment.Config.PDBs = nil
+	assert.Empty(t, pdbAlerts(current, historical))
+}
@@ -0,0 +1,43 @@
+package analyzer
+
+import (
+	"context"
+	"sync"
+)
+
+// poolJob é um item da fila do pool com a sua posição na entrada
+type poolJob[T any] struct {
+	index int
+	item  T
+}
+
+// runPool executa work para cada item com um número fixo de workers, que consomem os itens de
+// um canal; o número de goroutines não cresce com o número de itens. Itens retirados da fila
+// depois do fim de ctx falham com o erro do contexto. Os erros mantêm a ordem de entrada.
+func runPool[T any](ctx context.Context, items []T, workers int, work func(i int, item T) error) []error {
+	failures := make([]error, len(items))
+	jobs := make(chan poolJob[T])
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(workers, 1), len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if err := ctx.Err(); err != nil {
+					failures[job.index] = err
+					continue
+				}
+				failures[job.index] = work(job.index, job.item)
+			}
+		}()
+	}
+
+	for i, item := range items {
+		jobs <- poolJob[T]{index: i, item: item}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return failures
+}
@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPool(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	baseline := runtime.NumGoroutine()
+
+	var peak int32
+	failures := runPool(context.Background(), items, 4, func(i int, item int) error {
+		if goroutines := int32(runtime.NumGoroutine()); goroutines > atomic.LoadInt32(&peak) {
+			atomic.StoreInt32(&peak, goroutines)
+		}
+		if item%2 == 1 {
+			return fmt.Errorf("item %d", item)
+		}
+		return nil
+	})
+
+	// O número de goroutines é limitado pelos workers, e não pelo número de itens
+	assert.LessOrEqual(t, int(atomic.LoadInt32(&peak)), baseline+4)
+	assert.Len(t, failures, 1000)
+	assert.NoError(t, failures[0])
+	assert.EqualError(t, failures[999], "item 999")
+}
+
+func TestRunPool_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int32
+	failures := runPool(ctx, []string{"a", "b", "c"}, 2, func(int, string) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	// Itens retirados da fila após o fim do contexto falham sem executar
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	for _, err := range failures {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
@@ -0,0 +1,279 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/resource/collector"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+const (
+	// LimitRangeStatusOK indica que o request padrão atende os containers que dependem dele
+	LimitRangeStatusOK = "ok"
+	// LimitRangeStatusOverprovisioned indica que o request padrão superdimensiona a maioria dos containers
+	LimitRangeStatusOverprovisioned = "overprovisioned"
+	// LimitRangeStatusUnderprovisioned indica que o request padrão subdimensiona a maioria dos containers
+	LimitRangeStatusUnderprovisioned = "underprovisioned"
+)
+
+// quotaPeakQueries mapeia os recursos de quota com recomendação para a query do valor máximo
+// contabilizado pela quota no namespace: a soma dos requests ou o número de pods. Quotas de
+// limits dependem das razões limit/request e não são recomendadas.
+var quotaPeakQueries = map[string]func(namespace string, period time.Duration) string{
+	"cpu":             buildNamespaceCPURequestsPeakQuery,
+	"requests.cpu":    buildNamespaceCPURequestsPeakQuery,
+	"memory":          buildNamespaceMemoryRequestsPeakQuery,
+	"requests.memory": buildNamespaceMemoryRequestsPeakQuery,
+	"pods":            buildNamespacePodsPeakQuery,
+}
+
+// AnalyzeQuotas analisa os ResourceQuotas e LimitRanges de um namespace
+func (s *Service) AnalyzeQuotas(ctx context.Context, namespace string, period time.Duration) (*types.QuotaAnalysis, error) {
+	logger.Info("Starting quota analysis",
+		logger.NewField("namespace", namespace),
+		logger.NewField("period", period),
+	)
+
+	quotas, err := s.metricsCollector.GetNamespaceQuotas(ctx, namespace)
+	if err != nil {
+		logger.Error("Failed to get namespace quotas", err,
+			logger.NewField("namespace", namespace),
+		)
+		return nil, fmt.Errorf("failed to get namespace quotas: %w", err)
+	}
+
+	result := &types.QuotaAnalysis{
+		Cluster:     collector.ClusterFromContext(ctx),
+		Namespace:   namespace,
+		Period:      period.String(),
+		Quotas:      []types.QuotaUsage{},
+		LimitRanges: []types.LimitRangeDefaultAnalysis{},
+	}
+
+	if result.Quotas, err = s.analyzeQuotaUsage(ctx, namespace, quotas.ResourceQuotas, period); err != nil {
+		return nil, err
+	}
+
+	if len(quotas.LimitRanges) > 0 && len(quotas.DefaultedContainers) > 0 {
+		usages, errs := s.collectDefaultedUsage(ctx, quotas.DefaultedContainers, period)
+		result.Errors = errs
+		for _, limitRange := range quotas.LimitRanges {
+			if analysis, ok := analyzeLimitRangeDefault(limitRange.Name, "cpu", limitRange.CPU.DefaultRequest, quotas.DefaultedContainers, usages); ok {
+				result.LimitRanges = append(result.LimitRanges, analysis)
+			}
+			if analysis, ok := analyzeLimitRangeDefault(limitRange.Name, "memory", limitRange.Memory.DefaultRequest, quotas.DefaultedContainers, usages); ok {
+				result.LimitRanges = append(result.LimitRanges, analysis)
+			}
+		}
+	}
+
+	logger.Info("Quota analysis completed",
+		logger.NewField("namespace", namespace),
+		logger.NewField("quotas", len(result.Quotas)),
+		logger.NewField("limit_ranges", len(result.LimitRanges)),
+		logger.NewField("failed", len(result.Errors)),
+	)
+
+	return result, nil
+}
+
+// analyzeQuotaUsage calcula a folga de cada recurso de quota e recomenda o tamanho dos recursos
+// com histórico: o valor máximo contabilizado no período acrescido da folga configurada, nunca
+// abaixo do uso atual da quota, para não bloquear a criação de pods no namespace
+func (s *Service) analyzeQuotaUsage(ctx context.Context, namespace string, quotas []types.ResourceQuotaConfig, period time.Duration) ([]types.QuotaUsage, error) {
+	peaks := make(map[string]float64)
+	usages := []types.QuotaUsage{}
+
+	for _, quota := range quotas {
+		for _, resource := range quota.Resources {
+			usage := types.QuotaUsage{
+				Quota:    quota.Name,
+				Resource: resource.Resource,
+				Hard:     resource.Hard,
+				Used:     resource.Used,
+				Headroom: resource.Hard - resource.Used,
+			}
+			if resource.Hard > 0 {
+				usage.HeadroomPercent = usage.Headroom / resource.Hard * 100
+			}
+
+			if query, ok := quotaPeakQueries[resource.Resource]; ok {
+				expr := query(namespace, period)
+				peak, cached := peaks[expr]
+				if !cached {
+					result, err := s.metricsCollector.Query(ctx, expr)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get peak usage for quota resource %s: %w", resource.Resource, err)
+					}
+					if !result.Missing {
+						peak = result.Value
+					}
+					peaks[expr] = peak
+				}
+				if peak > 0 {
+					usage.PeakUsage = peak
+					usage.Recommended = max(s.recommendQuota(resource.Resource, peak), resource.Used)
+					usage.Action = determineAction(resource.Hard, usage.Recommended)
+				}
+			}
+
+			usages = append(usages, usage)
+		}
+	}
+	return usages, nil
+}
+
+// recommendQuota aplica a folga ao valor máximo e arredonda para 100m (CPU), 128Mi (memória)
+// ou para o próximo pod
+func (s *Service) recommendQuota(resource string, peak float64) float64 {
+	recommended := peak * (1 + s.quotaHeadroom/100)
+	switch resource {
+	case "cpu", "requests.cpu":
+		return math.Ceil(recommended/100) * 100
+	case "memory", "requests.memory":
+		return math.Ceil(recommended/128) * 128
+	default:
+		return math.Ceil(recommended)
+	}
+}
+
+// defaultedUsage é o uso histórico por pod de um container sem request explícito
+type defaultedUsage struct {
+	cpu    types.UsageStats
+	memory types.UsageStats
+}
+
+// collectDefaultedUsage obtém o uso histórico dos containers sem request explícito em um pool de
+// maxConcurrency workers. Containers com falha ficam sem uso e são reportados.
+func (s *Service) collectDefaultedUsage(ctx context.Context, containers []types.DefaultedContainer, period time.Duration) ([]*defaultedUsage, []types.WorkloadError) {
+	usages := make([]*defaultedUsage, len(containers))
+
+	end := time.Now()
+	start := end.Add(-period)
+
+	failures := runPool(ctx, containers, s.maxConcurrency, func(i int, container types.DefaultedContainer) error {
+		ref := container.Workload
+		usage := &defaultedUsage{}
+		if container.CPU {
+			result, err := s.metricsCollector.QueryRange(ctx, buildContainerCPUHistoricalQuery(ref.Namespace, ref.Kind, ref.Name, container.Container), start, end, historicalStep)
+			if err != nil {
+				return fmt.Errorf("failed to get historical CPU metrics for container %s: %w", container.Container, err)
+			}
+			usage.cpu = summarizeHistorical(result.Values)
+		}
+		if container.Memory {
+			result, err := s.metricsCollector.QueryRange(ctx, buildContainerMemoryHistoricalQuery(ref.Namespace, ref.Kind, ref.Name, container.Container), start, end, historicalStep)
+			if err != nil {
+				return fmt.Errorf("failed to get historical memory metrics for container %s: %w", container.Container, err)
+			}
+			usage.memory = summarizeHistorical(result.Values)
+		}
+		usages[i] = usage
+		return nil
+	})
+
+	var errs []types.WorkloadError
+	for i, err := range failures {
+		if err == nil {
+			continue
+		}
+		ref := containers[i].Workload
+		logger.Error("Failed to collect defaulted container usage", err,
+			logger.NewField("kind", ref.Kind),
+			logger.NewField("name", ref.Name),
+			logger.NewField("container", containers[i].Container),
+		)
+		errs = append(errs, types.WorkloadError{
+			Namespace: ref.Namespace,
+			Kind:      ref.Kind,
+			Name:      ref.Name,
+			Error:     err.Error(),
+		})
+	}
+
+	return usages, errs
+}
+
+// analyzeLimitRangeDefault compara o request padrão de um recurso com a recomendação de cada
+// container que depende dele. O padrão é sinalizado quando a maioria dos containers com uso
+// histórico ficaria super ou subdimensionada. Retorna false quando o LimitRange não define
+// padrão para o recurso ou nenhum container depende dele.
+func analyzeLimitRangeDefault(limitRange, resource string, defaultRequest float64, containers []types.DefaultedContainer, usages []*defaultedUsage) (types.LimitRangeDefaultAnalysis, bool) {
+	if defaultRequest <= 0 {
+		return types.LimitRangeDefaultAnalysis{}, false
+	}
+
+	analysis := types.LimitRangeDefaultAnalysis{
+		LimitRange:     limitRange,
+		Resource:       resource,
+		DefaultRequest: defaultRequest,
+		Status:         LimitRangeStatusOK,
+		Details:        []types.DefaultedContainerUsage{},
+	}
+
+	var suggestions []float64
+	for i, container := range containers {
+		if usages[i] == nil {
+			continue
+		}
+
+		var recommendation *types.ResourceRecommendation
+		var usage types.UsageStats
+		switch {
+		case resource == "cpu" && container.CPU:
+			usage = usages[i].cpu
+			recommendation = recommendCPU(usage, defaultRequest)
+		case resource == "memory" && container.Memory:
+			usage = usages[i].memory
+			recommendation = recommendMemory(usage, defaultRequest)
+		default:
+			continue
+		}
+		if recommendation == nil || recommendation.Recommendation == nil {
+			continue
+		}
+
+		suggestion := recommendation.Recommendation
+		analysis.Details = append(analysis.Details, types.DefaultedContainerUsage{
+			Workload:  container.Workload,
+			Container: container.Container,
+			Usage:     usage,
+			Suggested: suggestion.Suggested,
+			Action:    suggestion.Action,
+		})
+		suggestions = append(suggestions, suggestion.Suggested)
+
+		switch suggestion.Action {
+		case "decrease":
+			analysis.Overprovisioned++
+		case "increase":
+			analysis.Underprovisioned++
+		}
+	}
+
+	analysis.Containers = len(analysis.Details)
+	if analysis.Containers == 0 {
+		return types.LimitRangeDefaultAnalysis{}, false
+	}
+
+	sort.Float64s(suggestions)
+	analysis.SuggestedDefault = suggestions[len(suggestions)/2]
+
+	switch {
+	case analysis.Overprovisioned*2 > analysis.Containers:
+		analysis.Status = LimitRangeStatusOverprovisioned
+		analysis.Message = fmt.Sprintf("Request padrão de %s (%.0f) superdimensiona %d de %d containers sem request explícito; sugerido %.0f",
+			resource, defaultRequest, analysis.Overprovisioned, analysis.Containers, analysis.SuggestedDefault)
+	case analysis.Underprovisioned*2 > analysis.Containers:
+		analysis.Status = LimitRangeStatusUnderprovisioned
+		analysis.Message = fmt.Sprintf("Request padrão de %s (%.0f) subdimensiona %d de %d containers sem request explícito; sugerido %.0f",
+			resource, defaultRequest, analysis.Underprovisioned, analysis.Containers, analysis.SuggestedDefault)
+	}
+
+	return analysis, true
+}
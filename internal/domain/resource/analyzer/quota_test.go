@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeQuotas(t *testing.T) {
+	period := 24 * time.Hour
+	api := types.WorkloadRef{Kind: types.WorkloadKindDeployment, Namespace: "team-a", Name: "api"}
+	worker := types.WorkloadRef{Kind: types.WorkloadKindDeployment, Namespace: "team-a", Name: "worker"}
+
+	collector := &mockCollector{
+		quotas: &types.NamespaceQuotas{
+			Namespace: "team-a",
+			ResourceQuotas: []types.ResourceQuotaConfig{{
+				Name: "budget",
+				Resources: []types.QuotaResource{
+					{Resource: "limits.cpu", Hard: 8000, Used: 3000},
+					{Resource: "pods", Hard: 20, Used: 7},
+					{Resource: "requests.cpu", Hard: 4000, Used: 2500},
+					{Resource: "requests.memory", Hard: 4096, Used: 2048},
+				},
+			}},
+			LimitRanges: []types.LimitRangeConfig{{
+				Name:   "defaults",
+				CPU:    types.LimitRangeValues{DefaultRequest: 500},
+				Memory: types.LimitRangeValues{DefaultRequest: 128},
+			}},
+			DefaultedContainers: []types.DefaultedContainer{
+				{Workload: api, Container: "sidecar", CPU: true, Memory: true},
+				{Workload: worker, Container: "app", CPU: true, Memory: true},
+			},
+		},
+		instant: map[string]float64{
+			buildNamespaceCPURequestsPeakQuery("team-a", period):    3000,
+			buildNamespaceMemoryRequestsPeakQuery("team-a", period): 1024,
+			buildNamespacePodsPeakQuery("team-a", period):           9,
+		},
+		// Uso por pod de 100m de CPU e 100Mi de memória em todos os containers
+		history: []types.QueryResult{{Value: 100}, {Value: 100}},
+	}
+	service := NewService(collector, nil, nil)
+
+	analysis, err := service.AnalyzeQuotas(context.Background(), "team-a", period)
+
+	assert.NoError(t, err)
+	if assert.Len(t, analysis.Quotas, 4) {
+		// Quotas de limits não são recomendadas
+		assert.Equal(t, float64(5000), analysis.Quotas[0].Headroom)
+		assert.Zero(t, analysis.Quotas[0].Recommended)
+
+		pods := analysis.Quotas[1]
+		assert.Equal(t, float64(9), pods.PeakUsage)
+		assert.Equal(t, float64(11), pods.Recommended)
+		assert.Equal(t, "decrease", pods.Action)
+
+		cpu := analysis.Quotas[2]
+		assert.Equal(t, float64(1500), cpu.Headroom)
+		assert.Equal(t, 37.5, cpu.HeadroomPercent)
+		assert.Equal(t, float64(3000), cpu.PeakUsage)
+		assert.Equal(t, float64(3600), cpu.Recommended)
+		assert.Equal(t, "maintain", cpu.Action)
+
+		// O pico dos requests no período está abaixo dos requests atuais: a quota nunca fica
+		// abaixo do uso atual
+		memory := analysis.Quotas[3]
+		assert.Equal(t, float64(1024), memory.PeakUsage)
+		assert.Equal(t, float64(2048), memory.Recommended)
+		assert.Equal(t, "decrease", memory.Action)
+	}
+
+	if assert.Len(t, analysis.LimitRanges, 2) {
+		cpu := analysis.LimitRanges[0]
+		assert.Equal(t, "cpu", cpu.Resource)
+		assert.Equal(t, 2, cpu.Containers)
+		assert.Equal(t, 2, cpu.Overprovisioned)
+		assert.Equal(t, LimitRangeStatusOverprovisioned, cpu.Status)
+		assert.Equal(t, float64(200), cpu.SuggestedDefault)
+
+		memory := analysis.LimitRanges[1]
+		assert.Equal(t, "memory", memory.Resource)
+		assert.Equal(t, 2, memory.Underprovisioned)
+		assert.Equal(t, LimitRangeStatusUnderprovisioned, memory.Status)
+		assert.Equal(t, float64(256), memory.SuggestedDefault)
+	}
+}
+
+func TestAnalyzeLimitRangeDefault(t *testing.T) {
+	containers := []types.DefaultedContainer{
+		{Container: "a", CPU: true},
+		{Container: "b", CPU: true},
+		{Container: "c", Memory: true},
+	}
+	usages := []*defaultedUsage{
+		{cpu: types.UsageStats{Average: 400, Peak: 400}},
+		{cpu: types.UsageStats{Average: 100, Peak: 100}},
+		{memory: types.UsageStats{Average: 100, Peak: 100}},
+	}
+
+	analysis, ok := analyzeLimitRangeDefault("defaults", "cpu", 500, containers, usages)
+	assert.True(t, ok)
+	assert.Equal(t, 2, analysis.Containers)
+	assert.Equal(t, 1, analysis.Overprovisioned)
+	assert.Equal(t, LimitRangeStatusOK, analysis.Status, "metade dos containers não é a maioria")
+
+	_, ok = analyzeLimitRangeDefault("defaults", "memory", 0, containers, usages)
+	assert.False(t, ok, "LimitRange sem padrão de memória")
+}
@@ -13,79 +13,142 @@ import (
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/pricing"
 )
 
-// Service implementa a interface ResourceAnalyzer
+const (
+	// DefaultMaxConcurrency é o número padrão de workloads analisados em paralelo
+	DefaultMaxConcurrency = 4
+	// DefaultGroupLabel é o label padrão usado para agrupar workloads no relatório do cluster
+	DefaultGroupLabel = "team"
+	// DefaultTopOffenders é o número padrão de workloads listados como maiores desperdícios
+	DefaultTopOffenders = 10
+	// DefaultHPAUtilizationCeiling é o teto padrão de utilização de CPU (percentual do request)
+	// aceito no replay do HPA
+	DefaultHPAUtilizationCeiling = 90.0
+	// DefaultThrottlingThreshold é o percentual padrão de períodos do CFS com throttling
+	// a partir do qual um container é considerado limitado pelo limit de CPU
+	DefaultThrottlingThreshold = 25.0
+	// DefaultQuotaHeadroom é a folga padrão (percentual) sobre o uso máximo do namespace
+	// nas quotas recomendadas
+	DefaultQuotaHeadroom = 20.0
+	// DefaultWorkloadTimeout é o prazo padrão da análise de cada workload nas análises agregadas
+	DefaultWorkloadTimeout = 60 * time.Second
+
+	// CPULimitPolicyKeep mantém os limits de CPU, ampliando os que causam throttling
+	CPULimitPolicyKeep = "keep"
+	// CPULimitPolicyRemove recomenda remover os limits de CPU que causam throttling
+	CPULimitPolicyRemove = "remove"
+
+	// historicalStep é o intervalo entre as amostras das séries históricas
+	historicalStep = 5 * time.Minute
+)
+
+// Config contém as configurações do Service
+type Config struct {
+	// MaxConcurrency limita quantos workloads são analisados em paralelo
+	// nas análises de namespace e cluster
+	MaxConcurrency int
+	// GroupLabel é o label (do workload ou do namespace) usado no showback do cluster
+	GroupLabel string
+	// TopOffenders é o número de workloads listados como maiores desperdícios
+	TopOffenders int
+	// HPAUtilizationCeiling é a utilização de CPU máxima (percentual do request) que a
+	// configuração de HPA recomendada pode atingir no replay do histórico
+	HPAUtilizationCeiling float64
+	// ThrottlingThreshold é o percentual de períodos do CFS com throttling que gera alerta
+	// e recomendação de ajuste do limit de CPU
+	ThrottlingThreshold float64
+	// CPULimitPolicy define a recomendação para limits de CPU com throttling
+	// (CPULimitPolicyKeep ou CPULimitPolicyRemove)
+	CPULimitPolicy string
+	// CPULimitRatio e MemoryLimitRatio são as razões limit/request usadas nos limits recomendados.
+	// Valores abaixo de 1 preservam a razão atual de cada container.
+	CPULimitRatio    float64
+	MemoryLimitRatio float64
+	// QuotaHeadroom é a folga (percentual) sobre o uso máximo do namespace nas quotas recomendadas
+	QuotaHeadroom float64
+	// WorkloadTimeout é o prazo da análise de cada workload nas análises de namespace, cluster e
+	// nodes, contado a partir do início da sua análise e limitado pelo prazo da requisição
+	WorkloadTimeout time.Duration
+}
+
+// Service implementa a interface Analyzer
 type Service struct {
-	metricsCollector collector.Collector
-	pricingClient    *pricing.Client
+	metricsCollector      collector.Collector
+	pricingClient         *pricing.Client
+	maxConcurrency        int
+	groupLabel            string
+	topOffenders          int
+	hpaUtilizationCeiling float64
+	throttlingThreshold   float64
+	cpuLimitPolicy        string
+	cpuLimitRatio         float64
+	memoryLimitRatio      float64
+	quotaHeadroom         float64
+	workloadTimeout       time.Duration
 }
 
-// NewService cria uma nova instância do Service
-func NewService(metricsCollector collector.Collector, pricingClient *pricing.Client) *Service {
-	return &Service{
-		metricsCollector: metricsCollector,
-		pricingClient:    pricingClient,
+// NewService cria uma nova instância do Service.
+// Se cfg for nil ou tiver valores inválidos, os valores padrão são usados.
+func NewService(metricsCollector collector.Collector, pricingClient *pricing.Client, cfg *Config) *Service {
+	service := &Service{
+		metricsCollector:      metricsCollector,
+		pricingClient:         pricingClient,
+		maxConcurrency:        DefaultMaxConcurrency,
+		groupLabel:            DefaultGroupLabel,
+		topOffenders:          DefaultTopOffenders,
+		hpaUtilizationCeiling: DefaultHPAUtilizationCeiling,
+		throttlingThreshold:   DefaultThrottlingThreshold,
+		cpuLimitPolicy:        CPULimitPolicyKeep,
+		quotaHeadroom:         DefaultQuotaHeadroom,
+		workloadTimeout:       DefaultWorkloadTimeout,
+	}
+	if cfg == nil {
+		return service
+	}
+
+	if cfg.MaxConcurrency > 0 {
+		service.maxConcurrency = cfg.MaxConcurrency
+	}
+	if cfg.GroupLabel != "" {
+		service.groupLabel = cfg.GroupLabel
+	}
+	if cfg.TopOffenders > 0 {
+		service.topOffenders = cfg.TopOffenders
+	}
+	if cfg.HPAUtilizationCeiling > 0 {
+		service.hpaUtilizationCeiling = cfg.HPAUtilizationCeiling
 	}
+	if cfg.ThrottlingThreshold > 0 {
+		service.throttlingThreshold = cfg.ThrottlingThreshold
+	}
+	if cfg.CPULimitPolicy == CPULimitPolicyRemove {
+		service.cpuLimitPolicy = cfg.CPULimitPolicy
+	}
+	if cfg.CPULimitRatio >= 1 {
+		service.cpuLimitRatio = cfg.CPULimitRatio
+	}
+	if cfg.MemoryLimitRatio >= 1 {
+		service.memoryLimitRatio = cfg.MemoryLimitRatio
+	}
+	if cfg.QuotaHeadroom > 0 {
+		service.quotaHeadroom = cfg.QuotaHeadroom
+	}
+	if cfg.WorkloadTimeout > 0 {
+		service.workloadTimeout = cfg.WorkloadTimeout
+	}
+	return service
 }
 
-// GetMetrics retorna métricas atuais e históricas de um deployment
-func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string, period time.Duration) (*types.MetricsResponse, error) {
+// GetMetrics retorna métricas atuais e históricas de um workload
+func (s *Service) GetMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration) (*types.MetricsResponse, error) {
 	logger.Info("Starting metrics collection",
 		logger.NewField("namespace", namespace),
-		logger.NewField("deployment", deployment),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 		logger.NewField("period", period),
 	)
 
 	response := &types.MetricsResponse{
 		Current: &types.CurrentMetrics{
-			Deployment: struct {
-				Config struct {
-					CPU struct {
-						Request float64 `json:"request"`
-						Limit   float64 `json:"limit"`
-					} `json:"cpu"`
-					Memory struct {
-						Request float64 `json:"request"`
-						Limit   float64 `json:"limit"`
-					} `json:"memory"`
-					HPA struct {
-						MinReplicas int     `json:"minReplicas"`
-						MaxReplicas int     `json:"maxReplicas"`
-						TargetCPU   float64 `json:"targetCPU"`
-					} `json:"hpa"`
-				} `json:"config"`
-			}{},
-			Analysis: struct {
-				CPU struct {
-					Distribution map[string]float64 `json:"distribution"`
-					Alerts       struct {
-						HighCPU    int `json:"highCPU"`
-						NearLimit  int `json:"nearLimit"`
-						HighMemory int `json:"highMemory"`
-					} `json:"alerts"`
-					Usage struct {
-						Current struct {
-							Average float64 `json:"average"`
-							Peak    float64 `json:"peak"`
-						} `json:"current"`
-						Historical struct {
-							Average float64 `json:"average"`
-							Peak    float64 `json:"peak"`
-						} `json:"historical"`
-					} `json:"usage"`
-				} `json:"cpu"`
-				Memory struct {
-					Usage struct {
-						Current struct {
-							Average float64 `json:"average"`
-							Peak    float64 `json:"peak"`
-						} `json:"current"`
-						Historical struct {
-							Average float64 `json:"average"`
-							Peak    float64 `json:"peak"`
-						} `json:"historical"`
-					} `json:"usage"`
-				} `json:"memory"`
-			}{},
 			CPU:    &types.ResourceMetrics{},
 			Memory: &types.ResourceMetrics{},
 			Pods:   &types.PodMetrics{},
@@ -95,19 +158,6 @@ func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string,
 			Memory: []*types.ResourceMetrics{},
 			Pods:   []*types.PodMetrics{},
 		},
-		Metadata: struct {
-			Analysis struct {
-				Timestamp  string   `json:"timestamp"`
-				Period     string   `json:"period"`
-				Cluster    string   `json:"cluster"`
-				Sources    []string `json:"sources"`
-				Confidence struct {
-					CPU    float64 `json:"cpu"`
-					Memory float64 `json:"memory"`
-					Pods   float64 `json:"pods"`
-				} `json:"confidence"`
-			} `json:"analysis"`
-		}{},
 	}
 
 	// Inicializa o mapa de distribuição
@@ -115,28 +165,33 @@ func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string,
 
 	// Obtém métricas atuais
 	logger.Info("Collecting current metrics")
-	k8sMetrics, err := s.metricsCollector.GetDeploymentMetrics(ctx, namespace, deployment)
+	currentCtx, cancel := withBudget(ctx, currentMetricsBudget)
+	defer cancel()
+	k8sMetrics, err := s.metricsCollector.GetWorkloadMetrics(currentCtx, namespace, kind, name)
 	if err != nil {
-		logger.Error("Failed to get deployment metrics", err,
+		logger.Error("Failed to get workload metrics", err,
 			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", deployment),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
 		)
-		return nil, fmt.Errorf("failed to get deployment metrics: %w", err)
+		return nil, fmt.Errorf("failed to get workload metrics: %w", budgetError(currentCtx, "workload_metrics", err))
 	}
 
-	// Obtém configuração do deployment
-	logger.Info("Collecting deployment configuration")
-	config, err := s.metricsCollector.GetDeploymentConfig(ctx, namespace, deployment)
+	// Obtém configuração do workload
+	logger.Info("Collecting workload configuration")
+	config, err := s.metricsCollector.GetWorkloadConfig(currentCtx, namespace, kind, name)
 	if err != nil {
-		logger.Error("Failed to get deployment configuration", err,
+		logger.Error("Failed to get workload configuration", err,
 			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", deployment),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
 		)
-		return nil, fmt.Errorf("failed to get deployment configuration: %w", err)
+		return nil, fmt.Errorf("failed to get workload configuration: %w", budgetError(currentCtx, "workload_config", err))
 	}
 
 	// Configura a resposta com os dados atuais
 	// Os valores já estão nas unidades corretas
+	response.Current.Deployment.Kind = kind
 	response.Current.Deployment.Config.CPU.Request = config.CPU.Request
 	response.Current.Deployment.Config.CPU.Limit = config.CPU.Limit
 	response.Current.Deployment.Config.Memory.Request = config.Memory.Request
@@ -144,6 +199,17 @@ func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string,
 	response.Current.Deployment.Config.HPA.MinReplicas = config.Pods.MinReplicas
 	response.Current.Deployment.Config.HPA.MaxReplicas = config.Pods.MaxReplicas
 	response.Current.Deployment.Config.HPA.TargetCPU = config.Pods.TargetCPU * 100 // Converte para percentual
+	response.Current.Deployment.Config.HPA.Spec = config.HPA
+	response.Current.Deployment.Config.VPA = config.VPA
+	response.Current.Deployment.Config.KEDA = config.KEDA
+	response.Current.Deployment.Config.PDBs = config.PDBs
+
+	// Configura réplicas
+	response.Current.Pods.Running = k8sMetrics.Pods.Running
+	response.Current.Pods.Replicas = config.Pods.Replicas
+	response.Current.Pods.MinReplicas = config.Pods.MinReplicas
+	response.Current.Pods.MaxReplicas = config.Pods.MaxReplicas
+	response.Current.Pods.Utilization = k8sMetrics.Pods.Utilization
 
 	// Configura análise de CPU (valores já em milicores)
 	response.Current.Analysis.CPU.Usage.Current.Average = k8sMetrics.CPU.Average
@@ -163,7 +229,7 @@ func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string,
 	// Obtém métricas históricas
 	end := time.Now()
 	start := end.Add(-period)
-	step := 5 * time.Minute
+	step := historicalStep
 
 	logger.Info("Collecting historical metrics",
 		logger.NewField("start", start),
@@ -172,66 +238,82 @@ func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string,
 	)
 
 	// Query para CPU
-	cpuQuery := buildCPUHistoricalQuery(namespace, deployment)
+	historicalCtx, cancel := withBudget(ctx, historicalBudget)
+	defer cancel()
+	cpuQuery := buildCPUHistoricalQuery(namespace, kind, name)
 	logger.Info("Executing CPU historical query",
 		logger.NewField("query", cpuQuery),
 	)
-	cpuResult, err := s.metricsCollector.QueryRange(ctx, cpuQuery, start, end, step)
+	cpuResult, err := s.metricsCollector.QueryRange(historicalCtx, cpuQuery, start, end, step)
 	if err != nil {
 		logger.Error("Failed to get historical CPU metrics", err,
 			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", deployment),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
 		)
-		return nil, fmt.Errorf("failed to get historical CPU metrics: %w", err)
+		return nil, fmt.Errorf("failed to get historical CPU metrics: %w", budgetError(historicalCtx, "historical_metrics", err))
 	}
 
 	// Query para memória
-	memoryQuery := buildMemoryHistoricalQuery(namespace, deployment)
+	memoryQuery := buildMemoryHistoricalQuery(namespace, kind, name)
 	logger.Info("Executing memory historical query",
 		logger.NewField("query", memoryQuery),
 	)
-	memoryResult, err := s.metricsCollector.QueryRange(ctx, memoryQuery, start, end, step)
+	memoryResult, err := s.metricsCollector.QueryRange(historicalCtx, memoryQuery, start, end, step)
 	if err != nil {
 		logger.Error("Failed to get historical memory metrics", err,
 			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", deployment),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
 		)
-		return nil, fmt.Errorf("failed to get historical memory metrics: %w", err)
+		return nil, fmt.Errorf("failed to get historical memory metrics: %w", budgetError(historicalCtx, "historical_metrics", err))
 	}
 
 	// Configura métricas históricas
-	if len(cpuResult.Values) > 0 {
-		var sum, peak float64
-		for _, v := range cpuResult.Values {
-			// Converte de cores para milicores
-			cpuValue := v.Value * 1000 // Já está em cores, precisamos converter para milicores
-			sum += cpuValue
-			if cpuValue > peak {
-				peak = cpuValue
-			}
-		}
-		response.Current.Analysis.CPU.Usage.Historical.Average = sum / float64(len(cpuResult.Values))
-		response.Current.Analysis.CPU.Usage.Historical.Peak = peak
-	}
-
-	if len(memoryResult.Values) > 0 {
-		var sum, peak float64
-		for _, v := range memoryResult.Values {
-			// Converte de bytes para Mi
-			memValue := v.Value / (1024 * 1024) // Converte bytes para Mi
-			sum += memValue
-			if memValue > peak {
-				peak = memValue
-			}
-		}
-		response.Current.Analysis.Memory.Usage.Historical.Average = sum / float64(len(memoryResult.Values))
-		response.Current.Analysis.Memory.Usage.Historical.Peak = peak
+	// As queries já retornam milicores e Mi, não há conversão adicional
+	cpuHistorical := summarizeHistorical(cpuResult.Values)
+	response.Current.Analysis.CPU.Usage.Historical.Average = cpuHistorical.Average
+	response.Current.Analysis.CPU.Usage.Historical.Peak = cpuHistorical.Peak
+
+	memoryHistorical := summarizeHistorical(memoryResult.Values)
+	response.Current.Analysis.Memory.Usage.Historical.Average = memoryHistorical.Average
+	response.Current.Analysis.Memory.Usage.Historical.Peak = memoryHistorical.Peak
+
+	// Série de uso total de CPU, usada no replay do HPA
+	response.Historical.CPUUsage = usageSamples(cpuResult.Values)
+	response.Historical.Step = int64(step.Seconds())
+
+	// Métricas por container (config, uso atual e histórico por pod)
+	response.Current.Containers = buildContainerMetrics(config, k8sMetrics)
+	containersCtx, cancel := withBudget(ctx, containerHistoryBudget)
+	defer cancel()
+	if err := s.collectContainerHistory(containersCtx, namespace, kind, name, response.Current.Containers, start, end, step); err != nil {
+		logger.Error("Failed to get historical container metrics", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		return nil, fmt.Errorf("failed to get historical container metrics: %w", budgetError(containersCtx, "container_metrics", err))
 	}
 
+	// Histórico de reinícios, OOMKills e evictions
+	response.Current.Stability = &k8sMetrics.Stability
+	applyContainerStability(response.Current.Containers, &k8sMetrics.Stability)
+	oomCtx, cancel := withBudget(ctx, oomKillsBudget)
+	defer cancel()
+	s.detectOOMKills(oomCtx, namespace, kind, name, response.Current.Containers, start, end)
+
+	// Throttling de CPU por container
+	throttlingCtx, cancel := withBudget(ctx, throttlingBudget)
+	defer cancel()
+	s.collectCPUThrottling(throttlingCtx, namespace, kind, name, response.Current.Containers, period)
+
 	// Configura metadados
 	response.Metadata.Analysis.Timestamp = time.Now().Format(time.RFC3339)
 	response.Metadata.Analysis.Period = period.String()
 	response.Metadata.Analysis.Cluster = config.ClusterName
+	response.Metadata.Analysis.Kind = string(kind)
+	response.Metadata.Analysis.Workload = name
 	response.Metadata.Analysis.Sources = []string{"kubernetes", "prometheus"}
 	response.Metadata.Analysis.Confidence.CPU = 95.0
 	response.Metadata.Analysis.Confidence.Memory = 95.0
@@ -255,29 +337,19 @@ func (s *Service) GetMetrics(ctx context.Context, namespace, deployment string,
 
 	logger.Info("Analysis completed successfully",
 		logger.NewField("namespace", namespace),
-		logger.NewField("deployment", deployment),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 	)
 
 	return response, nil
 }
 
-// GetTrends retorna as tendências de uso de recursos
-func (s *Service) GetTrends(ctx context.Context, namespace, deployment string, period time.Duration) (*types.TrendsResponse, error) {
-	logger.Info("Starting trend analysis",
-		logger.NewField("namespace", namespace),
-		logger.NewField("deployment", deployment),
+// CalculateTrends retorna as tendências de uso de recursos a partir das métricas já coletadas por
+// GetMetrics, sem consultar o Mimir novamente e, portanto, sem consumir o prazo da requisição
+func (s *Service) CalculateTrends(metricsResponse *types.MetricsResponse, period time.Duration) *types.TrendsResponse {
+	logger.Info("Calculating trends",
 		logger.NewField("period", period),
 	)
-
-	// Obtém métricas
-	metricsResponse, err := s.GetMetrics(ctx, namespace, deployment, period)
-	if err != nil {
-		logger.Error("Failed to get metrics for trend analysis", err)
-		return nil, errors.NewInvalidMetricsError("trends", "failed to get metrics for trend analysis")
-	}
-
-	// Calcula tendências
-	logger.Info("Calculating trends")
 	response := &types.TrendsResponse{
 		CPU: &types.TrendMetrics{
 			Trend:      calculateUtilizationTrend(metricsResponse.Historical.CPU),
@@ -302,7 +374,7 @@ func (s *Service) GetTrends(ctx context.Context, namespace, deployment string, p
 		logger.NewField("pods_trend", response.Pods.Trend),
 	)
 
-	return response, nil
+	return response
 }
 
 // AnalyzeResources realiza análise detalhada dos recursos
@@ -365,6 +437,13 @@ func (s *Service) AnalyzeResources(current *types.CurrentMetrics, historical *ty
 		},
 	}
 
+	// Valida as réplicas sugeridas contra os PDBs do workload
+	pdbs := current.Deployment.Config.PDBs
+	podsAnalysis.PDBConflicts = append(
+		constrainRecommendation(pdbs, "replicas", podsAnalysis.Recommendations.Current),
+		constrainRecommendation(pdbs, "minReplicas", podsAnalysis.Recommendations.MinReplicas)...,
+	)
+
 	// Determina status geral
 	status := determineOverallStatus(cpuAnalysis, memoryAnalysis, podsAnalysis)
 
@@ -416,8 +495,9 @@ func (s *Service) CalculateCosts(ctx context.Context, current *types.CurrentMetr
 	}
 
 	// Converte recomendações para unidades corretas
-	recommendedCPUCores := analysis.CPU.Recommendation.Suggested / 1000
-	recommendedMemoryGB := analysis.Memory.Recommendation.Suggested / 1024
+	// Sem dados suficientes, o valor recomendado é o atual
+	recommendedCPUCores := suggestedOrCurrent(analysis.CPU, current.Deployment.Config.CPU.Request) / 1000
+	recommendedMemoryGB := suggestedOrCurrent(analysis.Memory, current.Deployment.Config.Memory.Request) / 1024
 
 	// Calcula custos recomendados em BRL
 	recommendedHourly := &types.ResourceCosts{
@@ -445,6 +525,10 @@ func (s *Service) CalculateCosts(ctx context.Context, current *types.CurrentMetr
 	}
 	savings.Total = savings.CPU + savings.Memory
 
+	// Calcula custos mensais por container e o custo e risco do par request/limit recomendado
+	containerCosts := calculateContainerCosts(current.Containers, analysis.Containers, prices.CPU.PerCore*exchange.Rate, prices.Memory.PerGB*exchange.Rate)
+	projection := projectRequestLimit(current.Containers, analysis, prices.CPU.PerCore*exchange.Rate, prices.Memory.PerGB*exchange.Rate)
+
 	return &types.CostAnalysis{
 		Current: &types.CostData{
 			Hourly:  hourly,
@@ -456,8 +540,10 @@ func (s *Service) CalculateCosts(ctx context.Context, current *types.CurrentMetr
 			Daily:   recommendedDaily,
 			Monthly: recommendedMonthly,
 		},
-		Savings:  savings,
-		Currency: "BRL",
+		Savings:    savings,
+		Containers: containerCosts,
+		Projection: projection,
+		Currency:   "BRL",
 		Exchange: &types.ExchangeInfo{
 			Rate:         exchange.Rate,
 			FromCurrency: exchange.FromCurrency,
@@ -469,7 +555,7 @@ func (s *Service) CalculateCosts(ctx context.Context, current *types.CurrentMetr
 
 // GenerateAlerts gera alertas baseados nas métricas
 func (s *Service) GenerateAlerts(current *types.CurrentMetrics, historical *types.HistoricalMetrics) []types.Alert {
-	return nil
+	return append(s.throttlingAlerts(current), pdbAlerts(current, historical)...)
 }
 
 // CalculateRecommendations calcula recomendações de recursos baseadas nas métricas
@@ -486,54 +572,35 @@ func (s *Service) CalculateRecommendations(current *types.CurrentMetrics, histor
 		},
 	}
 
-	// Calcula recomendações de CPU
-	cpuPeak := current.Analysis.CPU.Usage.Current.Peak
-	cpuAvg := current.Analysis.CPU.Usage.Current.Average
-	cpuRequest := current.Deployment.Config.CPU.Request
-
-	if cpuPeak > 0 && cpuAvg > 0 {
-		// Calcula a recomendação baseada no uso médio + 30% de buffer
-		suggestedCPU := cpuAvg * 1.3
-
-		// Ajusta para o pico se necessário
-		if suggestedCPU < cpuPeak {
-			suggestedCPU = cpuPeak * 1.1 // 10% de buffer para picos
-		}
-
-		// Arredonda para o próximo múltiplo de 100m
-		suggestedCPU = math.Ceil(suggestedCPU/100) * 100
-
-		analysis.CPU.Status = "optimized"
-		analysis.CPU.Recommendation = &types.ResourceSuggestion{
-			Current:   cpuRequest,
-			Suggested: suggestedCPU,
-			Action:    determineAction(cpuRequest, suggestedCPU),
-		}
+	// Calcula recomendações de CPU e memória do pod
+	if cpuRec := recommendCPU(current.Analysis.CPU.Usage.Current, current.Deployment.Config.CPU.Request); cpuRec != nil {
+		analysis.CPU = cpuRec
+	}
+	if memRec := recommendMemory(current.Analysis.Memory.Usage.Current, current.Deployment.Config.Memory.Request); memRec != nil {
+		analysis.Memory = memRec
 	}
 
-	// Calcula recomendações de memória
-	memPeak := current.Analysis.Memory.Usage.Current.Peak
-	memAvg := current.Analysis.Memory.Usage.Current.Average
-	memRequest := current.Deployment.Config.Memory.Request
-
-	if memPeak > 0 && memAvg > 0 {
-		// Calcula a recomendação baseada no uso médio + 40% de buffer
-		suggestedMem := memAvg * 1.4
+	// Sem métricas por container, um OOMKill em qualquer container impede reduzir a memória do pod
+	if len(current.Containers) == 0 && current.Stability != nil && current.Stability.OOMKills > 0 {
+		analysis.Memory = protectMemory(analysis.Memory, current.Deployment.Config.Memory.Request)
+	}
 
-		// Ajusta para o pico se necessário
-		if suggestedMem < memPeak {
-			suggestedMem = memPeak * 1.2 // 20% de buffer para picos
+	// Calcula recomendações por container; o total do pod passa a ser a soma dos containers
+	if len(current.Containers) > 0 {
+		analysis.Containers = recommendContainers(current.Containers)
+		cpuRec := rollupRecommendation(current.Containers, analysis.Containers,
+			func(r *types.ContainerRecommendation) *types.ResourceRecommendation { return r.CPU },
+			func(c *types.ContainerMetrics) float64 { return c.CPU.Request })
+		if cpuRec != nil {
+			analysis.CPU = cpuRec
 		}
-
-		// Arredonda para o próximo múltiplo de 128Mi
-		suggestedMem = math.Ceil(suggestedMem/128) * 128
-
-		analysis.Memory.Status = "optimized"
-		analysis.Memory.Recommendation = &types.ResourceSuggestion{
-			Current:   memRequest,
-			Suggested: suggestedMem,
-			Action:    determineAction(memRequest, suggestedMem),
+		memRec := rollupRecommendation(current.Containers, analysis.Containers,
+			func(r *types.ContainerRecommendation) *types.ResourceRecommendation { return r.Memory },
+			func(c *types.ContainerMetrics) float64 { return c.Memory.Request })
+		if memRec != nil {
+			analysis.Memory = memRec
 		}
+		analysis.QoS = s.recommendLimits(current.Containers, analysis.Containers)
 	}
 
 	// Calcula recomendações de pods
@@ -547,9 +614,134 @@ func (s *Service) CalculateRecommendations(current *types.CurrentMetrics, histor
 		}
 	}
 
+	// Compara com a recomendação do VPA, quando existir
+	analysis.VPA = compareVPA(current.Deployment.Config.VPA, current, analysis)
+
+	switch {
+	case current.Deployment.Config.KEDA != nil:
+		analysis.Pods.ScalingSource = "keda"
+	case current.Deployment.Config.HPA.Spec != nil:
+		analysis.Pods.ScalingSource = "hpa"
+	}
+
+	// Recomenda o HPA pelo replay do histórico com o request de CPU recomendado.
+	// DaemonSets não escalam horizontalmente e workloads escalados por eventos do KEDA
+	// seguem a fila, não o uso de CPU. As réplicas recomendadas só seguem o HPA sugerido quando
+	// o workload já escala por utilização de CPU; nos demais casos, o HPA é apenas uma proposta.
+	if keda := current.Deployment.Config.KEDA; keda.EventDriven() {
+		analysis.HPA = &types.HPARecommendation{Status: "event_driven"}
+		recommendEventDrivenPods(analysis.Pods.Recommendation, keda)
+	} else if current.Deployment.Kind != types.WorkloadKindDaemonSet {
+		cpuRequest := suggestedOrCurrent(analysis.CPU, current.Deployment.Config.CPU.Request)
+		analysis.HPA = s.recommendHPA(current, historical, cpuRequest)
+		if suggested := analysis.HPA.Suggested; suggested != nil && !scalesOnCPU(current.Deployment.Config.HPA.Spec) {
+			analysis.HPA.Status = "proposed"
+		} else if suggested != nil && analysis.Pods.Recommendation != nil {
+			analysis.Pods.Recommendation.Min = suggested.Settings.MinReplicas
+			analysis.Pods.Recommendation.Max = suggested.Settings.MaxReplicas
+			analysis.Pods.Recommendation.Suggested = desiredReplicas(suggested.Settings,
+				current.Analysis.CPU.Usage.Current.Average*float64(current.Pods.Running))
+		}
+	}
+
+	// Valida as réplicas e o HPA recomendados contra os PDBs do workload
+	analysis.PDBConflicts = constrainPodRecommendation(current, historical, analysis)
+
 	return analysis
 }
 
+// recommendEventDrivenPods ajusta a recomendação de réplicas de um workload escalado por eventos:
+// os limites são os do ScaledObject (incluindo escala para zero) e as réplicas sugeridas são as
+// atuais, já que a quantidade de pods depende do tamanho da fila e não da utilização de CPU
+func recommendEventDrivenPods(recommendation *types.PodCount, keda *types.KEDAScaledObject) {
+	if recommendation == nil {
+		return
+	}
+	recommendation.Min = keda.MinReplicas
+	recommendation.Max = keda.MaxReplicas
+	recommendation.Suggested = max(keda.MinReplicas, min(keda.MaxReplicas, recommendation.Current))
+}
+
+// usageSamples converte o resultado de uma query range em amostras de uso, descartando as
+// amostras ausentes
+func usageSamples(values []types.QueryResult) []types.UsageSample {
+	samples := make([]types.UsageSample, 0, len(values))
+	for _, v := range values {
+		if v.Missing {
+			continue
+		}
+		samples = append(samples, types.UsageSample{
+			Timestamp: v.Timestamp.Unix(),
+			Value:     v.Value,
+		})
+	}
+	return samples
+}
+
+// recommendCPU calcula a recomendação de request de CPU a partir do uso médio e de pico.
+// Retorna nil quando não há dados de uso suficientes.
+func recommendCPU(usage types.UsageStats, request float64) *types.ResourceRecommendation {
+	if usage.Peak <= 0 || usage.Average <= 0 {
+		return nil
+	}
+
+	// Calcula a recomendação baseada no uso médio + 30% de buffer
+	suggestedCPU := usage.Average * 1.3
+
+	// Ajusta para o pico se necessário
+	if suggestedCPU < usage.Peak {
+		suggestedCPU = usage.Peak * 1.1 // 10% de buffer para picos
+	}
+
+	// Arredonda para o próximo múltiplo de 100m
+	suggestedCPU = math.Ceil(suggestedCPU/100) * 100
+
+	return &types.ResourceRecommendation{
+		Status: "optimized",
+		Recommendation: &types.ResourceSuggestion{
+			Current:   request,
+			Suggested: suggestedCPU,
+			Action:    determineAction(request, suggestedCPU),
+		},
+	}
+}
+
+// recommendMemory calcula a recomendação de request de memória a partir do uso médio e de pico.
+// Retorna nil quando não há dados de uso suficientes.
+func recommendMemory(usage types.UsageStats, request float64) *types.ResourceRecommendation {
+	if usage.Peak <= 0 || usage.Average <= 0 {
+		return nil
+	}
+
+	// Calcula a recomendação baseada no uso médio + 40% de buffer
+	suggestedMem := usage.Average * 1.4
+
+	// Ajusta para o pico se necessário
+	if suggestedMem < usage.Peak {
+		suggestedMem = usage.Peak * 1.2 // 20% de buffer para picos
+	}
+
+	// Arredonda para o próximo múltiplo de 128Mi
+	suggestedMem = math.Ceil(suggestedMem/128) * 128
+
+	return &types.ResourceRecommendation{
+		Status: "optimized",
+		Recommendation: &types.ResourceSuggestion{
+			Current:   request,
+			Suggested: suggestedMem,
+			Action:    determineAction(request, suggestedMem),
+		},
+	}
+}
+
+// suggestedOrCurrent retorna o valor sugerido da recomendação ou o valor atual quando não há sugestão
+func suggestedOrCurrent(recommendation *types.ResourceRecommendation, current float64) float64 {
+	if recommendation == nil || recommendation.Recommendation == nil {
+		return current
+	}
+	return recommendation.Recommendation.Suggested
+}
+
 // determineAction determina a ação recomendada baseada nos valores atual e sugerido
 func determineAction(current, suggested float64) string {
 	diff := math.Abs(current - suggested)
@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// SimulateHPA reproduz o histórico de uso de CPU do workload com a configuração atual e com a
+// configuração proposta, projetando réplicas, tempo no máximo de réplicas e custo mensal
+func (s *Service) SimulateHPA(ctx context.Context, namespace string, kind types.WorkloadKind, name string, period time.Duration, input types.HPASimulationInput) (*types.HPASimulation, error) {
+	if err := validateSimulationInput(input); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Starting HPA simulation",
+		logger.NewField("namespace", namespace),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+		logger.NewField("period", period),
+		logger.NewField("target_cpu", input.Settings.TargetCPU),
+		logger.NewField("min_replicas", input.Settings.MinReplicas),
+		logger.NewField("max_replicas", input.Settings.MaxReplicas),
+	)
+
+	config, err := s.metricsCollector.GetWorkloadConfig(ctx, namespace, kind, name)
+	if err != nil {
+		logger.Error("Failed to get workload configuration", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		return nil, fmt.Errorf("failed to get workload configuration: %w", err)
+	}
+
+	end := time.Now()
+	cpuResult, err := s.metricsCollector.QueryRange(ctx, buildCPUHistoricalQuery(namespace, kind, name), end.Add(-period), end, historicalStep)
+	if err != nil {
+		logger.Error("Failed to get historical CPU metrics", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		return nil, fmt.Errorf("failed to get historical CPU metrics: %w", err)
+	}
+
+	samples := usageSamples(cpuResult.Values)
+	if len(samples) < 2 {
+		return nil, errors.NewInvalidMetricsError("cpu", "histórico de uso insuficiente para a simulação")
+	}
+
+	// Sem behavior proposto, mantém as políticas de escala do HPA atual
+	behavior := input.Behavior
+	if behavior == nil && config.HPA != nil {
+		behavior = config.HPA.Behavior
+	}
+
+	proposed := newHPAController(input.Settings, behavior).replay(samples, historicalStep, true)
+	current := currentReplay(config, samples)
+
+	memoryRequest := input.MemoryRequest
+	if memoryRequest <= 0 {
+		memoryRequest = config.Memory.Request
+	}
+
+	prices, err := s.pricingClient.GetCurrentPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", err)
+	}
+	exchange, err := s.pricingClient.GetExchangeRate(ctx, "USD", "BRL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	cpuHourly, memoryHourly := prices.CPU.PerCore*exchange.Rate, prices.Memory.PerGB*exchange.Rate
+
+	// Custo mensal projetado: réplicas médias do replay x custo mensal por pod
+	currentCosts := scaleCosts(monthlyCost(config.CPU.Request, config.Memory.Request, cpuHourly, memoryHourly), current.AverageReplicas)
+	proposedCosts := scaleCosts(monthlyCost(input.Settings.CPURequest, memoryRequest, cpuHourly, memoryHourly), proposed.AverageReplicas)
+
+	result := &types.HPASimulation{
+		Namespace: namespace,
+		Kind:      kind,
+		Name:      name,
+		Period:    period.String(),
+		Step:      int64(historicalStep.Seconds()),
+		Currency:  "BRL",
+		Current:   current,
+		Proposed:  proposed,
+		Costs: types.HPASimulationCosts{
+			Current:  currentCosts,
+			Proposed: proposedCosts,
+			Savings: &types.ResourceCosts{
+				CPU:    currentCosts.CPU - proposedCosts.CPU,
+				Memory: currentCosts.Memory - proposedCosts.Memory,
+				Total:  currentCosts.Total - proposedCosts.Total,
+			},
+		},
+	}
+
+	logger.Info("HPA simulation completed",
+		logger.NewField("namespace", namespace),
+		logger.NewField("name", name),
+		logger.NewField("peak_utilization", proposed.PeakUtilization),
+		logger.NewField("time_at_max_seconds", proposed.TimeAtMaxSeconds),
+		logger.NewField("monthly_savings", result.Costs.Savings.Total),
+	)
+
+	return result, nil
+}
+
+// validateSimulationInput valida a configuração proposta para o simulador
+func validateSimulationInput(input types.HPASimulationInput) error {
+	settings := input.Settings
+	switch {
+	case settings.CPURequest <= 0:
+		return errors.NewInvalidConfigurationError("cpuRequest", "request de CPU deve ser maior que zero")
+	case settings.TargetCPU <= 0:
+		return errors.NewInvalidConfigurationError("targetCPUUtilization", "alvo de utilização deve ser maior que zero")
+	case settings.MinReplicas < 1:
+		return errors.NewInvalidConfigurationError("minReplicas", "mínimo de réplicas deve ser maior que zero")
+	case settings.MaxReplicas < settings.MinReplicas:
+		return errors.NewInvalidConfigurationError("maxReplicas", "máximo de réplicas deve ser maior ou igual ao mínimo")
+	}
+	return nil
+}
+
+// currentReplay reproduz o histórico com a configuração atual do workload. Sem HPA de
+// utilização de CPU, o workload mantém as réplicas configuradas durante todo o período.
+func currentReplay(config *types.K8sDeploymentConfig, samples []types.UsageSample) *types.HPAReplay {
+	if target := config.HPA.ResourceUtilizationTarget("cpu"); target > 0 && config.CPU.Request > 0 {
+		settings := types.HPASettings{
+			TargetCPU:   target,
+			MinReplicas: config.HPA.MinReplicas,
+			MaxReplicas: config.HPA.MaxReplicas,
+			CPURequest:  config.CPU.Request,
+		}
+		return newHPAController(settings, config.HPA.Behavior).replay(samples, historicalStep, true)
+	}
+
+	replicas := max(1, config.Pods.Replicas)
+	settings := types.HPASettings{MinReplicas: replicas, MaxReplicas: replicas, CPURequest: config.CPU.Request}
+	replay := &types.HPAReplay{
+		Settings:        settings,
+		AverageReplicas: float64(replicas),
+		ReplicaHours:    float64(replicas*len(samples)) * historicalStep.Hours(),
+		Series:          make([]types.HPAReplayPoint, 0, len(samples)),
+	}
+
+	var sumUtilization float64
+	for _, sample := range samples {
+		var utilization float64
+		if config.CPU.Request > 0 {
+			utilization = sample.Value / (float64(replicas) * config.CPU.Request) * 100
+		}
+		replay.PeakUtilization = max(replay.PeakUtilization, utilization)
+		sumUtilization += utilization
+		replay.Series = append(replay.Series, types.HPAReplayPoint{
+			Timestamp:   sample.Timestamp,
+			Usage:       sample.Value,
+			Replicas:    replicas,
+			Utilization: utilization,
+		})
+	}
+	replay.AverageUtilization = sumUtilization / float64(len(samples))
+	return replay
+}
+
+// scaleCosts multiplica os custos por pod pelo número de réplicas
+func scaleCosts(costs *types.ResourceCosts, replicas float64) *types.ResourceCosts {
+	return &types.ResourceCosts{
+		CPU:    costs.CPU * replicas,
+		Memory: costs.Memory * replicas,
+		Total:  costs.Total * replicas,
+	}
+}
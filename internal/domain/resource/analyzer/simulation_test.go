@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateHPA(t *testing.T) {
+	start := time.Unix(0, 0)
+	history := make([]types.QueryResult, 0, 4)
+	for i, value := range []float64{2000, 2000, 4000, 2000} {
+		history = append(history, types.QueryResult{Value: value, Timestamp: start.Add(time.Duration(i) * historicalStep)})
+	}
+
+	collector := &mockCollector{
+		configs: map[string]*types.K8sDeploymentConfig{},
+		history: history,
+	}
+	// Sem HPA: 4 réplicas fixas de 1000m e 1024Mi
+	collector.configs["api"], _ = newWorkloadFixture(1000, 500, 1024, 512, 4)
+
+	service := NewService(collector, pricing.NewClient(&pricing.Config{}), nil)
+	input := types.HPASimulationInput{
+		Settings: types.HPASettings{TargetCPU: 80, MinReplicas: 2, MaxReplicas: 4, CPURequest: 1000},
+	}
+
+	t.Run("projeta réplicas e custos", func(t *testing.T) {
+		result, err := service.SimulateHPA(context.Background(), "default", types.WorkloadKindDeployment, "api", time.Hour, input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(300), result.Step)
+		assert.Equal(t, 4.0, result.Current.AverageReplicas)
+		assert.Len(t, result.Current.Series, 4)
+
+		// 2000m com alvo de 80% de 1000m: 3 réplicas; o pico de 4000m leva ao máximo de 4
+		assert.Len(t, result.Proposed.Series, 4)
+		assert.Equal(t, 3, result.Proposed.Series[0].Replicas)
+		assert.Equal(t, 4, result.Proposed.Series[3].Replicas)
+		assert.Equal(t, int64(300), result.Proposed.TimeAtMaxSeconds)
+
+		assert.Greater(t, result.Costs.Current.Total, result.Costs.Proposed.Total)
+		assert.InDelta(t, result.Costs.Current.Total-result.Costs.Proposed.Total, result.Costs.Savings.Total, 0.0001)
+	})
+
+	t.Run("configuração inválida", func(t *testing.T) {
+		invalid := input
+		invalid.Settings.MaxReplicas = 1
+
+		_, err := service.SimulateHPA(context.Background(), "default", types.WorkloadKindDeployment, "api", time.Hour, invalid)
+
+		assert.True(t, errors.IsInvalidConfiguration(err))
+	})
+
+	t.Run("histórico insuficiente", func(t *testing.T) {
+		empty := &mockCollector{configs: collector.configs}
+		service := NewService(empty, pricing.NewClient(&pricing.Config{}), nil)
+
+		_, err := service.SimulateHPA(context.Background(), "default", types.WorkloadKindDeployment, "api", time.Hour, input)
+
+		assert.True(t, errors.IsInvalidMetrics(err))
+	})
+}
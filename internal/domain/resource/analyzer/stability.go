@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// applyContainerStability copia reinícios e a última terminação de cada container para as métricas.
+// Containers com OOMKill no status de algum pod são marcados como OOMKilled.
+func applyContainerStability(containers []*types.ContainerMetrics, stability *types.WorkloadStability) {
+	byName := make(map[string]types.ContainerStability, len(stability.Containers))
+	for _, c := range stability.Containers {
+		byName[c.Name] = c
+	}
+	for _, container := range containers {
+		if c, ok := byName[container.Name]; ok {
+			container.Restarts = c.Restarts
+			container.LastTermination = c.LastTermination
+			if c.OOMKills > 0 {
+				container.OOMKilled = true
+			}
+		}
+	}
+}
+
+// detectOOMKills marca os containers encerrados por OOMKilled no período analisado.
+// O status dos pods só guarda a última terminação; para pods já substituídos ou terminações
+// mais antigas, consulta kube_pod_container_status_last_terminated_reason no Mimir. Quando a
+// consulta falha, o container é tratado como OOMKilled para que a memória não seja reduzida.
+func (s *Service) detectOOMKills(ctx context.Context, namespace string, kind types.WorkloadKind, name string, containers []*types.ContainerMetrics, start, end time.Time) {
+	for _, container := range containers {
+		if container.OOMKilled || terminatedInWindow(container.LastTermination, types.TerminationReasonOOMKilled, start) {
+			container.OOMKilled = true
+			continue
+		}
+
+		query := buildContainerOOMKilledQuery(namespace, kind, name, container.Name, end.Sub(start))
+		result, err := s.metricsCollector.Query(ctx, query)
+		if err != nil {
+			logger.Error("Failed to get container termination reasons, protecting memory", err,
+				logger.NewField("container", container.Name),
+			)
+			container.OOMKilled = true
+			continue
+		}
+		container.OOMKilled = !result.Missing && result.Value > 0
+	}
+}
+
+// terminatedInWindow verifica se a terminação tem o motivo informado e ocorreu a partir de start
+func terminatedInWindow(termination *types.ContainerTermination, reason string, start time.Time) bool {
+	if termination == nil || termination.Reason != reason {
+		return false
+	}
+	finishedAt, err := time.Parse(time.RFC3339, termination.FinishedAt)
+	if err != nil {
+		return false
+	}
+	return !finishedAt.Before(start)
+}
+
+// protectMemory impede que a recomendação sugira reduzir a memória de um container que sofreu OOMKill.
+// Quando a sugestão fica abaixo do request atual, mantém o request.
+func protectMemory(recommendation *types.ResourceRecommendation, request float64) *types.ResourceRecommendation {
+	if recommendation == nil || recommendation.Recommendation == nil {
+		recommendation = &types.ResourceRecommendation{
+			Recommendation: &types.ResourceSuggestion{Current: request, Suggested: request},
+		}
+	}
+	suggestion := recommendation.Recommendation
+	if suggestion.Suggested < request {
+		suggestion.Suggested = request
+	}
+	suggestion.Action = determineAction(suggestion.Current, suggestion.Suggested)
+	recommendation.Status = "oom_protected"
+	return recommendation
+}
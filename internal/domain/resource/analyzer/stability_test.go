@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectOOMKills(t *testing.T) {
+	end := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-24 * time.Hour)
+
+	containers := []*types.ContainerMetrics{
+		{Name: "app", LastTermination: &types.ContainerTermination{Reason: types.TerminationReasonOOMKilled, FinishedAt: end.Add(-time.Hour).Format(time.RFC3339)}},
+		{Name: "worker", LastTermination: &types.ContainerTermination{Reason: types.TerminationReasonOOMKilled, FinishedAt: start.Add(-time.Hour).Format(time.RFC3339)}},
+		{Name: "istio-proxy"},
+		{Name: "cache", LastTermination: &types.ContainerTermination{Reason: types.TerminationReasonError, FinishedAt: end.Format(time.RFC3339)}},
+	}
+	service := &Service{metricsCollector: &mockCollector{
+		instant: map[string]float64{
+			buildContainerOOMKilledQuery("default", types.WorkloadKindDeployment, "api", "istio-proxy", 24*time.Hour): 1,
+		},
+	}}
+
+	service.detectOOMKills(context.Background(), "default", types.WorkloadKindDeployment, "api", containers, start, end)
+
+	assert.True(t, containers[0].OOMKilled, "OOMKill recente no status do pod")
+	assert.False(t, containers[1].OOMKilled, "OOMKill anterior ao período")
+	assert.True(t, containers[2].OOMKilled, "OOMKill registrado no Mimir")
+	assert.False(t, containers[3].OOMKilled)
+}
+
+func TestDetectOOMKills_QueryFailure(t *testing.T) {
+	end := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-24 * time.Hour)
+
+	containers := []*types.ContainerMetrics{{Name: "app"}, {Name: "istio-proxy"}}
+	applyContainerStability(containers, &types.WorkloadStability{
+		Containers: []types.ContainerStability{
+			{Name: "app", Restarts: 3, OOMKills: 2},
+			{Name: "istio-proxy"},
+		},
+	})
+	assert.True(t, containers[0].OOMKilled, "OOMKills anteriores no status dos pods")
+
+	service := &Service{metricsCollector: &mockCollector{queryErr: context.DeadlineExceeded}}
+	service.detectOOMKills(context.Background(), "default", types.WorkloadKindDeployment, "api", containers, start, end)
+
+	assert.True(t, containers[0].OOMKilled)
+	assert.True(t, containers[1].OOMKilled, "falha na consulta protege a memória")
+
+	current := &types.CurrentMetrics{Pods: &types.PodMetrics{}, Containers: containers}
+	containers[0].Memory.Request = 1024
+	containers[0].Memory.Usage.Current = types.UsageStats{Average: 300, Peak: 350}
+	analysis := (&Service{}).CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+	assert.Equal(t, "oom_protected", analysis.Containers[0].Memory.Status)
+	assert.Equal(t, float64(1024), analysis.Containers[0].Memory.Recommendation.Suggested)
+}
+
+func TestCalculateRecommendations_OOMProtected(t *testing.T) {
+	service := &Service{}
+	current := &types.CurrentMetrics{
+		Pods: &types.PodMetrics{},
+		Containers: []*types.ContainerMetrics{
+			{Name: "app", OOMKilled: true},
+			{Name: "istio-proxy"},
+		},
+	}
+	current.Containers[0].Memory.Request = 1024
+	current.Containers[0].Memory.Usage.Current = types.UsageStats{Average: 300, Peak: 350}
+	current.Containers[1].Memory.Request = 512
+	current.Containers[1].Memory.Usage.Current = types.UsageStats{Average: 60, Peak: 70}
+
+	analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+	// app: o uso sugere 512Mi, mas o container sofreu OOMKill e mantém o request
+	assert.Equal(t, "oom_protected", analysis.Containers[0].Memory.Status)
+	assert.Equal(t, float64(1024), analysis.Containers[0].Memory.Recommendation.Suggested)
+	assert.Equal(t, "maintain", analysis.Containers[0].Memory.Recommendation.Action)
+	// istio-proxy continua podendo reduzir
+	assert.Equal(t, float64(128), analysis.Containers[1].Memory.Recommendation.Suggested)
+	assert.Equal(t, float64(1024+128), analysis.Memory.Recommendation.Suggested)
+
+	t.Run("OOMKill sem métricas por container protege o pod", func(t *testing.T) {
+		current := &types.CurrentMetrics{
+			Pods:      &types.PodMetrics{},
+			Stability: &types.WorkloadStability{OOMKills: 1},
+		}
+		current.Deployment.Config.Memory.Request = 2048
+		current.Analysis.Memory.Usage.Current = types.UsageStats{Average: 300, Peak: 350}
+
+		analysis := service.CalculateRecommendations(current, &types.HistoricalMetrics{})
+
+		assert.Equal(t, "oom_protected", analysis.Memory.Status)
+		assert.Equal(t, float64(2048), analysis.Memory.Recommendation.Suggested)
+	})
+
+	t.Run("sugestão de aumento é mantida", func(t *testing.T) {
+		recommendation := protectMemory(recommendMemory(types.UsageStats{Average: 900, Peak: 1000}, 512), 512)
+
+		assert.Equal(t, float64(1280), recommendation.Recommendation.Suggested)
+		assert.Equal(t, "increase", recommendation.Recommendation.Action)
+	})
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// collectCPUThrottling obtém o percentual de períodos do CFS com throttling de cada container no período
+func (s *Service) collectCPUThrottling(ctx context.Context, namespace string, kind types.WorkloadKind, name string, containers []*types.ContainerMetrics, period time.Duration) {
+	for _, container := range containers {
+		result, err := s.metricsCollector.Query(ctx, buildContainerCPUThrottlingQuery(namespace, kind, name, container.Name, period))
+		if err != nil {
+			logger.Error("Failed to get container CPU throttling", err,
+				logger.NewField("container", container.Name),
+			)
+			continue
+		}
+		if result.Missing {
+			// Sem períodos do CFS no período (ex: container sem limite de CPU)
+			continue
+		}
+		container.CPUThrottling = result.Value
+	}
+}
+
+// throttlingThresholdOrDefault retorna o limiar de throttling configurado ou o padrão
+func (s *Service) throttlingThresholdOrDefault() float64 {
+	if s.throttlingThreshold <= 0 {
+		return DefaultThrottlingThreshold
+	}
+	return s.throttlingThreshold
+}
+
+// recommendCPULimit calcula a recomendação de limit de CPU de um container.
+// O limit segue a razão limit/request da política; um limit com throttling acima do limiar é
+// ampliado ou, se a política permitir, removido. Em pods Guaranteed o limit acompanha o request.
+// Retorna nil quando o container não tem limit de CPU.
+func (s *Service) recommendCPULimit(container *types.ContainerMetrics, request *types.ResourceRecommendation, policy limitPolicy) *types.ResourceRecommendation {
+	limit := container.CPU.Limit
+	if limit <= 0 {
+		return nil
+	}
+
+	throttled := container.CPUThrottling >= s.throttlingThresholdOrDefault()
+	if throttled && !policy.guaranteed && s.cpuLimitPolicy == CPULimitPolicyRemove {
+		return &types.ResourceRecommendation{
+			Status: "throttled",
+			Recommendation: &types.ResourceSuggestion{
+				Current:   limit,
+				Suggested: 0,
+				Action:    "remove",
+			},
+		}
+	}
+
+	status := "optimized"
+	suggested := limitForRequest(limit, container.CPU.Request, suggestedOrCurrent(request, container.CPU.Request), policy.cpuRatio, policy.guaranteed, 100)
+	if throttled {
+		status = "throttled"
+		if !policy.guaranteed {
+			// O throttling indica rajadas acima do limit, mesmo com uso médio baixo:
+			// amplia o limit em 50% ou para 1,5x o pico, arredondado para 100m
+			suggested = max(suggested, math.Ceil(max(limit, container.CPU.Usage.Current.Peak)*1.5/100)*100)
+		}
+	}
+
+	return &types.ResourceRecommendation{
+		Status: status,
+		Recommendation: &types.ResourceSuggestion{
+			Current:   limit,
+			Suggested: suggested,
+			Action:    determineAction(limit, suggested),
+		},
+	}
+}
+
+// throttlingAlerts gera um alerta para cada container com throttling de CPU acima do limiar.
+// O alerta independe do uso médio: rajadas curtas são limitadas mesmo quando a média está baixa.
+func (s *Service) throttlingAlerts(current *types.CurrentMetrics) []types.Alert {
+	if current == nil {
+		return nil
+	}
+
+	threshold := s.throttlingThresholdOrDefault()
+	var alerts []types.Alert
+	for _, container := range current.Containers {
+		if container.CPUThrottling < threshold {
+			continue
+		}
+
+		severity := "warning"
+		if container.CPUThrottling >= 2*threshold {
+			severity = "critical"
+		}
+		message := fmt.Sprintf("Container %s limitado em %.1f%% dos períodos de CPU", container.Name, container.CPUThrottling)
+		if limit := container.CPU.Limit; limit > 0 && container.CPU.Usage.Current.Average < limit/2 {
+			message += fmt.Sprintf(", mesmo com uso médio de %.0f%% do limit", container.CPU.Usage.Current.Average/limit*100)
+		}
+
+		alerts = append(alerts, types.Alert{
+			Type:        "cpu_throttling",
+			Severity:    severity,
+			Message:     message,
+			Resource:    container.Name,
+			CurrentVal:  container.CPUThrottling,
+			Threshold:   threshold,
+			Occurrences: 1,
+		})
+	}
+	return alerts
+}
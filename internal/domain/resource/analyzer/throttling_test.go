@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newThrottledContainer cria um container com request, limit, uso e throttling de CPU
+func newThrottledContainer(name string, request, limit, average, peak, throttling float64) *types.ContainerMetrics {
+	container := &types.ContainerMetrics{Name: name, CPUThrottling: throttling}
+	container.CPU.Request = request
+	container.CPU.Limit = limit
+	container.CPU.Usage.Current = types.UsageStats{Average: average, Peak: peak}
+	return container
+}
+
+func TestCollectCPUThrottling(t *testing.T) {
+	containers := []*types.ContainerMetrics{{Name: "app"}, {Name: "istio-proxy"}}
+	service := &Service{metricsCollector: &mockCollector{
+		instant: map[string]float64{
+			buildContainerCPUThrottlingQuery("default", types.WorkloadKindDeployment, "api", "app", time.Hour): 42.5,
+		},
+	}}
+
+	service.collectCPUThrottling(context.Background(), "default", types.WorkloadKindDeployment, "api", containers, time.Hour)
+
+	assert.Equal(t, 42.5, containers[0].CPUThrottling)
+	assert.Equal(t, float64(0), containers[1].CPUThrottling)
+}
+
+func TestRecommendCPULimit(t *testing.T) {
+	request := &types.ResourceRecommendation{Recommendation: &types.ResourceSuggestion{Suggested: 300}}
+
+	tests := []struct {
+		name      string
+		policy    string
+		limits    limitPolicy
+		container *types.ContainerMetrics
+		expected  *types.ResourceRecommendation
+	}{
+		{
+			name:      "Sem limit não há recomendação",
+			container: newThrottledContainer("app", 500, 0, 100, 200, 0),
+		},
+		{
+			name:      "Limit sem throttling preserva a razão limit/request atual",
+			container: newThrottledContainer("app", 500, 1000, 100, 200, 5),
+			expected: &types.ResourceRecommendation{
+				Status:         "optimized",
+				Recommendation: &types.ResourceSuggestion{Current: 1000, Suggested: 600, Action: "decrease"},
+			},
+		},
+		{
+			name:      "Limit segue a razão configurada",
+			limits:    limitPolicy{cpuRatio: 3},
+			container: newThrottledContainer("app", 500, 500, 100, 200, 5),
+			expected: &types.ResourceRecommendation{
+				Status:         "optimized",
+				Recommendation: &types.ResourceSuggestion{Current: 500, Suggested: 900, Action: "increase"},
+			},
+		},
+		{
+			name:      "Limit com throttling e uso médio baixo é ampliado",
+			container: newThrottledContainer("app", 500, 500, 100, 450, 40),
+			expected: &types.ResourceRecommendation{
+				Status:         "throttled",
+				Recommendation: &types.ResourceSuggestion{Current: 500, Suggested: 800, Action: "increase"},
+			},
+		},
+		{
+			name:      "Limit com throttling é removido quando a política permite",
+			policy:    CPULimitPolicyRemove,
+			container: newThrottledContainer("app", 500, 500, 100, 450, 40),
+			expected: &types.ResourceRecommendation{
+				Status:         "throttled",
+				Recommendation: &types.ResourceSuggestion{Current: 500, Suggested: 0, Action: "remove"},
+			},
+		},
+		{
+			name:      "Pod Guaranteed mantém o limit igual ao request mesmo com throttling",
+			policy:    CPULimitPolicyRemove,
+			limits:    limitPolicy{guaranteed: true},
+			container: newThrottledContainer("app", 500, 500, 100, 450, 40),
+			expected: &types.ResourceRecommendation{
+				Status:         "throttled",
+				Recommendation: &types.ResourceSuggestion{Current: 500, Suggested: 300, Action: "decrease"},
+			},
+		},
+		{
+			name:      "Limit igual ao request acompanha o request recomendado",
+			container: newThrottledContainer("app", 200, 200, 150, 250, 0),
+			expected: &types.ResourceRecommendation{
+				Status:         "optimized",
+				Recommendation: &types.ResourceSuggestion{Current: 200, Suggested: 300, Action: "increase"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Service{cpuLimitPolicy: tt.policy}
+			assert.Equal(t, tt.expected, service.recommendCPULimit(tt.container, request, tt.limits))
+		})
+	}
+}
+
+func TestGenerateAlerts_Throttling(t *testing.T) {
+	service := NewService(nil, nil, nil)
+	current := &types.CurrentMetrics{
+		Containers: []*types.ContainerMetrics{
+			newThrottledContainer("app", 500, 1000, 100, 900, 30),
+			newThrottledContainer("worker", 500, 500, 400, 500, 60),
+			newThrottledContainer("istio-proxy", 100, 200, 50, 100, 10),
+		},
+	}
+
+	alerts := service.GenerateAlerts(current, &types.HistoricalMetrics{})
+
+	if assert.Len(t, alerts, 2) {
+		assert.Equal(t, "cpu_throttling", alerts[0].Type)
+		assert.Equal(t, "warning", alerts[0].Severity)
+		assert.Equal(t, "app", alerts[0].Resource)
+		assert.Equal(t, DefaultThrottlingThreshold, alerts[0].Threshold)
+		assert.Contains(t, alerts[0].Message, "uso médio de 10% do limit")
+		assert.Equal(t, "critical", alerts[1].Severity)
+		assert.NotContains(t, alerts[1].Message, "uso médio")
+	}
+}
@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"math"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+)
+
+// compareVPA coloca a recomendação do VPA ao lado da recomendação do analisador para cada
+// container e calcula a divergência entre elas. Retorna nil quando o workload não tem VPA.
+func compareVPA(vpa *types.VPAConfig, current *types.CurrentMetrics, analysis *types.ResourceRecommendationAnalysis) *types.VPAComparison {
+	if vpa == nil {
+		return nil
+	}
+
+	result := &types.VPAComparison{
+		Name:           vpa.Name,
+		UpdateMode:     vpa.UpdateMode,
+		Recommendation: vpa.Containers,
+	}
+
+	var totalDivergence float64
+	for _, recommendation := range vpa.Containers {
+		cpu, memory, ok := analyzerRecommendation(recommendation.Container, len(vpa.Containers), current, analysis)
+		if !ok {
+			continue
+		}
+
+		comparison := types.VPAContainerComparison{
+			Container: recommendation.Container,
+			CPU:       compareVPAResource(cpu, recommendation.Target.CPU, recommendation.LowerBound.CPU, recommendation.UpperBound.CPU),
+			Memory:    compareVPAResource(memory, recommendation.Target.Memory, recommendation.LowerBound.Memory, recommendation.UpperBound.Memory),
+		}
+		totalDivergence += comparison.CPU.Divergence + comparison.Memory.Divergence
+		result.Containers = append(result.Containers, comparison)
+	}
+
+	if len(result.Containers) > 0 {
+		result.DivergenceScore = totalDivergence / float64(2*len(result.Containers))
+	}
+	return result
+}
+
+// analyzerRecommendation retorna os requests recomendados pelo analisador para um container.
+// Sem detalhe por container, um VPA com um único container é comparado ao total do pod.
+func analyzerRecommendation(container string, vpaContainers int, current *types.CurrentMetrics, analysis *types.ResourceRecommendationAnalysis) (float64, float64, bool) {
+	for i, rec := range analysis.Containers {
+		if rec.Name != container || i >= len(current.Containers) {
+			continue
+		}
+		config := current.Containers[i]
+		return suggestedOrCurrent(rec.CPU, config.CPU.Request), suggestedOrCurrent(rec.Memory, config.Memory.Request), true
+	}
+
+	if len(analysis.Containers) == 0 && vpaContainers == 1 {
+		return suggestedOrCurrent(analysis.CPU, current.Deployment.Config.CPU.Request),
+			suggestedOrCurrent(analysis.Memory, current.Deployment.Config.Memory.Request), true
+	}
+	return 0, 0, false
+}
+
+// compareVPAResource compara a recomendação do analisador com o target e os limites do VPA.
+// A divergência é a diferença relativa ao maior dos dois valores, de 0 (iguais) a 100.
+func compareVPAResource(analyzer, target, lowerBound, upperBound float64) types.VPAResourceComparison {
+	comparison := types.VPAResourceComparison{
+		Analyzer:     analyzer,
+		Target:       target,
+		LowerBound:   lowerBound,
+		UpperBound:   upperBound,
+		WithinBounds: analyzer >= lowerBound && (upperBound <= 0 || analyzer <= upperBound),
+	}
+	if largest := math.Max(analyzer, target); largest > 0 {
+		comparison.Divergence = math.Abs(analyzer-target) / largest * 100
+	}
+	return comparison
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVPA(t *testing.T) {
+	vpa := &types.VPAConfig{
+		Name:       "api-vpa",
+		UpdateMode: "Off",
+		Containers: []types.VPAContainerRecommendation{
+			{
+				Container:  "app",
+				Target:     types.VPAResources{CPU: 400, Memory: 512},
+				LowerBound: types.VPAResources{CPU: 200, Memory: 256},
+				UpperBound: types.VPAResources{CPU: 800, Memory: 1024},
+			},
+			{Container: "unknown", Target: types.VPAResources{CPU: 100, Memory: 128}},
+		},
+	}
+
+	current := &types.CurrentMetrics{
+		Containers: []*types.ContainerMetrics{{Name: "app"}},
+	}
+	current.Containers[0].CPU.Request = 1000
+	current.Containers[0].Memory.Request = 512
+	analysis := &types.ResourceRecommendationAnalysis{
+		Containers: []*types.ContainerRecommendation{{
+			Name: "app",
+			CPU: &types.ResourceRecommendation{
+				Status:         "optimized",
+				Recommendation: &types.ResourceSuggestion{Current: 1000, Suggested: 1000},
+			},
+			// Sem dados de memória, o request atual é comparado
+			Memory: &types.ResourceRecommendation{Status: "insufficient_data"},
+		}},
+	}
+
+	result := compareVPA(vpa, current, analysis)
+
+	assert.Equal(t, "Off", result.UpdateMode)
+	assert.Len(t, result.Recommendation, 2)
+	if assert.Len(t, result.Containers, 1) {
+		comparison := result.Containers[0]
+		assert.Equal(t, "app", comparison.Container)
+		assert.Equal(t, 1000.0, comparison.CPU.Analyzer)
+		assert.False(t, comparison.CPU.WithinBounds)
+		assert.Equal(t, 60.0, comparison.CPU.Divergence)
+		assert.True(t, comparison.Memory.WithinBounds)
+		assert.Equal(t, 0.0, comparison.Memory.Divergence)
+	}
+	assert.Equal(t, 30.0, result.DivergenceScore)
+
+	assert.Nil(t, compareVPA(nil, current, analysis))
+}
+
+func TestCompareVPA_PodTotal(t *testing.T) {
+	vpa := &types.VPAConfig{
+		Name:       "worker-vpa",
+		UpdateMode: "Auto",
+		Containers: []types.VPAContainerRecommendation{
+			{Container: "worker", Target: types.VPAResources{CPU: 500, Memory: 256}},
+		},
+	}
+	current := &types.CurrentMetrics{}
+	current.Deployment.Config.CPU.Request = 500
+	current.Deployment.Config.Memory.Request = 512
+	analysis := &types.ResourceRecommendationAnalysis{
+		CPU:    &types.ResourceRecommendation{Status: "insufficient_data"},
+		Memory: &types.ResourceRecommendation{Status: "insufficient_data"},
+	}
+
+	result := compareVPA(vpa, current, analysis)
+
+	if assert.Len(t, result.Containers, 1) {
+		assert.Equal(t, 0.0, result.Containers[0].CPU.Divergence)
+		assert.Equal(t, 50.0, result.Containers[0].Memory.Divergence)
+	}
+	assert.Equal(t, 25.0, result.DivergenceScore)
+}
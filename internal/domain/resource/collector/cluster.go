@@ -0,0 +1,185 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+)
+
+// clusterContextKey é a chave do cluster selecionado no contexto da requisição
+type clusterContextKey struct{}
+
+// WithCluster retorna um contexto que direciona a coleta ao cluster informado
+func WithCluster(ctx context.Context, cluster string) context.Context {
+	return context.WithValue(ctx, clusterContextKey{}, cluster)
+}
+
+// ClusterFromContext retorna o cluster selecionado no contexto, ou vazio quando não há seleção
+func ClusterFromContext(ctx context.Context) string {
+	cluster, _ := ctx.Value(clusterContextKey{}).(string)
+	return cluster
+}
+
+// ClusterCollector implementa a interface Collector encaminhando cada chamada ao Collector
+// do cluster selecionado no contexto (WithCluster). Sem seleção, usa o cluster padrão.
+type ClusterCollector struct {
+	collectors     map[string]Collector
+	clusters       []string
+	defaultCluster string
+}
+
+// NewClusterCollector cria um ClusterCollector vazio com o cluster padrão informado
+func NewClusterCollector(defaultCluster string) *ClusterCollector {
+	return &ClusterCollector{
+		collectors:     make(map[string]Collector),
+		defaultCluster: defaultCluster,
+	}
+}
+
+// Register adiciona o Collector de um cluster
+func (c *ClusterCollector) Register(cluster string, collector Collector) {
+	if _, ok := c.collectors[cluster]; !ok {
+		c.clusters = append(c.clusters, cluster)
+	}
+	c.collectors[cluster] = collector
+}
+
+// Clusters retorna os clusters registrados, na ordem de registro
+func (c *ClusterCollector) Clusters() []string {
+	return append([]string(nil), c.clusters...)
+}
+
+// DefaultCluster retorna o cluster usado quando a requisição não seleciona nenhum
+func (c *ClusterCollector) DefaultCluster() string {
+	return c.defaultCluster
+}
+
+// HasCluster verifica se o cluster está registrado
+func (c *ClusterCollector) HasCluster(cluster string) bool {
+	_, ok := c.collectors[cluster]
+	return ok
+}
+
+// collector retorna o Collector do cluster selecionado no contexto
+func (c *ClusterCollector) collector(ctx context.Context) (Collector, error) {
+	cluster := ClusterFromContext(ctx)
+	if cluster == "" {
+		cluster = c.defaultCluster
+	}
+	collector, ok := c.collectors[cluster]
+	if !ok {
+		return nil, errors.NewResourceNotFoundError("cluster", "cluster não configurado: "+cluster)
+	}
+	return collector, nil
+}
+
+// GetDeploymentMetrics retorna métricas atuais de um deployment do cluster selecionado
+func (c *ClusterCollector) GetDeploymentMetrics(ctx context.Context, namespace, name string) (*types.K8sMetrics, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.GetDeploymentMetrics(ctx, namespace, name)
+}
+
+// GetDeploymentConfig retorna configurações de um deployment do cluster selecionado
+func (c *ClusterCollector) GetDeploymentConfig(ctx context.Context, namespace, name string) (*types.K8sDeploymentConfig, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.GetDeploymentConfig(ctx, namespace, name)
+}
+
+// GetWorkloadMetrics retorna métricas atuais de um workload do cluster selecionado
+func (c *ClusterCollector) GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.GetWorkloadMetrics(ctx, namespace, kind, name)
+}
+
+// GetWorkloadConfig retorna configurações de um workload do cluster selecionado
+func (c *ClusterCollector) GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.GetWorkloadConfig(ctx, namespace, kind, name)
+}
+
+// ListWorkloads lista os workloads de um namespace do cluster selecionado
+func (c *ClusterCollector) ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.ListWorkloads(ctx, namespace)
+}
+
+// ListNamespaces lista os namespaces do cluster selecionado
+func (c *ClusterCollector) ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.ListNamespaces(ctx)
+}
+
+// GetNodeInventory retorna o inventário de nodes do cluster selecionado
+func (c *ClusterCollector) GetNodeInventory(ctx context.Context) (*types.NodeInventory, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.GetNodeInventory(ctx)
+}
+
+// GetNamespaceQuotas retorna os ResourceQuotas e LimitRanges de um namespace do cluster selecionado
+func (c *ClusterCollector) GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.GetNamespaceQuotas(ctx, namespace)
+}
+
+// Query executa uma query pontual no tenant do Mimir do cluster selecionado
+func (c *ClusterCollector) Query(ctx context.Context, query string) (*types.QueryResult, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.Query(ctx, query)
+}
+
+// QueryRange executa uma query com range de tempo no tenant do Mimir do cluster selecionado
+func (c *ClusterCollector) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.QueryRangeResult, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.QueryRange(ctx, query, start, end, step)
+}
+
+// QuerySeries executa uma query pontual no tenant do Mimir do cluster selecionado e retorna todas as séries
+func (c *ClusterCollector) QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.QuerySeries(ctx, query)
+}
+
+// QueryRangeSeries executa uma query com range de tempo no tenant do Mimir do cluster selecionado e
+// retorna todas as séries
+func (c *ClusterCollector) QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error) {
+	collector, err := c.collector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return collector.QueryRangeSeries(ctx, query, start, end, step)
+}
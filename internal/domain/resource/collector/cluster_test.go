@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// namedK8sClient é um mock do cliente Kubernetes que identifica o cluster na configuração
+type namedK8sClient struct {
+	MockK8sClient
+	cluster string
+}
+
+func (m *namedK8sClient) GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error) {
+	config, err := m.MockK8sClient.GetWorkloadConfig(ctx, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	config.ClusterName = m.cluster
+	return config, nil
+}
+
+func TestClusterCollector(t *testing.T) {
+	clusters := NewClusterCollector("staging")
+	clusters.Register("staging", NewK8sMimirCollector(&namedK8sClient{cluster: "staging"}, &MockMimirClient{}))
+	clusters.Register("prod-us", NewK8sMimirCollector(&namedK8sClient{cluster: "prod-us"}, &MockMimirClient{}))
+
+	assert.Equal(t, []string{"staging", "prod-us"}, clusters.Clusters())
+	assert.True(t, clusters.HasCluster("prod-us"))
+	assert.False(t, clusters.HasCluster("prod-eu"))
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    string
+		wantErr bool
+	}{
+		{name: "sem seleção usa o cluster padrão", ctx: context.Background(), want: "staging"},
+		{name: "cluster selecionado no contexto", ctx: WithCluster(context.Background(), "prod-us"), want: "prod-us"},
+		{name: "cluster não configurado", ctx: WithCluster(context.Background(), "prod-eu"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := clusters.GetWorkloadConfig(tt.ctx, "default", types.WorkloadKindDeployment, "api")
+			if tt.wantErr {
+				assert.True(t, errors.IsResourceNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, config.ClusterName)
+		})
+	}
+}
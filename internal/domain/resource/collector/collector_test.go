@@ -41,6 +41,43 @@ func (m *MockK8sClient) GetDeploymentConfig(ctx context.Context, namespace, name
 	return config, nil
 }
 
+func (m *MockK8sClient) GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error) {
+	return m.GetDeploymentMetrics(ctx, namespace, name)
+}
+
+func (m *MockK8sClient) GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error) {
+	config, err := m.GetDeploymentConfig(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	config.Kind = kind
+	return config, nil
+}
+
+func (m *MockK8sClient) ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error) {
+	return []types.WorkloadRef{
+		{Kind: types.WorkloadKindDeployment, Namespace: namespace, Name: "api"},
+		{Kind: types.WorkloadKindStatefulSet, Namespace: namespace, Name: "kafka"},
+	}, nil
+}
+
+func (m *MockK8sClient) ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error) {
+	return []types.NamespaceRef{
+		{Name: "default"},
+		{Name: "payments", Labels: map[string]string{"team": "billing"}},
+	}, nil
+}
+
+func (m *MockK8sClient) GetNodeInventory(ctx context.Context) (*types.NodeInventory, error) {
+	return &types.NodeInventory{
+		Nodes: []types.NodeCapacity{{Name: "node-1", Pool: "default"}},
+	}, nil
+}
+
+func (m *MockK8sClient) GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error) {
+	return &types.NamespaceQuotas{Namespace: namespace}, nil
+}
+
 func (m *MockK8sClient) CheckConnection(ctx context.Context) error {
 	return nil
 }
@@ -66,6 +103,25 @@ func (m *MockMimirClient) QueryRange(ctx context.Context, query string, start, e
 	}, nil
 }
 
+func (m *MockMimirClient) QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error) {
+	return &types.SeriesResult{
+		ResultType: types.ResultTypeVector,
+		Series: []types.Series{
+			{Labels: map[string]string{"pod": "test-pod-1"}, Samples: []types.QueryResult{{Value: 42.0, Timestamp: time.Now()}}},
+			{Labels: map[string]string{"pod": "test-pod-2"}, Samples: []types.QueryResult{{Value: 43.0, Timestamp: time.Now()}}},
+		},
+	}, nil
+}
+
+func (m *MockMimirClient) QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error) {
+	return &types.SeriesResult{
+		ResultType: types.ResultTypeMatrix,
+		Series: []types.Series{
+			{Labels: map[string]string{"pod": "test-pod-1"}, Samples: []types.QueryResult{{Value: 42.0, Timestamp: start}}},
+		},
+	}, nil
+}
+
 func (m *MockMimirClient) CheckConnection(ctx context.Context) error {
 	return nil
 }
@@ -167,6 +223,37 @@ func TestK8sMimirCollector_GetDeploymentConfig(t *testing.T) {
 	assert.Equal(t, "test-cluster", config.ClusterName)
 }
 
+func TestK8sMimirCollector_GetWorkloadConfig(t *testing.T) {
+	collector := NewK8sMimirCollector(&MockK8sClient{}, &MockMimirClient{})
+
+	config, err := collector.GetWorkloadConfig(context.Background(), "default", types.WorkloadKindStatefulSet, "test-statefulset")
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkloadKindStatefulSet, config.Kind)
+	assert.Equal(t, float64(200), config.CPU.Request)
+	assert.Equal(t, 3, config.Pods.Replicas)
+}
+
+func TestK8sMimirCollector_ListWorkloads(t *testing.T) {
+	collector := NewK8sMimirCollector(&MockK8sClient{}, &MockMimirClient{})
+
+	workloads, err := collector.ListWorkloads(context.Background(), "default")
+
+	assert.NoError(t, err)
+	assert.Len(t, workloads, 2)
+	assert.Equal(t, types.WorkloadKindStatefulSet, workloads[1].Kind)
+}
+
+func TestK8sMimirCollector_ListNamespaces(t *testing.T) {
+	collector := NewK8sMimirCollector(&MockK8sClient{}, &MockMimirClient{})
+
+	namespaces, err := collector.ListNamespaces(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, namespaces, 2)
+	assert.Equal(t, "billing", namespaces[1].Labels["team"])
+}
+
 func TestK8sMimirCollector_Query(t *testing.T) {
 	collector := NewK8sMimirCollector(&MockK8sClient{}, &MockMimirClient{})
 
@@ -188,3 +275,16 @@ func TestK8sMimirCollector_QueryRange(t *testing.T) {
 	assert.Equal(t, now, result.StartTime)
 	assert.Equal(t, now.Add(time.Hour), result.EndTime)
 }
+
+func TestK8sMimirCollector_QuerySeries(t *testing.T) {
+	collector := NewK8sMimirCollector(&MockK8sClient{}, &MockMimirClient{})
+
+	result, err := collector.QuerySeries(context.Background(), "sum by (pod) (test_query)")
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.ResultTypeVector, result.ResultType)
+	if assert.Len(t, result.Series, 2) {
+		assert.Equal(t, "test-pod-2", result.Series[1].Labels["pod"])
+		assert.Equal(t, 43.0, result.Series[1].Samples[0].Value)
+	}
+}
@@ -46,6 +46,78 @@ type Collector interface {
 	//   - error: Erro em caso de falha na obtenção
 	GetDeploymentConfig(ctx context.Context, namespace, deployment string) (*types.K8sDeploymentConfig, error)
 
+	// GetWorkloadMetrics retorna métricas atuais de um workload de qualquer tipo
+	// suportado (Deployment, StatefulSet, DaemonSet ou ReplicaSet).
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//   - kind: Tipo do workload
+	//   - name: Nome do workload
+	//
+	// Retorna:
+	//   - K8sMetrics: Métricas atuais do workload
+	//   - error: Erro em caso de falha na coleta
+	GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error)
+
+	// GetWorkloadConfig retorna configurações de um workload de qualquer tipo suportado.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//   - kind: Tipo do workload
+	//   - name: Nome do workload
+	//
+	// Retorna:
+	//   - K8sDeploymentConfig: Configurações do workload
+	//   - error: Erro em caso de falha na obtenção
+	GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error)
+
+	// ListWorkloads lista os workloads analisáveis de um namespace.
+	// ReplicaSets gerenciados por Deployments não são retornados.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//
+	// Retorna:
+	//   - []WorkloadRef: Workloads encontrados
+	//   - error: Erro em caso de falha na listagem
+	ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error)
+
+	// ListNamespaces lista os namespaces visíveis para a service account.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//
+	// Retorna:
+	//   - []NamespaceRef: Namespaces encontrados, com seus labels
+	//   - error: Erro em caso de falha na listagem
+	ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error)
+
+	// GetNodeInventory retorna a capacidade alocável, os requests e o uso atual de cada node,
+	// além dos pods agendados em cada um.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//
+	// Retorna:
+	//   - NodeInventory: Nodes e pods agendados
+	//   - error: Erro em caso de falha na coleta
+	GetNodeInventory(ctx context.Context) (*types.NodeInventory, error)
+
+	// GetNamespaceQuotas retorna os ResourceQuotas e LimitRanges de um namespace e os containers
+	// dos workloads sem request explícito, que recebem o request padrão do LimitRange.
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - namespace: Namespace do Kubernetes
+	//
+	// Retorna:
+	//   - NamespaceQuotas: Quotas, LimitRanges e containers dependentes dos padrões
+	//   - error: Erro em caso de falha na coleta
+	GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error)
+
 	// Query executa uma query pontual no sistema de métricas.
 	// Utiliza Prometheus/Mimir para consultas instantâneas.
 	//
@@ -72,4 +144,31 @@ type Collector interface {
 	//   - QueryRangeResult: Série temporal de métricas
 	//   - error: Erro em caso de falha na consulta
 	QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.QueryRangeResult, error)
+
+	// QuerySeries executa uma query pontual e retorna todas as séries do resultado.
+	// Usado em consultas agrupadas por label (ex: by (pod), by (container)).
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - query: Query PromQL
+	//
+	// Retorna:
+	//   - SeriesResult: Séries com os seus labels e amostras
+	//   - error: Erro em caso de falha na consulta
+	QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error)
+
+	// QueryRangeSeries executa uma query com range de tempo e retorna todas as séries do resultado.
+	// Usado em consultas agrupadas por label (ex: by (pod), by (container)).
+	//
+	// Parâmetros:
+	//   - ctx: Contexto da requisição
+	//   - query: Query PromQL
+	//   - start: Início do período
+	//   - end: Fim do período
+	//   - step: Intervalo entre pontos
+	//
+	// Retorna:
+	//   - SeriesResult: Séries temporais com os seus labels
+	//   - error: Erro em caso de falha na consulta
+	QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error)
 }
@@ -12,6 +12,12 @@ import (
 type K8sClient interface {
 	GetDeploymentMetrics(ctx context.Context, namespace, name string) (*types.K8sMetrics, error)
 	GetDeploymentConfig(ctx context.Context, namespace, name string) (*types.K8sDeploymentConfig, error)
+	GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error)
+	GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error)
+	ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error)
+	ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error)
+	GetNodeInventory(ctx context.Context) (*types.NodeInventory, error)
+	GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error)
 	CheckConnection(ctx context.Context) error
 }
 
@@ -19,6 +25,8 @@ type K8sClient interface {
 type MimirClient interface {
 	Query(ctx context.Context, query string) (*types.QueryResult, error)
 	QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.QueryRangeResult, error)
+	QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error)
+	QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error)
 	CheckConnection(ctx context.Context) error
 }
 
@@ -70,6 +78,96 @@ func (c *K8sMimirCollector) GetDeploymentConfig(ctx context.Context, namespace,
 	return config, nil
 }
 
+// GetWorkloadMetrics retorna métricas atuais de um workload
+func (c *K8sMimirCollector) GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error) {
+	logger.Info("Collecting workload metrics",
+		logger.NewField("namespace", namespace),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+	)
+	metrics, err := c.K8sClient.GetWorkloadMetrics(ctx, namespace, kind, name)
+	if err != nil {
+		logger.Error("Failed to collect workload metrics", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// GetWorkloadConfig retorna configurações de um workload
+func (c *K8sMimirCollector) GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error) {
+	logger.Info("Collecting workload configuration",
+		logger.NewField("namespace", namespace),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+	)
+	config, err := c.K8sClient.GetWorkloadConfig(ctx, namespace, kind, name)
+	if err != nil {
+		logger.Error("Failed to collect workload configuration", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		return nil, err
+	}
+	return config, nil
+}
+
+// ListWorkloads lista os workloads de um namespace
+func (c *K8sMimirCollector) ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error) {
+	logger.Info("Listing namespace workloads",
+		logger.NewField("namespace", namespace),
+	)
+	workloads, err := c.K8sClient.ListWorkloads(ctx, namespace)
+	if err != nil {
+		logger.Error("Failed to list namespace workloads", err,
+			logger.NewField("namespace", namespace),
+		)
+		return nil, err
+	}
+	return workloads, nil
+}
+
+// ListNamespaces lista os namespaces do cluster
+func (c *K8sMimirCollector) ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error) {
+	logger.Info("Listing cluster namespaces")
+	namespaces, err := c.K8sClient.ListNamespaces(ctx)
+	if err != nil {
+		logger.Error("Failed to list cluster namespaces", err)
+		return nil, err
+	}
+	return namespaces, nil
+}
+
+// GetNodeInventory retorna a capacidade e os pods agendados em cada node
+func (c *K8sMimirCollector) GetNodeInventory(ctx context.Context) (*types.NodeInventory, error) {
+	logger.Info("Getting node inventory")
+	inventory, err := c.K8sClient.GetNodeInventory(ctx)
+	if err != nil {
+		logger.Error("Failed to get node inventory", err)
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// GetNamespaceQuotas retorna os ResourceQuotas e LimitRanges de um namespace
+func (c *K8sMimirCollector) GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error) {
+	logger.Info("Getting namespace quotas",
+		logger.NewField("namespace", namespace),
+	)
+	quotas, err := c.K8sClient.GetNamespaceQuotas(ctx, namespace)
+	if err != nil {
+		logger.Error("Failed to get namespace quotas", err,
+			logger.NewField("namespace", namespace),
+		)
+		return nil, err
+	}
+	return quotas, nil
+}
+
 // Query executa uma query pontual
 func (c *K8sMimirCollector) Query(ctx context.Context, query string) (*types.QueryResult, error) {
 	logger.Info("Executing instant query",
@@ -102,3 +200,36 @@ func (c *K8sMimirCollector) QueryRange(ctx context.Context, query string, start,
 	}
 	return result, nil
 }
+
+// QuerySeries executa uma query pontual e retorna todas as séries com os seus labels
+func (c *K8sMimirCollector) QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error) {
+	logger.Info("Executing instant series query",
+		logger.NewField("query", query),
+	)
+	result, err := c.MimirClient.QuerySeries(ctx, query)
+	if err != nil {
+		logger.Error("Failed to execute instant series query", err,
+			logger.NewField("query", query),
+		)
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryRangeSeries executa uma query com range de tempo e retorna todas as séries com os seus labels
+func (c *K8sMimirCollector) QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error) {
+	logger.Info("Executing range series query",
+		logger.NewField("query", query),
+		logger.NewField("start", start),
+		logger.NewField("end", end),
+		logger.NewField("step", step),
+	)
+	result, err := c.MimirClient.QueryRangeSeries(ctx, query, start, end, step)
+	if err != nil {
+		logger.Error("Failed to execute range series query", err,
+			logger.NewField("query", query),
+		)
+		return nil, err
+	}
+	return result, nil
+}
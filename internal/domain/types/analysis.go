@@ -31,6 +31,8 @@ type PodAnalysis struct {
 		MinReplicas *Recommendation `json:"minReplicas"`
 		MaxReplicas *Recommendation `json:"maxReplicas"`
 	} `json:"recommendations"`
+	// PDBConflicts são as sugestões de réplicas ajustadas ou sinalizadas pelos PDBs do workload
+	PDBConflicts []PDBConflict `json:"pdbConflicts,omitempty"`
 }
 
 // UtilizationTrend representa a tendência de utilização
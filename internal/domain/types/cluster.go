@@ -0,0 +1,24 @@
+package types
+
+// GroupSummary representa os totais de um grupo de workloads (namespace ou valor de label)
+type GroupSummary struct {
+	Name      string         `json:"name"`
+	Workloads int            `json:"workloads"`
+	CPU       ResourceTotals `json:"cpu"`
+	Memory    ResourceTotals `json:"memory"`
+	Costs     CostSummary    `json:"costs"`
+}
+
+// ClusterReport representa o inventário e showback de todos os namespaces do cluster.
+// Namespaces e Groups são ordenados pela economia mensal potencial (maior primeiro).
+type ClusterReport struct {
+	Cluster      string             `json:"cluster,omitempty"`
+	Period       string             `json:"period"`
+	Currency     string             `json:"currency"`
+	GroupBy      string             `json:"groupBy"`
+	Summary      NamespaceSummary   `json:"summary"`
+	Namespaces   []*GroupSummary    `json:"namespaces"`
+	Groups       []*GroupSummary    `json:"groups"`
+	TopOffenders []*WorkloadSummary `json:"topOffenders"`
+	Errors       []WorkloadError    `json:"errors,omitempty"`
+}
@@ -0,0 +1,64 @@
+package types
+
+// ReplicaResources representa um recurso por réplica
+type ReplicaResources struct {
+	Requested   float64 `json:"requested"`   // em milicores para CPU, Mi para memória
+	Used        float64 `json:"used"`        // em milicores para CPU, Mi para memória
+	Recommended float64 `json:"recommended"` // em milicores para CPU, Mi para memória
+}
+
+// ClusterWorkload representa a análise de um workload em um dos clusters comparados
+type ClusterWorkload struct {
+	Cluster      string           `json:"cluster"`
+	Replicas     int              `json:"replicas"`
+	CPU          ReplicaResources `json:"cpu"`
+	Memory       ReplicaResources `json:"memory"`
+	CPUAction    string           `json:"cpuAction"`
+	MemoryAction string           `json:"memoryAction"`
+	Costs        CostSummary      `json:"costs"`
+	// Difference é a diferença para o cluster de referência (ausente no próprio baseline)
+	Difference *ComparisonDifference `json:"difference,omitempty"`
+}
+
+// ComparisonDifference representa a diferença de um cluster para o baseline (cluster - baseline)
+type ComparisonDifference struct {
+	Replicas int              `json:"replicas"`
+	CPU      ReplicaResources `json:"cpu"`
+	Memory   ReplicaResources `json:"memory"`
+	Costs    CostSummary      `json:"costs"`
+}
+
+// ComparisonFinding sinaliza requests de um cluster dimensionados como os de outro cluster
+// com uso muito maior, como staging com requests copiados de produção
+type ComparisonFinding struct {
+	Type               string  `json:"type"`
+	Cluster            string  `json:"cluster"`
+	Reference          string  `json:"reference"`
+	Resource           string  `json:"resource"` // "cpu" ou "memory"
+	Requested          float64 `json:"requested"`
+	ReferenceRequested float64 `json:"referenceRequested"`
+	Used               float64 `json:"used"`
+	ReferenceUsed      float64 `json:"referenceUsed"`
+	Suggested          float64 `json:"suggested"`
+	Message            string  `json:"message"`
+}
+
+// ClusterError representa a falha na análise do workload em um cluster
+type ClusterError struct {
+	Cluster string `json:"cluster"`
+	Error   string `json:"error"`
+}
+
+// ClusterComparison representa o mesmo workload analisado lado a lado em dois ou mais clusters.
+// Valores de CPU e memória são por réplica e custos são totais mensais de todas as réplicas.
+type ClusterComparison struct {
+	Namespace string              `json:"namespace"`
+	Kind      WorkloadKind        `json:"kind"`
+	Name      string              `json:"name"`
+	Period    string              `json:"period"`
+	Currency  string              `json:"currency"`
+	Baseline  string              `json:"baseline"`
+	Clusters  []*ClusterWorkload  `json:"clusters"`
+	Findings  []ComparisonFinding `json:"findings,omitempty"`
+	Errors    []ClusterError      `json:"errors,omitempty"`
+}
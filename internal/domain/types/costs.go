@@ -2,11 +2,46 @@ package types
 
 // CostAnalysis representa a análise de custos
 type CostAnalysis struct {
-	Current     *CostData      `json:"current"`
-	Recommended *CostData      `json:"recommended"`
+	Current     *CostData        `json:"current"`
+	Recommended *CostData        `json:"recommended"`
+	Savings     *ResourceCosts   `json:"savings"`
+	Containers  []*ContainerCost `json:"containers"`
+	// Projection é o custo e o risco do par request/limit recomendado, quando há detalhe por container
+	Projection *RequestLimitProjection `json:"projection,omitempty"`
+	Currency   string                  `json:"currency"`
+	Exchange   *ExchangeInfo           `json:"exchange"`
+}
+
+// Níveis de risco do par request/limit recomendado
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// RequestLimitProjection representa o custo mensal por pod e o risco do par request/limit recomendado
+type RequestLimitProjection struct {
+	QoSClass string `json:"qosClass"`
+	// Request é o custo dos requests recomendados, reservado no node
+	Request *ResourceCosts `json:"request"`
+	// Limit é o custo caso os containers usem todo o limit recomendado; containers sem limit entram com o request
+	Limit *ResourceCosts `json:"limit"`
+	// Overcommit é a razão limit/request do pod por recurso (0 quando algum container não tem limit)
+	Overcommit struct {
+		CPU    float64 `json:"cpu"`
+		Memory float64 `json:"memory"`
+	} `json:"overcommit"`
+	Risk string `json:"risk"` // low, medium, high
+	// RiskFactors lista os motivos do risco (oom_history, cpu_throttling, memory_unbounded, memory_overcommit)
+	RiskFactors []string `json:"riskFactors,omitempty"`
+}
+
+// ContainerCost representa os custos mensais por pod de um container
+type ContainerCost struct {
+	Name        string         `json:"name"`
+	Current     *ResourceCosts `json:"current"`
+	Recommended *ResourceCosts `json:"recommended"`
 	Savings     *ResourceCosts `json:"savings"`
-	Currency    string         `json:"currency"`
-	Exchange    *ExchangeInfo  `json:"exchange"`
 }
 
 // CostData representa dados de custo
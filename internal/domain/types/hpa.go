@@ -0,0 +1,175 @@
+package types
+
+// HPAConfig representa um HorizontalPodAutoscaler (autoscaling/v2) associado a um workload
+type HPAConfig struct {
+	Name            string         `json:"name"`
+	MinReplicas     int            `json:"minReplicas"`
+	MaxReplicas     int            `json:"maxReplicas"`
+	CurrentReplicas int            `json:"currentReplicas"`
+	DesiredReplicas int            `json:"desiredReplicas"`
+	Metrics         []HPAMetric    `json:"metrics"`
+	Behavior        *HPABehavior   `json:"behavior,omitempty"`
+	Conditions      []HPACondition `json:"conditions,omitempty"`
+	// ScaledObject é o ScaledObject do KEDA que gerou o HPA, quando houver
+	ScaledObject string `json:"scaledObject,omitempty"`
+}
+
+// Tipos de métrica suportados pelo HPA (spec.metrics[].type)
+const (
+	HPAMetricResource          = "Resource"
+	HPAMetricContainerResource = "ContainerResource"
+	HPAMetricPods              = "Pods"
+	HPAMetricObject            = "Object"
+	HPAMetricExternal          = "External"
+)
+
+// Tipos de alvo de uma métrica do HPA (spec.metrics[].*.target.type)
+const (
+	HPATargetUtilization  = "Utilization"
+	HPATargetValue        = "Value"
+	HPATargetAverageValue = "AverageValue"
+)
+
+// HPAMetric representa uma métrica usada pelo HPA, com o alvo configurado e o valor atual reportado no status
+type HPAMetric struct {
+	Type string `json:"type"`
+	// Name é o recurso (cpu, memory) para Resource e ContainerResource, ou o nome da métrica para os demais tipos
+	Name string `json:"name"`
+	// Container é o container avaliado em métricas ContainerResource
+	Container string `json:"container,omitempty"`
+	// Selector é o seletor de labels da métrica, no formato de label selector
+	Selector string `json:"selector,omitempty"`
+	// Object é o objeto descrito por métricas do tipo Object
+	Object  *HPAObjectReference `json:"object,omitempty"`
+	Target  HPAMetricValue      `json:"target"`
+	Current *HPAMetricValue     `json:"current,omitempty"`
+}
+
+// HPAObjectReference identifica o objeto Kubernetes de uma métrica Object
+type HPAObjectReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+// HPAMetricValue representa o alvo ou o valor atual de uma métrica.
+// Value e AverageValue são quantities do Kubernetes (ex: "500m", "1Gi", "100").
+type HPAMetricValue struct {
+	Type               string `json:"type,omitempty"`
+	AverageUtilization *int32 `json:"averageUtilization,omitempty"` // em percentual do request
+	Value              string `json:"value,omitempty"`
+	AverageValue       string `json:"averageValue,omitempty"`
+}
+
+// HPABehavior representa as políticas de escala do HPA (spec.behavior)
+type HPABehavior struct {
+	ScaleUp   *HPAScalingRules `json:"scaleUp,omitempty"`
+	ScaleDown *HPAScalingRules `json:"scaleDown,omitempty"`
+}
+
+// HPAScalingRules representa as regras de escala em uma direção
+type HPAScalingRules struct {
+	StabilizationWindowSeconds *int32             `json:"stabilizationWindowSeconds,omitempty"`
+	SelectPolicy               string             `json:"selectPolicy,omitempty"`
+	Policies                   []HPAScalingPolicy `json:"policies,omitempty"`
+}
+
+// HPAScalingPolicy representa uma política de escala (Pods ou Percent por período)
+type HPAScalingPolicy struct {
+	Type          string `json:"type"`
+	Value         int32  `json:"value"`
+	PeriodSeconds int32  `json:"periodSeconds"`
+}
+
+// HPACondition representa uma condição do status do HPA (AbleToScale, ScalingActive, ScalingLimited)
+type HPACondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// ResourceUtilizationTarget retorna o alvo de utilização (percentual do request) configurado
+// para um recurso do pod (cpu ou memory), ou 0 quando não há alvo de utilização
+func (h *HPAConfig) ResourceUtilizationTarget(resource string) int32 {
+	if h == nil {
+		return 0
+	}
+	for _, metric := range h.Metrics {
+		if metric.Type == HPAMetricResource && metric.Name == resource &&
+			metric.Target.Type == HPATargetUtilization && metric.Target.AverageUtilization != nil {
+			return *metric.Target.AverageUtilization
+		}
+	}
+	return 0
+}
+
+// HPASettings representa a configuração de escala avaliada no replay do HPA
+type HPASettings struct {
+	TargetCPU   int32   `json:"targetCPUUtilization"` // em percentual do request
+	MinReplicas int     `json:"minReplicas"`
+	MaxReplicas int     `json:"maxReplicas"`
+	CPURequest  float64 `json:"cpuRequest"` // por pod, em milicores
+}
+
+// HPAReplayPoint representa um instante do replay do HPA
+type HPAReplayPoint struct {
+	Timestamp   int64   `json:"timestamp"`   // unix, em segundos
+	Usage       float64 `json:"usage"`       // uso total de CPU, em milicores
+	Replicas    int     `json:"replicas"`    // réplicas projetadas
+	Utilization float64 `json:"utilization"` // utilização projetada, em percentual do request
+}
+
+// HPAReplay representa o resultado do replay do histórico de uso contra o algoritmo do HPA
+type HPAReplay struct {
+	Settings           HPASettings `json:"settings"`
+	PeakUtilization    float64     `json:"peakUtilization"`    // em percentual
+	AverageUtilization float64     `json:"averageUtilization"` // em percentual
+	AverageReplicas    float64     `json:"averageReplicas"`
+	ReplicaHours       float64     `json:"replicaHours"`
+	// TimeAtMaxSeconds é o tempo em que o workload ficou no máximo de réplicas
+	TimeAtMaxSeconds int64            `json:"timeAtMaxSeconds"`
+	Series           []HPAReplayPoint `json:"series,omitempty"`
+}
+
+// HPARecommendation representa a recomendação de target e limites do HPA obtida pelo replay
+// do histórico. Suggested mantém o pico de utilização projetado abaixo de UtilizationCeiling
+// com o menor número de réplicas-hora. Status "proposed" indica que o workload não escala por
+// utilização de CPU e a sugestão não altera as réplicas recomendadas.
+type HPARecommendation struct {
+	Status             string     `json:"status"`
+	UtilizationCeiling float64    `json:"utilizationCeiling"` // em percentual
+	Current            *HPAReplay `json:"current,omitempty"`
+	Suggested          *HPAReplay `json:"suggested,omitempty"`
+}
+
+// HPASimulationInput representa a configuração proposta avaliada no simulador do HPA
+type HPASimulationInput struct {
+	Settings HPASettings
+	// MemoryRequest é o request de memória proposto por pod, em Mi (0 mantém o atual)
+	MemoryRequest float64
+	// Behavior são as políticas de escala propostas (nil mantém as do HPA atual)
+	Behavior *HPABehavior
+}
+
+// HPASimulationCosts representa os custos mensais projetados pelo simulador do HPA
+type HPASimulationCosts struct {
+	Current  *ResourceCosts `json:"current"`
+	Proposed *ResourceCosts `json:"proposed"`
+	Savings  *ResourceCosts `json:"savings"`
+}
+
+// HPASimulation representa o replay do histórico de um workload com a configuração atual e
+// com a configuração proposta. Sem HPA de CPU, o replay atual mantém as réplicas fixas.
+type HPASimulation struct {
+	Namespace string             `json:"namespace"`
+	Kind      WorkloadKind       `json:"kind"`
+	Name      string             `json:"name"`
+	Period    string             `json:"period"`
+	Step      int64              `json:"step"` // em segundos
+	Currency  string             `json:"currency"`
+	Current   *HPAReplay         `json:"current"`
+	Proposed  *HPAReplay         `json:"proposed"`
+	Costs     HPASimulationCosts `json:"costs"`
+}
@@ -1,6 +1,53 @@
 package types
 
-// K8sMetrics representa métricas do Kubernetes
+import "strings"
+
+// WorkloadKind representa o tipo de controlador Kubernetes analisado
+type WorkloadKind string
+
+const (
+	// WorkloadKindDeployment representa um Deployment (apps/v1)
+	WorkloadKindDeployment WorkloadKind = "Deployment"
+	// WorkloadKindStatefulSet representa um StatefulSet (apps/v1)
+	WorkloadKindStatefulSet WorkloadKind = "StatefulSet"
+	// WorkloadKindDaemonSet representa um DaemonSet (apps/v1)
+	WorkloadKindDaemonSet WorkloadKind = "DaemonSet"
+	// WorkloadKindReplicaSet representa um ReplicaSet (apps/v1)
+	WorkloadKindReplicaSet WorkloadKind = "ReplicaSet"
+)
+
+// ParseWorkloadKind converte o nome usado nas rotas (ex: "deployments", "statefulset", "ds")
+// para o WorkloadKind correspondente
+func ParseWorkloadKind(value string) (WorkloadKind, bool) {
+	switch strings.ToLower(value) {
+	case "deployment", "deployments", "deploy":
+		return WorkloadKindDeployment, true
+	case "statefulset", "statefulsets", "sts":
+		return WorkloadKindStatefulSet, true
+	case "daemonset", "daemonsets", "ds":
+		return WorkloadKindDaemonSet, true
+	case "replicaset", "replicasets", "rs":
+		return WorkloadKindReplicaSet, true
+	}
+	return "", false
+}
+
+// WorkloadRef identifica um workload em um namespace
+type WorkloadRef struct {
+	Kind      WorkloadKind      `json:"kind"`
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// NamespaceRef identifica um namespace do cluster
+type NamespaceRef struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// K8sMetrics representa métricas do Kubernetes.
+// CPU e Memory são agregados por pod (soma dos containers); o detalhe por container fica em Containers.
 type K8sMetrics struct {
 	CPU struct {
 		Usage       float64 `json:"usage"`       // em milicores
@@ -18,11 +65,31 @@ type K8sMetrics struct {
 		Running     int     `json:"running"`
 		Utilization float64 `json:"utilization"` // em percentual
 	} `json:"pods"`
+	Containers []ContainerUsage `json:"containers"`
+	// Stability contém reinícios, últimas terminações e evictions dos pods do workload
+	Stability WorkloadStability `json:"stability"`
+}
+
+// ContainerUsage representa o uso atual de um container, agregado entre os pods do workload
+type ContainerUsage struct {
+	Name string `json:"name"`
+	CPU  struct {
+		Usage   float64 `json:"usage"`   // soma entre os pods, em milicores
+		Average float64 `json:"average"` // média por pod, em milicores
+		Peak    float64 `json:"peak"`    // maior valor em um pod, em milicores
+	} `json:"cpu"`
+	Memory struct {
+		Usage   float64 `json:"usage"`   // soma entre os pods, em Mi
+		Average float64 `json:"average"` // média por pod, em Mi
+		Peak    float64 `json:"peak"`    // maior valor em um pod, em Mi
+	} `json:"memory"`
 }
 
-// K8sDeploymentConfig representa configuração de um deployment
+// K8sDeploymentConfig representa configuração de um workload (Deployment, StatefulSet, DaemonSet ou ReplicaSet).
+// CPU e Memory são o total do pod (soma dos containers); o detalhe por container fica em Containers.
 type K8sDeploymentConfig struct {
-	CPU struct {
+	Kind WorkloadKind `json:"kind"`
+	CPU  struct {
 		Request float64 `json:"request"` // em milicores
 		Limit   float64 `json:"limit"`   // em milicores
 	} `json:"cpu"`
@@ -34,7 +101,29 @@ type K8sDeploymentConfig struct {
 		Replicas    int     `json:"replicas"`
 		MinReplicas int     `json:"minReplicas"`
 		MaxReplicas int     `json:"maxReplicas"`
-		TargetCPU   float64 `json:"targetCPU"` // em percentual
+		TargetCPU   float64 `json:"targetCPU"` // fração do request (0.8 = 80%)
 	} `json:"pods"`
-	ClusterName string `json:"clusterName"`
+	// HPA é o HorizontalPodAutoscaler que escala o workload, se existir
+	HPA *HPAConfig `json:"hpa,omitempty"`
+	// VPA é o VerticalPodAutoscaler que tem o workload como alvo, se existir
+	VPA *VPAConfig `json:"vpa,omitempty"`
+	// KEDA é o ScaledObject do KEDA que escala o workload, se existir
+	KEDA *KEDAScaledObject `json:"keda,omitempty"`
+	// PDBs são os PodDisruptionBudgets que cobrem os pods do workload
+	PDBs        []PDBConfig       `json:"pdbs,omitempty"`
+	Containers  []ContainerConfig `json:"containers"`
+	ClusterName string            `json:"clusterName"`
+}
+
+// ContainerConfig representa requests e limits de um container do pod template
+type ContainerConfig struct {
+	Name string `json:"name"`
+	CPU  struct {
+		Request float64 `json:"request"` // em milicores
+		Limit   float64 `json:"limit"`   // em milicores
+	} `json:"cpu"`
+	Memory struct {
+		Request float64 `json:"request"` // em Mi
+		Limit   float64 `json:"limit"`   // em Mi
+	} `json:"memory"`
 }
@@ -0,0 +1,40 @@
+package types
+
+// KEDAScaledObject representa um ScaledObject do KEDA (keda.sh/v1alpha1) que escala o workload.
+// O KEDA gera um HPA oculto a partir do ScaledObject; os limites de réplicas efetivos são os do ScaledObject.
+type KEDAScaledObject struct {
+	Name        string `json:"name"`
+	MinReplicas int    `json:"minReplicas"`
+	MaxReplicas int    `json:"maxReplicas"`
+	// IdleReplicas é o número de réplicas quando não há eventos, se configurado
+	IdleReplicas    *int  `json:"idleReplicas,omitempty"`
+	PollingInterval int32 `json:"pollingInterval"` // em segundos
+	CooldownPeriod  int32 `json:"cooldownPeriod"`  // em segundos
+	// HPAName é o nome do HPA gerado pelo KEDA
+	HPAName  string        `json:"hpaName"`
+	Paused   bool          `json:"paused,omitempty"`
+	Triggers []KEDATrigger `json:"triggers"`
+}
+
+// KEDATrigger representa um gatilho de escala de um ScaledObject (ex: kafka, rabbitmq, cpu)
+type KEDATrigger struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	// MetricType é o tipo de alvo do HPA gerado (AverageValue, Value ou Utilization)
+	MetricType string            `json:"metricType,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// EventDriven indica se o ScaledObject escala por eventos externos (filas, streams, etc.),
+// e não apenas por uso de CPU e memória
+func (k *KEDAScaledObject) EventDriven() bool {
+	if k == nil {
+		return false
+	}
+	for _, trigger := range k.Triggers {
+		if trigger.Type != "cpu" && trigger.Type != "memory" {
+			return true
+		}
+	}
+	return false
+}
@@ -32,6 +32,7 @@ type PodMetrics struct {
 // CurrentMetrics representa métricas atuais
 type CurrentMetrics struct {
 	Deployment struct {
+		Kind   WorkloadKind `json:"kind"`
 		Config struct {
 			CPU struct {
 				Request float64 `json:"request"` // em milicores
@@ -45,7 +46,15 @@ type CurrentMetrics struct {
 				MinReplicas int     `json:"minReplicas"`
 				MaxReplicas int     `json:"maxReplicas"`
 				TargetCPU   float64 `json:"targetCPU"` // em percentual
+				// Spec é o HPA completo (métricas, behavior e condições), quando existir
+				Spec *HPAConfig `json:"spec,omitempty"`
 			} `json:"hpa"`
+			// VPA é o VerticalPodAutoscaler do workload, quando existir
+			VPA *VPAConfig `json:"vpa,omitempty"`
+			// KEDA é o ScaledObject do workload, quando existir
+			KEDA *KEDAScaledObject `json:"keda,omitempty"`
+			// PDBs são os PodDisruptionBudgets que cobrem os pods do workload
+			PDBs []PDBConfig `json:"pdbs,omitempty"`
 		} `json:"config"`
 	} `json:"deployment"`
 	Analysis struct {
@@ -80,9 +89,47 @@ type CurrentMetrics struct {
 			} `json:"usage"`
 		} `json:"memory"`
 	} `json:"analysis"`
-	CPU    *ResourceMetrics `json:"cpu"`
-	Memory *ResourceMetrics `json:"memory"`
-	Pods   *PodMetrics      `json:"pods"`
+	CPU        *ResourceMetrics    `json:"cpu"`
+	Memory     *ResourceMetrics    `json:"memory"`
+	Pods       *PodMetrics         `json:"pods"`
+	Containers []*ContainerMetrics `json:"containers"`
+	// Stability contém reinícios, OOMKills e evictions dos pods do workload
+	Stability *WorkloadStability `json:"stability,omitempty"`
+}
+
+// UsageStats representa média e pico de uso de um recurso
+type UsageStats struct {
+	Average float64 `json:"average"`
+	Peak    float64 `json:"peak"`
+}
+
+// ContainerResourceMetrics representa configuração e uso de um recurso em um container
+type ContainerResourceMetrics struct {
+	Request float64 `json:"request"` // em milicores para CPU, Mi para memória
+	Limit   float64 `json:"limit"`   // em milicores para CPU, Mi para memória
+	Usage   struct {
+		Current    UsageStats `json:"current"`    // por pod
+		Historical UsageStats `json:"historical"` // por pod
+	} `json:"usage"`
+}
+
+// ContainerMetrics representa configuração e uso de um container do workload
+type ContainerMetrics struct {
+	Name     string                   `json:"name"`
+	CPU      ContainerResourceMetrics `json:"cpu"`
+	Memory   ContainerResourceMetrics `json:"memory"`
+	Restarts int32                    `json:"restarts"`
+	// OOMKilled indica se o container foi encerrado por OOMKilled no período analisado
+	OOMKilled       bool                  `json:"oomKilled"`
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"`
+	// CPUThrottling é o percentual de períodos do CFS em que o container foi limitado pelo limit de CPU
+	CPUThrottling float64 `json:"cpuThrottling"`
+}
+
+// UsageSample representa uma amostra de uma série temporal de uso
+type UsageSample struct {
+	Timestamp int64   `json:"timestamp"` // unix, em segundos
+	Value     float64 `json:"value"`
 }
 
 // HistoricalMetrics representa métricas históricas
@@ -90,6 +137,10 @@ type HistoricalMetrics struct {
 	CPU    []*ResourceMetrics `json:"cpu"`
 	Memory []*ResourceMetrics `json:"memory"`
 	Pods   []*PodMetrics      `json:"pods"`
+	// CPUUsage é a série do uso total de CPU do workload (soma dos pods), em milicores
+	CPUUsage []UsageSample `json:"cpuUsage,omitempty"`
+	// Step é o intervalo entre as amostras das séries, em segundos
+	Step int64 `json:"step,omitempty"`
 }
 
 // TrendsResponse representa a resposta com tendências
@@ -99,7 +150,7 @@ type TrendsResponse struct {
 	Pods   *TrendMetrics `json:"pods"`
 }
 
-// MetricsResponse representa a resposta com métricas de um deployment
+// MetricsResponse representa a resposta com métricas de um workload
 type MetricsResponse struct {
 	Current    *CurrentMetrics                 `json:"current"`
 	Historical *HistoricalMetrics              `json:"historical"`
@@ -110,6 +161,8 @@ type MetricsResponse struct {
 			Timestamp  string   `json:"timestamp"`
 			Period     string   `json:"period"`
 			Cluster    string   `json:"cluster"`
+			Kind       string   `json:"kind"`
+			Workload   string   `json:"workload"`
 			Sources    []string `json:"sources"`
 			Confidence struct {
 				CPU    float64 `json:"cpu"`
@@ -181,6 +234,8 @@ type ResourceRecommendation struct {
 type PodRecommendation struct {
 	Status         string    `json:"status"`
 	Recommendation *PodCount `json:"recommendation,omitempty"`
+	// ScalingSource é a origem da escala horizontal do workload (hpa ou keda), quando houver
+	ScalingSource string `json:"scalingSource,omitempty"`
 }
 
 // PodCount representa a contagem de pods
@@ -198,9 +253,40 @@ type ResourceSuggestion struct {
 	Action    string  `json:"action"`
 }
 
-// ResourceRecommendationAnalysis representa a análise completa dos recursos
-type ResourceRecommendationAnalysis struct {
+// ContainerRecommendation representa as recomendações de recursos de um container
+type ContainerRecommendation struct {
+	Name   string                  `json:"name"`
 	CPU    *ResourceRecommendation `json:"cpu"`
 	Memory *ResourceRecommendation `json:"memory"`
-	Pods   *PodRecommendation      `json:"pods"`
+	// CPULimit e MemoryLimit são as recomendações de limit, presentes quando o container tem limit configurado
+	CPULimit    *ResourceRecommendation `json:"cpuLimit,omitempty"`
+	MemoryLimit *ResourceRecommendation `json:"memoryLimit,omitempty"`
+}
+
+// Classes de QoS de um pod no Kubernetes
+const (
+	QoSClassGuaranteed = "Guaranteed"
+	QoSClassBurstable  = "Burstable"
+	QoSClassBestEffort = "BestEffort"
+)
+
+// QoSRecommendation representa a classe de QoS do pod com os recursos atuais e com os recomendados
+type QoSRecommendation struct {
+	Current   string `json:"current"`
+	Suggested string `json:"suggested"`
+}
+
+// ResourceRecommendationAnalysis representa a análise completa dos recursos.
+// CPU e Memory são o total do pod; quando há detalhe por container, o total
+// é a soma das recomendações de cada container.
+type ResourceRecommendationAnalysis struct {
+	CPU        *ResourceRecommendation    `json:"cpu"`
+	Memory     *ResourceRecommendation    `json:"memory"`
+	Pods       *PodRecommendation         `json:"pods"`
+	HPA        *HPARecommendation         `json:"hpa,omitempty"`
+	VPA        *VPAComparison             `json:"vpa,omitempty"`
+	QoS        *QoSRecommendation         `json:"qos,omitempty"`
+	Containers []*ContainerRecommendation `json:"containers"`
+	// PDBConflicts são as sugestões de réplicas ajustadas ou sinalizadas pelos PDBs do workload
+	PDBConflicts []PDBConflict `json:"pdbConflicts,omitempty"`
 }
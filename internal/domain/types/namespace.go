@@ -0,0 +1,55 @@
+package types
+
+// ResourceTotals representa totais de um recurso somando todas as réplicas
+type ResourceTotals struct {
+	Requested   float64 `json:"requested"`   // em milicores para CPU, Mi para memória
+	Used        float64 `json:"used"`        // em milicores para CPU, Mi para memória
+	Recommended float64 `json:"recommended"` // em milicores para CPU, Mi para memória
+}
+
+// CostSummary representa custos mensais totais (todas as réplicas)
+type CostSummary struct {
+	CurrentMonthly     float64 `json:"currentMonthly"`
+	RecommendedMonthly float64 `json:"recommendedMonthly"`
+	SavingsMonthly     float64 `json:"savingsMonthly"`
+}
+
+// WorkloadSummary representa o resultado resumido da análise de um workload
+type WorkloadSummary struct {
+	WorkloadRef
+	Replicas int            `json:"replicas"`
+	CPU      ResourceTotals `json:"cpu"`
+	Memory   ResourceTotals `json:"memory"`
+	Costs    CostSummary    `json:"costs"`
+}
+
+// WorkloadError representa uma falha na análise de um workload.
+// Falhas ao listar os workloads de um namespace não possuem Kind e Name.
+type WorkloadError struct {
+	Namespace string       `json:"namespace,omitempty"`
+	Kind      WorkloadKind `json:"kind,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	Error     string       `json:"error"`
+}
+
+// NamespaceSummary representa os totais agregados de um namespace
+type NamespaceSummary struct {
+	Workloads int            `json:"workloads"`
+	Analyzed  int            `json:"analyzed"`
+	Failed    int            `json:"failed"`
+	CPU       ResourceTotals `json:"cpu"`
+	Memory    ResourceTotals `json:"memory"`
+	Costs     CostSummary    `json:"costs"`
+}
+
+// NamespaceAnalysis representa a análise de todos os workloads de um namespace,
+// ordenados pela economia mensal potencial (maior primeiro)
+type NamespaceAnalysis struct {
+	Cluster   string             `json:"cluster,omitempty"`
+	Namespace string             `json:"namespace"`
+	Period    string             `json:"period"`
+	Currency  string             `json:"currency"`
+	Summary   NamespaceSummary   `json:"summary"`
+	Workloads []*WorkloadSummary `json:"workloads"`
+	Errors    []WorkloadError    `json:"errors,omitempty"`
+}
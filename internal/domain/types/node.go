@@ -0,0 +1,91 @@
+package types
+
+// NodeResource representa a capacidade e o consumo de um recurso em um node ou node pool.
+// Valores em milicores para CPU e Mi para memória.
+type NodeResource struct {
+	Allocatable float64 `json:"allocatable"`
+	Requested   float64 `json:"requested"`
+	// Used é o uso atual reportado pela metrics API
+	Used float64 `json:"used"`
+	// AverageUsed é o uso médio no período, obtido do Mimir
+	AverageUsed float64 `json:"averageUsed"`
+	// Unallocated é a capacidade não requisitada por nenhum pod (allocatable - requested)
+	Unallocated float64 `json:"unallocated"`
+	// Idle é a capacidade requisitada mas não usada (requested - used)
+	Idle float64 `json:"idle"`
+	// Stranded é a capacidade livre que não pode ser agendada porque o outro recurso
+	// do node já está proporcionalmente mais comprometido
+	Stranded float64 `json:"stranded"`
+}
+
+// NodeCapacity representa a capacidade e o consumo de um node
+type NodeCapacity struct {
+	Name         string       `json:"name"`
+	Pool         string       `json:"pool"`
+	InstanceType string       `json:"instanceType,omitempty"`
+	Schedulable  bool         `json:"schedulable"`
+	Pods         int          `json:"pods"`
+	CPU          NodeResource `json:"cpu"`
+	Memory       NodeResource `json:"memory"`
+}
+
+// NodePoolCapacity representa a capacidade e o consumo agregados de um node pool
+type NodePoolCapacity struct {
+	Name   string       `json:"name"`
+	Nodes  int          `json:"nodes"`
+	Pods   int          `json:"pods"`
+	CPU    NodeResource `json:"cpu"`
+	Memory NodeResource `json:"memory"`
+}
+
+// NodePod representa os requests de um pod agendado em um node
+type NodePod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Node      string `json:"node"`
+	// Owner é o workload que controla o pod; nil para pods sem controlador suportado (ex: Jobs)
+	Owner         *WorkloadRef `json:"owner,omitempty"`
+	CPURequest    float64      `json:"cpuRequest"`    // em milicores
+	MemoryRequest float64      `json:"memoryRequest"` // em Mi
+}
+
+// NodeInventory representa os nodes do cluster e os pods agendados neles
+type NodeInventory struct {
+	Nodes []NodeCapacity `json:"nodes"`
+	Pods  []NodePod      `json:"pods"`
+}
+
+// PoolBinPacking representa o resultado da simulação de bin-packing de um node pool
+type PoolBinPacking struct {
+	Pool           string  `json:"pool"`
+	CurrentNodes   int     `json:"currentNodes"`
+	RequiredNodes  int     `json:"requiredNodes"`
+	RemovableNodes int     `json:"removableNodes"`
+	ReleasedCPU    float64 `json:"releasedCPU"`    // allocatable dos nodes removíveis, em milicores
+	ReleasedMemory float64 `json:"releasedMemory"` // allocatable dos nodes removíveis, em Mi
+	MonthlySavings float64 `json:"monthlySavings"`
+	// UnplacedPods conta pods que não cabem em nenhum node do pool
+	UnplacedPods int `json:"unplacedPods,omitempty"`
+}
+
+// BinPackingSimulation representa quantos nodes seriam necessários se todos os workloads
+// adotassem os requests recomendados
+type BinPackingSimulation struct {
+	CurrentNodes   int               `json:"currentNodes"`
+	RequiredNodes  int               `json:"requiredNodes"`
+	RemovableNodes int               `json:"removableNodes"`
+	MonthlySavings float64           `json:"monthlySavings"`
+	Pools          []*PoolBinPacking `json:"pools"`
+}
+
+// NodeAnalysis representa a análise de capacidade dos nodes do cluster
+type NodeAnalysis struct {
+	Cluster    string                `json:"cluster,omitempty"`
+	Period     string                `json:"period"`
+	Currency   string                `json:"currency"`
+	Summary    NodePoolCapacity      `json:"summary"`
+	Pools      []*NodePoolCapacity   `json:"pools"`
+	Nodes      []NodeCapacity        `json:"nodes"`
+	Simulation *BinPackingSimulation `json:"simulation"`
+	Errors     []WorkloadError       `json:"errors,omitempty"`
+}
@@ -0,0 +1,27 @@
+package types
+
+// PDBConfig representa um PodDisruptionBudget que cobre os pods do workload
+type PDBConfig struct {
+	Name           string `json:"name"`
+	MinAvailable   string `json:"minAvailable,omitempty"`   // número ou percentual (ex: "2", "50%")
+	MaxUnavailable string `json:"maxUnavailable,omitempty"` // número ou percentual
+	// RequiredReplicas é o menor número de réplicas que permite ao menos uma interrupção
+	// voluntária (ex: drain de node)
+	RequiredReplicas int `json:"requiredReplicas"`
+	// BlocksDisruptions indica que o PDB não permite interrupções com nenhum número de
+	// réplicas (ex: maxUnavailable 0 ou minAvailable 100%)
+	BlocksDisruptions bool `json:"blocksDisruptions,omitempty"`
+}
+
+// PDBConflict representa uma sugestão de réplicas que bloquearia interrupções voluntárias
+// por causa de um PodDisruptionBudget
+type PDBConflict struct {
+	PDB            string `json:"pdb"`
+	Recommendation string `json:"recommendation"` // ex: "minReplicas", "replicas", "hpa.minReplicas"
+	Suggested      int    `json:"suggested"`
+	Required       int    `json:"required"`
+	// Adjusted é a sugestão após a validação contra todos os PDBs do workload. PDBs que
+	// bloqueiam interrupções com qualquer número de réplicas não alteram a sugestão.
+	Adjusted int    `json:"adjusted"`
+	Message  string `json:"message"`
+}
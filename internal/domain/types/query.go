@@ -6,6 +6,9 @@ import "time"
 type QueryResult struct {
 	Value     float64
 	Timestamp time.Time
+	// Missing indica uma amostra sem valor utilizável: NaN (inclusive stale markers), ±Inf ou
+	// query sem resultado. Value mantém o valor original e não deve entrar em estatísticas.
+	Missing bool
 }
 
 // QueryRangeResult representa o resultado de uma query com range
@@ -14,3 +17,26 @@ type QueryRangeResult struct {
 	StartTime time.Time
 	EndTime   time.Time
 }
+
+// Tipos de resultado das queries do Prometheus/Mimir
+const (
+	ResultTypeVector = "vector"
+	ResultTypeMatrix = "matrix"
+	ResultTypeScalar = "scalar"
+	ResultTypeString = "string"
+)
+
+// Series representa uma série retornada por uma query, identificada pelos seus labels. Vetores
+// têm uma amostra por série e matrizes a série temporal completa. Escalares e strings têm uma
+// única série sem labels; o valor de uma string fica em Text.
+type Series struct {
+	Labels  map[string]string
+	Samples []QueryResult
+	Text    string
+}
+
+// SeriesResult representa todas as séries retornadas por uma query
+type SeriesResult struct {
+	ResultType string
+	Series     []Series
+}
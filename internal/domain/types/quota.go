@@ -0,0 +1,99 @@
+package types
+
+// QuotaResource representa um recurso limitado por um ResourceQuota.
+// CPU em milicores, memória em Mi e contagens (ex: pods) em unidades.
+type QuotaResource struct {
+	Resource string  `json:"resource"` // ex: requests.cpu, limits.memory, pods
+	Hard     float64 `json:"hard"`
+	Used     float64 `json:"used"`
+}
+
+// ResourceQuotaConfig representa um ResourceQuota do namespace
+type ResourceQuotaConfig struct {
+	Name      string          `json:"name"`
+	Resources []QuotaResource `json:"resources"`
+}
+
+// LimitRangeValues representa os valores de um LimitRange para containers
+// (em milicores para CPU, Mi para memória; 0 quando não definido)
+type LimitRangeValues struct {
+	DefaultRequest float64 `json:"defaultRequest"`
+	DefaultLimit   float64 `json:"defaultLimit"`
+	Min            float64 `json:"min"`
+	Max            float64 `json:"max"`
+}
+
+// LimitRangeConfig representa os limites de containers de um LimitRange do namespace
+type LimitRangeConfig struct {
+	Name   string           `json:"name"`
+	CPU    LimitRangeValues `json:"cpu"`
+	Memory LimitRangeValues `json:"memory"`
+}
+
+// DefaultedContainer representa um container de workload sem request explícito, que recebe
+// o request padrão do LimitRange na admissão dos pods
+type DefaultedContainer struct {
+	Workload  WorkloadRef `json:"workload"`
+	Container string      `json:"container"`
+	CPU       bool        `json:"cpu"`    // sem request nem limit de CPU
+	Memory    bool        `json:"memory"` // sem request nem limit de memória
+}
+
+// NamespaceQuotas representa os ResourceQuotas e LimitRanges de um namespace e os containers
+// que dependem dos valores padrão do LimitRange
+type NamespaceQuotas struct {
+	Namespace           string                `json:"namespace"`
+	ResourceQuotas      []ResourceQuotaConfig `json:"resourceQuotas"`
+	LimitRanges         []LimitRangeConfig    `json:"limitRanges"`
+	DefaultedContainers []DefaultedContainer  `json:"defaultedContainers"`
+}
+
+// QuotaUsage representa a folga de um recurso de um ResourceQuota e o tamanho recomendado
+// a partir do uso histórico agregado do namespace
+type QuotaUsage struct {
+	Quota           string  `json:"quota"`
+	Resource        string  `json:"resource"`
+	Hard            float64 `json:"hard"`
+	Used            float64 `json:"used"`
+	Headroom        float64 `json:"headroom"`
+	HeadroomPercent float64 `json:"headroomPercent"`
+	// PeakUsage é o valor máximo contabilizado pela quota no período: a soma dos requests ou o
+	// número de pods (0 quando não há recomendação)
+	PeakUsage   float64 `json:"peakUsage,omitempty"`
+	Recommended float64 `json:"recommended,omitempty"`
+	Action      string  `json:"action,omitempty"`
+}
+
+// DefaultedContainerUsage representa o uso histórico por pod de um container sem request explícito
+type DefaultedContainerUsage struct {
+	Workload  WorkloadRef `json:"workload"`
+	Container string      `json:"container"`
+	Usage     UsageStats  `json:"usage"`
+	Suggested float64     `json:"suggested"`
+	Action    string      `json:"action"`
+}
+
+// LimitRangeDefaultAnalysis avalia o request padrão de um LimitRange contra o uso dos containers
+// que dependem dele
+type LimitRangeDefaultAnalysis struct {
+	LimitRange       string                    `json:"limitRange"`
+	Resource         string                    `json:"resource"` // "cpu" ou "memory"
+	DefaultRequest   float64                   `json:"defaultRequest"`
+	Containers       int                       `json:"containers"`
+	Overprovisioned  int                       `json:"overprovisioned"`
+	Underprovisioned int                       `json:"underprovisioned"`
+	SuggestedDefault float64                   `json:"suggestedDefault,omitempty"`
+	Status           string                    `json:"status"` // "ok", "overprovisioned" ou "underprovisioned"
+	Message          string                    `json:"message,omitempty"`
+	Details          []DefaultedContainerUsage `json:"details"`
+}
+
+// QuotaAnalysis representa a análise dos ResourceQuotas e LimitRanges de um namespace
+type QuotaAnalysis struct {
+	Cluster     string                      `json:"cluster,omitempty"`
+	Namespace   string                      `json:"namespace"`
+	Period      string                      `json:"period"`
+	Quotas      []QuotaUsage                `json:"quotas"`
+	LimitRanges []LimitRangeDefaultAnalysis `json:"limitRanges"`
+	Errors      []WorkloadError             `json:"errors,omitempty"`
+}
@@ -0,0 +1,41 @@
+package types
+
+// Motivos de terminação de containers e de pods relevantes para as recomendações
+const (
+	TerminationReasonOOMKilled = "OOMKilled"
+	TerminationReasonError     = "Error"
+	PodReasonEvicted           = "Evicted"
+)
+
+// ContainerTermination representa a última terminação de um container
+type ContainerTermination struct {
+	Pod        string `json:"pod"`
+	Reason     string `json:"reason"` // OOMKilled, Error, Completed...
+	ExitCode   int32  `json:"exitCode"`
+	FinishedAt string `json:"finishedAt,omitempty"` // RFC3339
+}
+
+// ContainerStability representa reinícios e terminações de um container, agregados entre os pods
+type ContainerStability struct {
+	Name     string `json:"name"`
+	Restarts int32  `json:"restarts"`
+	// OOMKills é o número de pods cuja última terminação do container foi OOMKilled
+	OOMKills        int                   `json:"oomKills"`
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"`
+}
+
+// PodEviction representa a remoção de um pod pelo kubelet (pressão de recursos no node)
+type PodEviction struct {
+	Pod       string `json:"pod"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"` // RFC3339
+}
+
+// WorkloadStability representa o histórico de reinícios, OOMKills e evictions dos pods de um workload
+type WorkloadStability struct {
+	Restarts   int32                `json:"restarts"`
+	OOMKills   int                  `json:"oomKills"`
+	Containers []ContainerStability `json:"containers,omitempty"`
+	Evictions  []PodEviction        `json:"evictions,omitempty"`
+}
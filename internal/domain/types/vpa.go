@@ -0,0 +1,53 @@
+package types
+
+// VPAResources representa valores de CPU e memória de uma recomendação do VPA
+type VPAResources struct {
+	CPU    float64 `json:"cpu"`    // em milicores
+	Memory float64 `json:"memory"` // em Mi
+}
+
+// VPAContainerRecommendation representa a recomendação do VPA para um container
+type VPAContainerRecommendation struct {
+	Container      string       `json:"container"`
+	Target         VPAResources `json:"target"`
+	LowerBound     VPAResources `json:"lowerBound"`
+	UpperBound     VPAResources `json:"upperBound"`
+	UncappedTarget VPAResources `json:"uncappedTarget"`
+}
+
+// VPAConfig representa um VerticalPodAutoscaler (autoscaling.k8s.io/v1) associado a um workload
+type VPAConfig struct {
+	Name string `json:"name"`
+	// UpdateMode é o modo de atualização do VPA (Off, Initial, Recreate, Auto)
+	UpdateMode string                       `json:"updateMode"`
+	Containers []VPAContainerRecommendation `json:"containers"`
+}
+
+// VPAResourceComparison compara a recomendação do analisador com a do VPA para um recurso
+type VPAResourceComparison struct {
+	Analyzer   float64 `json:"analyzer"`
+	Target     float64 `json:"target"`
+	LowerBound float64 `json:"lowerBound"`
+	UpperBound float64 `json:"upperBound"`
+	// WithinBounds indica se a recomendação do analisador está entre lowerBound e upperBound
+	WithinBounds bool `json:"withinBounds"`
+	// Divergence é a diferença relativa entre o analisador e o target do VPA (0 a 100)
+	Divergence float64 `json:"divergence"`
+}
+
+// VPAContainerComparison compara as recomendações de um container
+type VPAContainerComparison struct {
+	Container string                `json:"container"`
+	CPU       VPAResourceComparison `json:"cpu"`
+	Memory    VPAResourceComparison `json:"memory"`
+}
+
+// VPAComparison representa a recomendação do VPA ao lado da recomendação do analisador.
+// DivergenceScore é a média das divergências de CPU e memória dos containers (0 a 100).
+type VPAComparison struct {
+	Name            string                       `json:"name"`
+	UpdateMode      string                       `json:"updateMode"`
+	Recommendation  []VPAContainerRecommendation `json:"recommendation"`
+	Containers      []VPAContainerComparison     `json:"containers"`
+	DivergenceScore float64                      `json:"divergenceScore"`
+}
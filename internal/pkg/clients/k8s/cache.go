@@ -0,0 +1,290 @@
+package k8s
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// DefaultCacheResync é o intervalo padrão de resincronização dos informers
+const DefaultCacheResync = 10 * time.Minute
+
+// Estados do cache de informers
+const (
+	// CacheDisabled indica que o cache não foi iniciado e as leituras vão direto à API
+	CacheDisabled = "disabled"
+	// CacheSyncing indica que a sincronização inicial não terminou e as leituras vão direto à API
+	CacheSyncing = "syncing"
+	// CacheSynced indica que as leituras vêm do cache
+	CacheSynced = "synced"
+)
+
+// informerCache mantém informers compartilhados de Deployments, ReplicaSets, Pods, HPAs e Nodes.
+// Depois da sincronização inicial, as leituras do Client vêm dos listers em memória,
+// sem chamadas Get/List na API a cada análise. Até lá (sincronização lenta ou RBAC sem acesso
+// ao cluster inteiro), as leituras vão direto à API.
+type informerCache struct {
+	factory     informers.SharedInformerFactory
+	deployments appslisters.DeploymentLister
+	replicaSets appslisters.ReplicaSetLister
+	pods        corelisters.PodLister
+	hpas        autoscalinglisters.HorizontalPodAutoscalerLister
+	nodes       corelisters.NodeLister
+	hasSynced   []toolscache.InformerSynced
+
+	started atomic.Bool
+	synced  chan struct{}
+	// syncErr é a última falha de list/watch dos informers
+	syncErr atomic.Pointer[error]
+}
+
+// newInformerCache registra os informers na factory; nada é lido da API até start
+func newInformerCache(clientset kubernetes.Interface, resync time.Duration) *informerCache {
+	if resync <= 0 {
+		resync = DefaultCacheResync
+	}
+	factory := informers.NewSharedInformerFactory(clientset, resync)
+
+	deployments := factory.Apps().V1().Deployments()
+	replicaSets := factory.Apps().V1().ReplicaSets()
+	pods := factory.Core().V1().Pods()
+	hpas := factory.Autoscaling().V2().HorizontalPodAutoscalers()
+	nodes := factory.Core().V1().Nodes()
+
+	cache := &informerCache{
+		factory:     factory,
+		deployments: deployments.Lister(),
+		replicaSets: replicaSets.Lister(),
+		pods:        pods.Lister(),
+		hpas:        hpas.Lister(),
+		nodes:       nodes.Lister(),
+		hasSynced: []toolscache.InformerSynced{
+			deployments.Informer().HasSynced,
+			replicaSets.Informer().HasSynced,
+			pods.Informer().HasSynced,
+			hpas.Informer().HasSynced,
+			nodes.Informer().HasSynced,
+		},
+		synced: make(chan struct{}),
+	}
+
+	for _, informer := range []toolscache.SharedIndexInformer{
+		deployments.Informer(), replicaSets.Informer(), pods.Informer(), hpas.Informer(), nodes.Informer(),
+	} {
+		_ = informer.SetWatchErrorHandler(cache.watchError)
+	}
+	return cache
+}
+
+// watchError registra a falha de list/watch de um informer (ex: RBAC sem permissão de listar o
+// recurso em todos os namespaces) e mantém o tratamento padrão do client-go
+func (c *informerCache) watchError(r *toolscache.Reflector, err error) {
+	c.syncErr.Store(&err)
+	if !c.ready() {
+		logger.Warn("Falha na sincronização do cache de informers; leituras direto na API",
+			logger.NewField("error", err.Error()),
+		)
+	}
+	toolscache.DefaultWatchErrorHandler(r, err)
+}
+
+// start inicia os informers e aguarda a sincronização inicial em segundo plano.
+// Os informers param quando o contexto é cancelado.
+func (c *informerCache) start(ctx context.Context) {
+	if !c.started.CompareAndSwap(false, true) {
+		return
+	}
+
+	logger.Info("Iniciando cache de informers")
+	c.factory.Start(ctx.Done())
+	go func() {
+		startedAt := time.Now()
+		if !toolscache.WaitForCacheSync(ctx.Done(), c.hasSynced...) {
+			logger.Warn("Cache de informers encerrado antes da sincronização")
+			return
+		}
+		close(c.synced)
+		logger.Info("Cache de informers sincronizado",
+			logger.NewField("duration", time.Since(startedAt).String()),
+		)
+	}()
+}
+
+// ready indica se a sincronização inicial foi concluída
+func (c *informerCache) ready() bool {
+	select {
+	case <-c.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// StartCache inicia o cache de informers do cliente. Até a sincronização inicial, e sem
+// StartCache, as leituras vão direto à API.
+func (c *Client) StartCache(ctx context.Context) {
+	if c.cache != nil {
+		c.cache.start(ctx)
+	}
+}
+
+// CacheReady indica se o cache de informers foi iniciado e sincronizado
+func (c *Client) CacheReady() bool {
+	return c.cache != nil && c.cache.started.Load() && c.cache.ready()
+}
+
+// CacheState retorna o estado do cache de informers (disabled, syncing ou synced) e, durante a
+// sincronização, a última falha de list/watch
+func (c *Client) CacheState() (string, error) {
+	switch {
+	case c.cache == nil || !c.cache.started.Load():
+		return CacheDisabled, nil
+	case c.cache.ready():
+		return CacheSynced, nil
+	}
+	if err := c.cache.syncErr.Load(); err != nil {
+		return CacheSyncing, *err
+	}
+	return CacheSyncing, nil
+}
+
+// informers retorna o cache quando sincronizado, ou nil para que a leitura vá direto à API
+func (c *Client) informers() *informerCache {
+	if !c.CacheReady() {
+		return nil
+	}
+	return c.cache
+}
+
+// getDeployment obtém um Deployment do cache ou, sem cache, da API
+func (c *Client) getDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	cache := c.informers()
+	if cache == nil {
+		return c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return cache.deployments.Deployments(namespace).Get(name)
+}
+
+// listDeployments lista os Deployments de um namespace do cache ou, sem cache, da API
+func (c *Client) listDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
+	cache := c.informers()
+	if cache == nil {
+		list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	items, err := cache.deployments.Deployments(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return derefAll(items), nil
+}
+
+// listReplicaSets lista os ReplicaSets de um namespace que atendem ao selector, do cache ou, sem cache, da API
+func (c *Client) listReplicaSets(ctx context.Context, namespace string, selector labels.Selector) ([]appsv1.ReplicaSet, error) {
+	cache := c.informers()
+	if cache == nil {
+		list, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	items, err := cache.replicaSets.ReplicaSets(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return derefAll(items), nil
+}
+
+// listPods lista os pods de um namespace (ou de todos, com metav1.NamespaceAll) que
+// atendem ao selector, do cache ou, sem cache, da API
+func (c *Client) listPods(ctx context.Context, namespace string, selector labels.Selector) ([]corev1.Pod, error) {
+	cache := c.informers()
+	if cache == nil {
+		list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	items, err := cache.pods.Pods(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return derefAll(items), nil
+}
+
+// getHPA obtém um HPA do cache ou, sem cache, da API
+func (c *Client) getHPA(ctx context.Context, namespace, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	cache := c.informers()
+	if cache == nil {
+		return c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return cache.hpas.HorizontalPodAutoscalers(namespace).Get(name)
+}
+
+// listHPAs lista os HPAs de um namespace do cache ou, sem cache, da API
+func (c *Client) listHPAs(ctx context.Context, namespace string) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
+	cache := c.informers()
+	if cache == nil {
+		list, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	items, err := cache.hpas.HorizontalPodAutoscalers(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return derefAll(items), nil
+}
+
+// listNodes lista os nodes do cluster do cache ou, sem cache, da API
+func (c *Client) listNodes(ctx context.Context) ([]corev1.Node, error) {
+	cache := c.informers()
+	if cache == nil {
+		list, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	items, err := cache.nodes.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return derefAll(items), nil
+}
+
+// derefAll copia os objetos de um lister para uma slice de valores.
+// Os objetos do cache são compartilhados e não devem ser alterados; a cópia é rasa,
+// então os chamadores continuam apenas lendo os campos.
+func derefAll[T any](items []*T) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, *item)
+	}
+	return result
+}
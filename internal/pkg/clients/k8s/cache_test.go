@@ -0,0 +1,124 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newCacheFixture cria um clientset fake com um Deployment, seus pods, um HPA e um node
+func newCacheFixture() *fake.Clientset {
+	podWithLabels := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	return fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			},
+		},
+		podWithLabels("api-1", map[string]string{"app": "api"}),
+		podWithLabels("api-2", map[string]string{"app": "api"}),
+		podWithLabels("worker-1", map[string]string{"app": "worker"}),
+		&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "api"},
+				MaxReplicas:    5,
+			},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	)
+}
+
+func TestInformerCache(t *testing.T) {
+	clientset := newCacheFixture()
+	client := &Client{clientset: clientset, cache: newInformerCache(clientset, 0)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.StartCache(ctx)
+	assert.Eventually(t, client.CacheReady, 5*time.Second, 10*time.Millisecond)
+
+	// Após a sincronização, as leituras não geram chamadas Get/List na API
+	actions := len(clientset.Actions())
+
+	wl, err := client.getWorkload(ctx, "default", types.WorkloadKindDeployment, "api")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "api"}, wl.Selector.MatchLabels)
+
+	pods, err := client.listPods(ctx, "default", labels.SelectorFromSet(wl.Selector.MatchLabels))
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+
+	hpa, err := client.findHPA(ctx, "default", types.WorkloadKindDeployment, "api")
+	assert.NoError(t, err)
+	if assert.NotNil(t, hpa) {
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	}
+
+	nodes, err := client.listNodes(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 1)
+
+	_, err = client.getWorkload(ctx, "default", types.WorkloadKindDeployment, "missing")
+	assert.True(t, errors.IsResourceNotFound(err))
+
+	assert.Equal(t, actions, len(clientset.Actions()))
+}
+
+func TestInformerCache_ReadinessGating(t *testing.T) {
+	clientset := newCacheFixture()
+
+	t.Run("sem StartCache as leituras vão direto à API", func(t *testing.T) {
+		client := &Client{clientset: clientset, cache: newInformerCache(clientset, 0)}
+
+		pods, err := client.listPods(context.Background(), "default", labels.Everything())
+
+		assert.NoError(t, err)
+		assert.Len(t, pods, 3)
+		assert.False(t, client.CacheReady())
+	})
+
+	t.Run("cache sem permissão lê da API e reporta a falha", func(t *testing.T) {
+		clientset := newCacheFixture()
+		// RBAC restrito ao namespace: listar nodes no cluster inteiro é negado
+		clientset.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(corev1.Resource("nodes"), "", fmt.Errorf("service account restrita ao namespace"))
+		})
+		client := &Client{clientset: clientset, cache: newInformerCache(clientset, 0)}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		client.StartCache(ctx)
+
+		assert.Eventually(t, func() bool {
+			state, err := client.CacheState()
+			return state == CacheSyncing && apierrors.IsForbidden(err)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		wl, err := client.getWorkload(ctx, "default", types.WorkloadKindDeployment, "api")
+		assert.NoError(t, err)
+		assert.Equal(t, "api", wl.Name)
+		pods, err := client.listPods(ctx, "default", labels.Everything())
+		assert.NoError(t, err)
+		assert.Len(t, pods, 3)
+	})
+}
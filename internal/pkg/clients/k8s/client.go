@@ -7,7 +7,10 @@ import (
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -29,14 +32,26 @@ type PodMetrics struct {
 
 // Client implementa a interface metrics.K8sClient
 type Client struct {
-	clientset     *kubernetes.Clientset
-	metricsClient *metricsv1beta1.Clientset
+	clientset     kubernetes.Interface
+	metricsClient metricsv1beta1.Interface
+	// dynamicClient lê CRDs como o VerticalPodAutoscaler
+	dynamicClient dynamic.Interface
+	// cache mantém Deployments, ReplicaSets, Pods, HPAs e Nodes em memória, após StartCache
+	cache *informerCache
+	// clusterName é o nome do cluster reportado nas configurações dos workloads
+	clusterName string
 }
 
 // ClientConfig contém as configurações para o cliente Kubernetes
 type ClientConfig struct {
+	// ClusterName identifica o cluster nas respostas
+	ClusterName    string
 	KubeconfigPath string
-	InCluster      bool
+	// Context é o contexto do kubeconfig usado; vazio usa o contexto atual
+	Context   string
+	InCluster bool
+	// CacheResync é o intervalo de resincronização dos informers (padrão DefaultCacheResync)
+	CacheResync time.Duration
 }
 
 // NewClient cria uma nova instância do cliente Kubernetes
@@ -45,8 +60,10 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 	var err error
 
 	logger.Info("Criando cliente Kubernetes",
+		logger.NewField("cluster", cfg.ClusterName),
 		logger.NewField("in_cluster", cfg.InCluster),
 		logger.NewField("kubeconfig_path", cfg.KubeconfigPath),
+		logger.NewField("context", cfg.Context),
 	)
 
 	if cfg.InCluster {
@@ -56,10 +73,14 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 			return nil, errors.NewInvalidConfigurationError("kubernetes", "erro ao criar configuração in-cluster")
 		}
 	} else {
-		config, err = clientcmd.BuildConfigFromFlags("", cfg.KubeconfigPath)
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: cfg.KubeconfigPath},
+			&clientcmd.ConfigOverrides{CurrentContext: cfg.Context},
+		).ClientConfig()
 		if err != nil {
 			logger.Error("Erro ao criar configuração a partir do kubeconfig", err,
 				logger.NewField("kubeconfig_path", cfg.KubeconfigPath),
+				logger.NewField("context", cfg.Context),
 			)
 			return nil, errors.NewInvalidConfigurationError("kubernetes", "erro ao criar configuração a partir do kubeconfig")
 		}
@@ -77,99 +98,124 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 		return nil, errors.NewInvalidConfigurationError("kubernetes", "erro ao criar metrics client")
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		logger.Error("Erro ao criar dynamic client", err)
+		return nil, errors.NewInvalidConfigurationError("kubernetes", "erro ao criar dynamic client")
+	}
+
 	logger.Info("Cliente Kubernetes criado com sucesso")
 	return &Client{
 		clientset:     clientset,
 		metricsClient: metricsClient,
+		dynamicClient: dynamicClient,
+		cache:         newInformerCache(clientset, cfg.CacheResync),
+		clusterName:   cfg.ClusterName,
 	}, nil
 }
 
 // GetDeploymentMetrics retorna as métricas atuais de um deployment
 func (c *Client) GetDeploymentMetrics(ctx context.Context, namespace, name string) (*types.K8sMetrics, error) {
-	logger.Info("Obtendo métricas do deployment",
+	return c.GetWorkloadMetrics(ctx, namespace, types.WorkloadKindDeployment, name)
+}
+
+// GetWorkloadMetrics retorna as métricas atuais de um workload de qualquer tipo suportado
+func (c *Client) GetWorkloadMetrics(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sMetrics, error) {
+	logger.Info("Obtendo métricas do workload",
 		logger.NewField("namespace", namespace),
-		logger.NewField("deployment", name),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 	)
 
-	// Obtém os pods do deployment
-	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	// Obtém o workload
+	wl, err := c.getWorkload(ctx, namespace, kind, name)
 	if err != nil {
-		logger.Error("Erro ao obter deployment", err,
-			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", name),
-		)
-		return nil, errors.NewResourceNotFoundError("deployment", "erro ao obter deployment")
+		return nil, err
 	}
-	logger.Info("Deployment encontrado",
-		logger.NewField("name", deployment.Name),
-	)
 
-	// Obtém os pods usando o selector do deployment
-	selector := deployment.Spec.Selector.MatchLabels
+	// Obtém os pods usando o selector completo do workload
+	podSelector, err := wl.selector()
+	if err != nil {
+		return nil, err
+	}
 	logger.Info("Listando pods",
-		logger.NewField("selector", selector),
+		logger.NewField("selector", podSelector.String()),
 	)
 
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: selector}),
-	})
+	pods, err := c.listPods(ctx, namespace, podSelector)
 	if err != nil {
 		logger.Error("Erro ao listar pods", err,
 			logger.NewField("namespace", namespace),
-			logger.NewField("selector", selector),
+			logger.NewField("selector", podSelector.String()),
 		)
+		if errors.IsUnavailableMetrics(err) {
+			return nil, err
+		}
 		return nil, errors.NewResourceNotFoundError("pods", "erro ao listar pods")
 	}
+
+	// O selector pode abranger pods de outros controladores; mantém apenas os do workload
+	owners, err := c.resolvePodOwners(ctx, wl, podSelector)
+	if err != nil {
+		logger.Error("Erro ao resolver os owners dos pods", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("name", name),
+		)
+		if errors.IsUnavailableMetrics(err) {
+			return nil, err
+		}
+		return nil, errors.NewResourceNotFoundError("replicasets", "erro ao listar replicasets")
+	}
+	pods = owners.filter(pods)
 	logger.Info("Pods encontrados",
-		logger.NewField("count", len(pods.Items)),
+		logger.NewField("count", len(pods)),
 	)
 
 	// Inicializa as métricas
-	result := &types.K8sMetrics{
-		CPU: struct {
-			Usage       float64 `json:"usage"`
-			Average     float64 `json:"average"`
-			Peak        float64 `json:"peak"`
-			Utilization float64 `json:"utilization"`
-		}{},
-		Memory: struct {
-			Usage       float64 `json:"usage"`
-			Average     float64 `json:"average"`
-			Peak        float64 `json:"peak"`
-			Utilization float64 `json:"utilization"`
-		}{},
-		Pods: struct {
-			Running     int     `json:"running"`
-			Utilization float64 `json:"utilization"`
-		}{},
+	result := &types.K8sMetrics{}
+
+	// Reinícios, terminações e evictions consideram pods em qualquer fase
+	result.Stability = podStability(pods)
+	if err := c.collectEvictionEvents(ctx, namespace, pods, &result.Stability); err != nil {
+		logger.Error("Erro ao listar eventos de eviction", err,
+			logger.NewField("namespace", namespace),
+		)
 	}
 	var totalCPUUsage, peakCPUUsage, totalMemoryUsage, peakMemoryUsage float64
 	runningPods := 0
 
-	// Coleta métricas de cada pod
-	for _, pod := range pods.Items {
+	// Uso agregado por container, na ordem em que os containers aparecem
+	containers := make(map[string]*types.ContainerUsage)
+	var containerOrder []string
+
+	// Apenas pods em execução têm métricas atuais
+	var running []corev1.Pod
+	for _, pod := range pods {
 		logger.Info("Verificando pod",
 			logger.NewField("name", pod.Name),
 			logger.NewField("status", pod.Status.Phase),
 		)
-		if pod.Status.Phase != "Running" {
-			continue
+		if pod.Status.Phase == corev1.PodRunning {
+			running = append(running, pod)
 		}
-		runningPods++
+	}
+	runningPods = len(running)
 
-		// Obtém métricas do pod
-		podMetrics, err := c.metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
-		if err != nil {
-			logger.Error("Erro ao obter métricas do pod", err,
-				logger.NewField("pod", pod.Name),
-			)
+	// Obtém as métricas de todos os pods de uma vez
+	podMetricsByName := c.getPodMetrics(ctx, namespace, podSelector, running)
+	logger.Info("Métricas obtidas para os pods",
+		logger.NewField("count", len(podMetricsByName)),
+	)
+
+	// Coleta métricas de cada pod
+	for _, pod := range running {
+		podMetrics, ok := podMetricsByName[pod.Name]
+		if !ok {
 			continue
 		}
-		logger.Info("Métricas obtidas para o pod",
-			logger.NewField("pod", pod.Name),
-		)
 
 		// Soma métricas de todos os containers do pod
+		var podCPUUsage, podMemoryUsage float64
 		for _, container := range podMetrics.Containers {
 			cpuUsage := float64(container.Usage.Cpu().MilliValue())                  // Já está em milicores
 			memoryUsage := float64(container.Usage.Memory().Value()) / (1024 * 1024) // Converte para Mi
@@ -180,38 +226,71 @@ func (c *Client) GetDeploymentMetrics(ctx context.Context, namespace, name strin
 				logger.NewField("memory_usage", memoryUsage),
 			)
 
-			totalCPUUsage += cpuUsage
-			totalMemoryUsage += memoryUsage
+			podCPUUsage += cpuUsage
+			podMemoryUsage += memoryUsage
 
-			if cpuUsage > peakCPUUsage {
-				peakCPUUsage = cpuUsage
+			usage, ok := containers[container.Name]
+			if !ok {
+				usage = &types.ContainerUsage{Name: container.Name}
+				containers[container.Name] = usage
+				containerOrder = append(containerOrder, container.Name)
 			}
-			if memoryUsage > peakMemoryUsage {
-				peakMemoryUsage = memoryUsage
+			usage.CPU.Usage += cpuUsage
+			usage.Memory.Usage += memoryUsage
+			if cpuUsage > usage.CPU.Peak {
+				usage.CPU.Peak = cpuUsage
+			}
+			if memoryUsage > usage.Memory.Peak {
+				usage.Memory.Peak = memoryUsage
 			}
 		}
+
+		// O pico do pod considera a soma dos seus containers
+		totalCPUUsage += podCPUUsage
+		totalMemoryUsage += podMemoryUsage
+		if podCPUUsage > peakCPUUsage {
+			peakCPUUsage = podCPUUsage
+		}
+		if podMemoryUsage > peakMemoryUsage {
+			peakMemoryUsage = podMemoryUsage
+		}
 	}
 
 	// Calcula médias e utilização
 	if runningPods > 0 {
+		statusReplicas := wl.StatusReplicas
+		if statusReplicas == 0 {
+			statusReplicas = runningPods
+		}
+
 		result.CPU.Usage = totalCPUUsage
 		result.CPU.Average = totalCPUUsage / float64(runningPods)
 		result.CPU.Peak = peakCPUUsage
-		result.CPU.Utilization = totalCPUUsage / float64(deployment.Status.Replicas) * 100
+		result.CPU.Utilization = totalCPUUsage / float64(statusReplicas) * 100
 
 		result.Memory.Usage = totalMemoryUsage
 		result.Memory.Average = totalMemoryUsage / float64(runningPods)
 		result.Memory.Peak = peakMemoryUsage
-		result.Memory.Utilization = totalMemoryUsage / float64(deployment.Status.Replicas) * 100
+		result.Memory.Utilization = totalMemoryUsage / float64(statusReplicas) * 100
 
 		result.Pods.Running = runningPods
-		result.Pods.Utilization = float64(runningPods) / float64(deployment.Status.Replicas) * 100
+		result.Pods.Utilization = float64(runningPods) / float64(statusReplicas) * 100
+
+		for _, containerName := range containerOrder {
+			usage := containers[containerName]
+			usage.CPU.Average = usage.CPU.Usage / float64(runningPods)
+			usage.Memory.Average = usage.Memory.Usage / float64(runningPods)
+			result.Containers = append(result.Containers, *usage)
+		}
 	}
 
 	logger.Info("Métricas coletadas com sucesso",
 		logger.NewField("cpu_usage", result.CPU.Usage),
 		logger.NewField("memory_usage", result.Memory.Usage),
 		logger.NewField("running_pods", result.Pods.Running),
+		logger.NewField("restarts", result.Stability.Restarts),
+		logger.NewField("oom_kills", result.Stability.OOMKills),
+		logger.NewField("evictions", len(result.Stability.Evictions)),
 	)
 
 	return result, nil
@@ -219,82 +298,169 @@ func (c *Client) GetDeploymentMetrics(ctx context.Context, namespace, name strin
 
 // GetDeploymentConfig retorna a configuração de um deployment
 func (c *Client) GetDeploymentConfig(ctx context.Context, namespace, name string) (*types.K8sDeploymentConfig, error) {
-	logger.Info("Obtendo configuração do deployment",
+	return c.GetWorkloadConfig(ctx, namespace, types.WorkloadKindDeployment, name)
+}
+
+// GetWorkloadConfig retorna a configuração de um workload de qualquer tipo suportado
+func (c *Client) GetWorkloadConfig(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.K8sDeploymentConfig, error) {
+	logger.Info("Obtendo configuração do workload",
 		logger.NewField("namespace", namespace),
-		logger.NewField("deployment", name),
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
 	)
 
-	// Obtém o deployment
-	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	// Obtém o workload
+	wl, err := c.getWorkload(ctx, namespace, kind, name)
 	if err != nil {
-		logger.Error("Erro ao obter deployment", err,
-			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", name),
-		)
-		return nil, errors.NewResourceNotFoundError("deployment", "erro ao obter deployment")
+		return nil, err
 	}
 
-	// Obtém o HPA, se existir
-	hpa, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
-	if err != nil {
-		logger.Info("HPA não encontrado",
-			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", name),
-		)
-		hpa = nil
-	} else {
-		logger.Info("HPA encontrado",
+	// Obtém o HPA que escala o workload, se existir (DaemonSets não podem ser escalados por HPA)
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	if kind != types.WorkloadKindDaemonSet {
+		hpa, err = c.findHPA(ctx, namespace, kind, name)
+		if err != nil {
+			logger.Error("Erro ao listar HPAs", err,
+				logger.NewField("namespace", namespace),
+			)
+			hpa = nil
+		} else if hpa == nil {
+			logger.Info("HPA não encontrado",
+				logger.NewField("namespace", namespace),
+				logger.NewField("name", name),
+			)
+		} else {
+			logger.Info("HPA encontrado",
+				logger.NewField("namespace", namespace),
+				logger.NewField("name", name),
+				logger.NewField("hpa", hpa.Name),
+			)
+		}
+	}
+
+	// Obtém o ScaledObject do KEDA que escala o workload, se existir
+	var scaledObject *types.KEDAScaledObject
+	if kind != types.WorkloadKindDaemonSet {
+		scaledObject, err = c.findScaledObject(ctx, namespace, kind, name)
+		if err != nil {
+			logger.Error("Erro ao listar ScaledObjects", err,
+				logger.NewField("namespace", namespace),
+			)
+			scaledObject = nil
+		}
+	}
+	if scaledObject != nil {
+		logger.Info("ScaledObject encontrado",
 			logger.NewField("namespace", namespace),
-			logger.NewField("deployment", name),
+			logger.NewField("name", name),
+			logger.NewField("scaled_object", scaledObject.Name),
+			logger.NewField("hpa", scaledObject.HPAName),
 		)
+		// Usa o HPA gerado pelo KEDA, mesmo quando outro HPA aponta para o workload
+		if hpa == nil || hpa.Name != scaledObject.HPAName {
+			generated, err := c.getHPA(ctx, namespace, scaledObject.HPAName)
+			if err != nil {
+				logger.Warn("HPA gerado pelo KEDA não encontrado",
+					logger.NewField("namespace", namespace),
+					logger.NewField("hpa", scaledObject.HPAName),
+				)
+			} else {
+				hpa = generated
+			}
+		}
 	}
 
-	result := &types.K8sDeploymentConfig{}
+	result := &types.K8sDeploymentConfig{Kind: kind, ClusterName: c.clusterName}
 
-	// Obtém requests e limits do primeiro container
-	if len(deployment.Spec.Template.Spec.Containers) > 0 {
-		container := deployment.Spec.Template.Spec.Containers[0]
+	// Obtém requests e limits de cada container; o total do pod é a soma dos containers
+	for _, container := range wl.Template.Spec.Containers {
+		containerConfig := types.ContainerConfig{Name: container.Name}
 
 		// CPU
 		if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-			result.CPU.Request = float64(cpu.MilliValue()) // Já está em milicores
+			containerConfig.CPU.Request = float64(cpu.MilliValue()) // Já está em milicores
 		}
 		if cpu := container.Resources.Limits.Cpu(); cpu != nil {
-			result.CPU.Limit = float64(cpu.MilliValue()) // Já está em milicores
+			containerConfig.CPU.Limit = float64(cpu.MilliValue()) // Já está em milicores
 		}
 
 		// Memória
 		if memory := container.Resources.Requests.Memory(); memory != nil {
-			result.Memory.Request = float64(memory.Value()) / (1024 * 1024) // Converte bytes para Mi
+			containerConfig.Memory.Request = float64(memory.Value()) / (1024 * 1024) // Converte bytes para Mi
 		}
 		if memory := container.Resources.Limits.Memory(); memory != nil {
-			result.Memory.Limit = float64(memory.Value()) / (1024 * 1024) // Converte bytes para Mi
+			containerConfig.Memory.Limit = float64(memory.Value()) / (1024 * 1024) // Converte bytes para Mi
 		}
 
 		logger.Info("Recursos do container",
-			logger.NewField("cpu_request", result.CPU.Request),
-			logger.NewField("cpu_limit", result.CPU.Limit),
-			logger.NewField("memory_request", result.Memory.Request),
-			logger.NewField("memory_limit", result.Memory.Limit),
+			logger.NewField("container", container.Name),
+			logger.NewField("cpu_request", containerConfig.CPU.Request),
+			logger.NewField("cpu_limit", containerConfig.CPU.Limit),
+			logger.NewField("memory_request", containerConfig.Memory.Request),
+			logger.NewField("memory_limit", containerConfig.Memory.Limit),
 		)
+
+		result.CPU.Request += containerConfig.CPU.Request
+		result.CPU.Limit += containerConfig.CPU.Limit
+		result.Memory.Request += containerConfig.Memory.Request
+		result.Memory.Limit += containerConfig.Memory.Limit
+		result.Containers = append(result.Containers, containerConfig)
 	}
 
 	// Configuração de pods
-	result.Pods.Replicas = int(*deployment.Spec.Replicas)
+	result.Pods.Replicas = wl.Replicas
 
 	if hpa != nil {
-		result.Pods.MinReplicas = int(*hpa.Spec.MinReplicas)
-		result.Pods.MaxReplicas = int(hpa.Spec.MaxReplicas)
+		result.HPA = parseHPA(hpa)
+		result.Pods.MinReplicas = result.HPA.MinReplicas
+		result.Pods.MaxReplicas = result.HPA.MaxReplicas
+		result.Pods.TargetCPU = float64(result.HPA.ResourceUtilizationTarget("cpu")) / 100
 	} else {
-		// Se não houver HPA, usa o número de réplicas do deployment
+		// Se não houver HPA, usa o número de réplicas do workload
 		result.Pods.MinReplicas = result.Pods.Replicas
 		result.Pods.MaxReplicas = result.Pods.Replicas
 	}
 
+	// Com KEDA, os limites efetivos são os do ScaledObject (minReplicaCount pode ser 0)
+	if scaledObject != nil {
+		result.KEDA = scaledObject
+		result.Pods.MinReplicas = scaledObject.MinReplicas
+		result.Pods.MaxReplicas = scaledObject.MaxReplicas
+		if result.HPA != nil {
+			result.HPA.ScaledObject = scaledObject.Name
+		}
+	}
+
+	// Obtém o VPA que tem o workload como alvo, se existir
+	result.VPA, err = c.findVPA(ctx, namespace, kind, name)
+	if err != nil {
+		logger.Error("Erro ao listar VPAs", err,
+			logger.NewField("namespace", namespace),
+		)
+		result.VPA = nil
+	} else if result.VPA != nil {
+		logger.Info("VPA encontrado",
+			logger.NewField("namespace", namespace),
+			logger.NewField("name", name),
+			logger.NewField("vpa", result.VPA.Name),
+			logger.NewField("update_mode", result.VPA.UpdateMode),
+		)
+	}
+
+	// Obtém os PDBs que cobrem os pods do workload
+	result.PDBs, err = c.findPDBs(ctx, wl)
+	if err != nil {
+		logger.Error("Erro ao listar PDBs", err,
+			logger.NewField("namespace", namespace),
+		)
+		result.PDBs = nil
+	}
+
 	logger.Info("Configuração de pods",
 		logger.NewField("replicas", result.Pods.Replicas),
 		logger.NewField("min_replicas", result.Pods.MinReplicas),
 		logger.NewField("max_replicas", result.Pods.MaxReplicas),
+		logger.NewField("target_cpu", result.Pods.TargetCPU),
 	)
 
 	return result, nil
@@ -311,3 +477,26 @@ func (c *Client) CheckConnection(ctx context.Context) error {
 	logger.Info("Conexão com o cluster estabelecida com sucesso")
 	return nil
 }
+
+// ListNamespaces lista os namespaces visíveis para a service account
+func (c *Client) ListNamespaces(ctx context.Context) ([]types.NamespaceRef, error) {
+	logger.Info("Listando namespaces do cluster")
+	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("Erro ao listar namespaces", err)
+		return nil, errors.NewResourceNotFoundError("namespaces", "erro ao listar namespaces")
+	}
+
+	result := make([]types.NamespaceRef, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		result = append(result, types.NamespaceRef{
+			Name:   ns.Name,
+			Labels: ns.Labels,
+		})
+	}
+
+	logger.Info("Namespaces encontrados",
+		logger.NewField("count", len(result)),
+	)
+	return result, nil
+}
@@ -0,0 +1,282 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// findHPA procura o HPA cujo scaleTargetRef aponta para o workload.
+// Retorna nil quando nenhum HPA escala o workload.
+func (c *Client) findHPA(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas, err := c.listHPAs(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range hpas {
+		if scalesWorkload(hpas[i].Spec.ScaleTargetRef, kind, name) {
+			return &hpas[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// scalesWorkload verifica se o scaleTargetRef de um HPA aponta para o workload
+func scalesWorkload(ref autoscalingv2.CrossVersionObjectReference, kind types.WorkloadKind, name string) bool {
+	if ref.Kind != string(kind) || ref.Name != name {
+		return false
+	}
+	group := strings.Split(ref.APIVersion, "/")[0]
+	return ref.APIVersion == "" || group == "apps" || group == "extensions"
+}
+
+// parseHPA converte um HPA autoscaling/v2 para o modelo do domínio
+func parseHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) *types.HPAConfig {
+	result := &types.HPAConfig{
+		Name:            hpa.Name,
+		MinReplicas:     int32Value(hpa.Spec.MinReplicas, 1),
+		MaxReplicas:     int(hpa.Spec.MaxReplicas),
+		CurrentReplicas: int(hpa.Status.CurrentReplicas),
+		DesiredReplicas: int(hpa.Status.DesiredReplicas),
+		Metrics:         make([]types.HPAMetric, 0, len(hpa.Spec.Metrics)),
+	}
+
+	// Sem métricas configuradas, o HPA usa 80% de utilização de CPU
+	specMetrics := hpa.Spec.Metrics
+	if len(specMetrics) == 0 {
+		utilization := int32(80)
+		specMetrics = []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: "cpu",
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		}}
+	}
+
+	current := make(map[string]*types.HPAMetricValue, len(hpa.Status.CurrentMetrics))
+	for _, status := range hpa.Status.CurrentMetrics {
+		if key, value := parseMetricStatus(status); value != nil {
+			current[key] = value
+		}
+	}
+
+	for _, spec := range specMetrics {
+		metric, ok := parseMetricSpec(spec)
+		if !ok {
+			continue
+		}
+		metric.Current = current[metricKey(metric)]
+		result.Metrics = append(result.Metrics, metric)
+	}
+
+	if behavior := hpa.Spec.Behavior; behavior != nil {
+		result.Behavior = &types.HPABehavior{
+			ScaleUp:   parseScalingRules(behavior.ScaleUp),
+			ScaleDown: parseScalingRules(behavior.ScaleDown),
+		}
+	}
+
+	for _, condition := range hpa.Status.Conditions {
+		result.Conditions = append(result.Conditions, types.HPACondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: formatTime(condition.LastTransitionTime),
+		})
+	}
+
+	return result
+}
+
+// parseMetricSpec converte uma métrica de spec.metrics
+func parseMetricSpec(spec autoscalingv2.MetricSpec) (types.HPAMetric, bool) {
+	metric := types.HPAMetric{Type: string(spec.Type)}
+
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource == nil {
+			return metric, false
+		}
+		metric.Name = string(spec.Resource.Name)
+		metric.Target = parseMetricTarget(spec.Resource.Target)
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource == nil {
+			return metric, false
+		}
+		metric.Name = string(spec.ContainerResource.Name)
+		metric.Container = spec.ContainerResource.Container
+		metric.Target = parseMetricTarget(spec.ContainerResource.Target)
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods == nil {
+			return metric, false
+		}
+		metric.Name = spec.Pods.Metric.Name
+		metric.Selector = formatSelector(spec.Pods.Metric.Selector)
+		metric.Target = parseMetricTarget(spec.Pods.Target)
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object == nil {
+			return metric, false
+		}
+		metric.Name = spec.Object.Metric.Name
+		metric.Selector = formatSelector(spec.Object.Metric.Selector)
+		metric.Object = &types.HPAObjectReference{
+			Kind:       spec.Object.DescribedObject.Kind,
+			Name:       spec.Object.DescribedObject.Name,
+			APIVersion: spec.Object.DescribedObject.APIVersion,
+		}
+		metric.Target = parseMetricTarget(spec.Object.Target)
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External == nil {
+			return metric, false
+		}
+		metric.Name = spec.External.Metric.Name
+		metric.Selector = formatSelector(spec.External.Metric.Selector)
+		metric.Target = parseMetricTarget(spec.External.Target)
+	default:
+		logger.Warn("Tipo de métrica de HPA não suportado",
+			logger.NewField("type", spec.Type),
+		)
+		return metric, false
+	}
+
+	return metric, true
+}
+
+// parseMetricStatus converte uma métrica de status.currentMetrics, retornando a chave
+// usada para associá-la à métrica da spec
+func parseMetricStatus(status autoscalingv2.MetricStatus) (string, *types.HPAMetricValue) {
+	metric := types.HPAMetric{Type: string(status.Type)}
+
+	var current autoscalingv2.MetricValueStatus
+	switch status.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if status.Resource == nil {
+			return "", nil
+		}
+		metric.Name = string(status.Resource.Name)
+		current = status.Resource.Current
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if status.ContainerResource == nil {
+			return "", nil
+		}
+		metric.Name = string(status.ContainerResource.Name)
+		metric.Container = status.ContainerResource.Container
+		current = status.ContainerResource.Current
+	case autoscalingv2.PodsMetricSourceType:
+		if status.Pods == nil {
+			return "", nil
+		}
+		metric.Name = status.Pods.Metric.Name
+		metric.Selector = formatSelector(status.Pods.Metric.Selector)
+		current = status.Pods.Current
+	case autoscalingv2.ObjectMetricSourceType:
+		if status.Object == nil {
+			return "", nil
+		}
+		metric.Name = status.Object.Metric.Name
+		metric.Selector = formatSelector(status.Object.Metric.Selector)
+		metric.Object = &types.HPAObjectReference{
+			Kind: status.Object.DescribedObject.Kind,
+			Name: status.Object.DescribedObject.Name,
+		}
+		current = status.Object.Current
+	case autoscalingv2.ExternalMetricSourceType:
+		if status.External == nil {
+			return "", nil
+		}
+		metric.Name = status.External.Metric.Name
+		metric.Selector = formatSelector(status.External.Metric.Selector)
+		current = status.External.Current
+	default:
+		return "", nil
+	}
+	return metricKey(metric), parseMetricValueStatus(current)
+}
+
+// metricKey identifica uma métrica do HPA pelo tipo, nome, container, seletor e objeto descrito.
+// Métricas Pods, Object e External com o mesmo nome se distinguem pelo seletor ou pelo objeto;
+// a apiVersion do objeto é ignorada, pois o status pode reportá-lo em outra versão.
+func metricKey(metric types.HPAMetric) string {
+	key := metric.Type + "/" + metric.Name + "/" + metric.Container + "/" + metric.Selector
+	if metric.Object != nil {
+		key += "/" + metric.Object.Kind + "/" + metric.Object.Name
+	}
+	return key
+}
+
+// parseMetricTarget converte o alvo de uma métrica
+func parseMetricTarget(target autoscalingv2.MetricTarget) types.HPAMetricValue {
+	return types.HPAMetricValue{
+		Type:               string(target.Type),
+		AverageUtilization: target.AverageUtilization,
+		Value:              formatQuantity(target.Value),
+		AverageValue:       formatQuantity(target.AverageValue),
+	}
+}
+
+// parseMetricValueStatus converte o valor atual de uma métrica
+func parseMetricValueStatus(current autoscalingv2.MetricValueStatus) *types.HPAMetricValue {
+	return &types.HPAMetricValue{
+		AverageUtilization: current.AverageUtilization,
+		Value:              formatQuantity(current.Value),
+		AverageValue:       formatQuantity(current.AverageValue),
+	}
+}
+
+// parseScalingRules converte as regras de escala de uma direção
+func parseScalingRules(rules *autoscalingv2.HPAScalingRules) *types.HPAScalingRules {
+	if rules == nil {
+		return nil
+	}
+
+	result := &types.HPAScalingRules{
+		StabilizationWindowSeconds: rules.StabilizationWindowSeconds,
+	}
+	if rules.SelectPolicy != nil {
+		result.SelectPolicy = string(*rules.SelectPolicy)
+	}
+	for _, policy := range rules.Policies {
+		result.Policies = append(result.Policies, types.HPAScalingPolicy{
+			Type:          string(policy.Type),
+			Value:         policy.Value,
+			PeriodSeconds: policy.PeriodSeconds,
+		})
+	}
+	return result
+}
+
+// formatQuantity formata uma quantity opcional
+func formatQuantity(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
+
+// formatSelector formata um label selector opcional
+func formatSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	return metav1.FormatLabelSelector(selector)
+}
+
+// formatTime formata um horário opcional em RFC3339
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
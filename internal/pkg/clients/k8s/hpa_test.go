@@ -0,0 +1,220 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func TestParseHPA(t *testing.T) {
+	selectPolicy := autoscalingv2.MinChangePolicySelect
+	averageValue := resource.MustParse("100")
+	externalValue := resource.MustParse("30")
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-hpa"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MinReplicas: int32Ptr(2),
+			MaxReplicas: 10,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(70)},
+					},
+				},
+				{
+					Type: autoscalingv2.ContainerResourceMetricSourceType,
+					ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+						Name:      corev1.ResourceMemory,
+						Container: "app",
+						Target:    autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(80)},
+					},
+				},
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "http_requests_per_second"},
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &averageValue},
+					},
+				},
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{Kind: "Ingress", Name: "main", APIVersion: "networking.k8s.io/v1"},
+						Metric:          autoscalingv2.MetricIdentifier{Name: "requests"},
+						Target:          autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: &averageValue},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name:     "queue_depth",
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"queue": "orders"}},
+						},
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &externalValue},
+					},
+				},
+			},
+			Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: int32Ptr(300),
+					SelectPolicy:               &selectPolicy,
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{Type: autoscalingv2.PercentScalingPolicy, Value: 10, PeriodSeconds: 60},
+					},
+				},
+			},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+			CurrentMetrics: []autoscalingv2.MetricStatus{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricStatus{
+						Name:    corev1.ResourceCPU,
+						Current: autoscalingv2.MetricValueStatus{AverageUtilization: int32Ptr(85)},
+					},
+				},
+			},
+			Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2.ScalingLimited, Status: corev1.ConditionFalse, Reason: "DesiredWithinRange"},
+			},
+		},
+	}
+
+	config := parseHPA(hpa)
+
+	assert.Equal(t, "api-hpa", config.Name)
+	assert.Equal(t, 2, config.MinReplicas)
+	assert.Equal(t, 10, config.MaxReplicas)
+	assert.Equal(t, 4, config.DesiredReplicas)
+	assert.Len(t, config.Metrics, 5)
+	assert.Equal(t, int32(70), config.ResourceUtilizationTarget("cpu"))
+	assert.Equal(t, int32(0), config.ResourceUtilizationTarget("memory"))
+
+	cpu := config.Metrics[0]
+	assert.Equal(t, types.HPAMetricResource, cpu.Type)
+	assert.Equal(t, int32(85), *cpu.Current.AverageUtilization)
+
+	assert.Equal(t, "app", config.Metrics[1].Container)
+	assert.Nil(t, config.Metrics[1].Current)
+	assert.Equal(t, "100", config.Metrics[2].Target.AverageValue)
+	assert.Equal(t, "Ingress", config.Metrics[3].Object.Kind)
+	assert.Equal(t, "queue=orders", config.Metrics[4].Selector)
+
+	assert.Nil(t, config.Behavior.ScaleUp)
+	assert.Equal(t, int32(300), *config.Behavior.ScaleDown.StabilizationWindowSeconds)
+	assert.Equal(t, "Min", config.Behavior.ScaleDown.SelectPolicy)
+	assert.Equal(t, "Percent", config.Behavior.ScaleDown.Policies[0].Type)
+
+	assert.Len(t, config.Conditions, 1)
+	assert.Equal(t, "ScalingLimited", config.Conditions[0].Type)
+}
+
+func TestParseHPA_DefaultMetric(t *testing.T) {
+	config := parseHPA(&autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: 5},
+	})
+
+	assert.Equal(t, 1, config.MinReplicas)
+	assert.Equal(t, int32(80), config.ResourceUtilizationTarget("cpu"))
+}
+
+func TestParseHPA_CurrentMetricsWithSameName(t *testing.T) {
+	target := resource.MustParse("10")
+	external := func(queue string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"queue": queue}}
+	}
+	ingress := func(name string) autoscalingv2.CrossVersionObjectReference {
+		return autoscalingv2.CrossVersionObjectReference{Kind: "Ingress", Name: name, APIVersion: "networking.k8s.io/v1"}
+	}
+	quantity := func(value string) *resource.Quantity {
+		q := resource.MustParse(value)
+		return &q
+	}
+
+	var specMetrics []autoscalingv2.MetricSpec
+	for _, queue := range []string{"orders", "payments"} {
+		specMetrics = append(specMetrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "queue_depth", Selector: external(queue)},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &target},
+			},
+		})
+	}
+	for _, name := range []string{"public", "internal"} {
+		specMetrics = append(specMetrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ObjectMetricSourceType,
+			Object: &autoscalingv2.ObjectMetricSource{
+				DescribedObject: ingress(name),
+				Metric:          autoscalingv2.MetricIdentifier{Name: "requests"},
+				Target:          autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: &target},
+			},
+		})
+	}
+
+	// O status lista as métricas em outra ordem e com outra versão do objeto descrito
+	statusObject := ingress("internal")
+	statusObject.APIVersion = "networking.k8s.io/v1beta1"
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: 5, Metrics: specMetrics},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentMetrics: []autoscalingv2.MetricStatus{
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricStatus{
+						DescribedObject: statusObject,
+						Metric:          autoscalingv2.MetricIdentifier{Name: "requests"},
+						Current:         autoscalingv2.MetricValueStatus{Value: quantity("7")},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricStatus{
+						Metric:  autoscalingv2.MetricIdentifier{Name: "queue_depth", Selector: external("payments")},
+						Current: autoscalingv2.MetricValueStatus{AverageValue: quantity("3")},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricStatus{
+						Metric:  autoscalingv2.MetricIdentifier{Name: "queue_depth", Selector: external("orders")},
+						Current: autoscalingv2.MetricValueStatus{AverageValue: quantity("20")},
+					},
+				},
+			},
+		},
+	}
+
+	config := parseHPA(hpa)
+
+	assert.Len(t, config.Metrics, 4)
+	assert.Equal(t, "20", config.Metrics[0].Current.AverageValue)
+	assert.Equal(t, "3", config.Metrics[1].Current.AverageValue)
+	assert.Nil(t, config.Metrics[2].Current)
+	assert.Equal(t, "7", config.Metrics[3].Current.Value)
+}
+
+func TestScalesWorkload(t *testing.T) {
+	ref := autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "api", APIVersion: "apps/v1"}
+
+	assert.True(t, scalesWorkload(ref, types.WorkloadKindDeployment, "api"))
+	assert.False(t, scalesWorkload(ref, types.WorkloadKindDeployment, "web"))
+	assert.False(t, scalesWorkload(ref, types.WorkloadKindStatefulSet, "api"))
+
+	ref.APIVersion = "argoproj.io/v1alpha1"
+	assert.False(t, scalesWorkload(ref, types.WorkloadKindDeployment, "api"))
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// scaledObjectResource identifica o recurso ScaledObject do KEDA, lido pelo cliente dinâmico
+var scaledObjectResource = schema.GroupVersionResource{
+	Group:    "keda.sh",
+	Version:  "v1alpha1",
+	Resource: "scaledobjects",
+}
+
+// Valores padrão do KEDA para campos omitidos no ScaledObject
+const (
+	kedaDefaultMaxReplicas     = 100
+	kedaDefaultPollingInterval = 30
+	kedaDefaultCooldownPeriod  = 300
+)
+
+// findScaledObject procura o ScaledObject cujo scaleTargetRef aponta para o workload.
+// Retorna nil quando nenhum ScaledObject escala o workload ou quando o KEDA não está instalado.
+func (c *Client) findScaledObject(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.KEDAScaledObject, error) {
+	if c.dynamicClient == nil {
+		return nil, nil
+	}
+
+	scaledObjects, err := c.dynamicClient.Resource(scaledObjectResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("CRD de ScaledObject não instalada no cluster")
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for i := range scaledObjects.Items {
+		if scaledObjectTargets(&scaledObjects.Items[i], kind, name) {
+			return parseScaledObject(&scaledObjects.Items[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+// scaledObjectTargets verifica se o spec.scaleTargetRef de um ScaledObject aponta para o workload.
+// Sem kind, o KEDA assume Deployment.
+func scaledObjectTargets(scaledObject *unstructured.Unstructured, kind types.WorkloadKind, name string) bool {
+	targetKind, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "kind")
+	targetName, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "name")
+	if targetKind == "" {
+		targetKind = string(types.WorkloadKindDeployment)
+	}
+	return targetKind == string(kind) && targetName == name
+}
+
+// parseScaledObject converte um ScaledObject para o modelo do domínio
+func parseScaledObject(scaledObject *unstructured.Unstructured) *types.KEDAScaledObject {
+	spec := scaledObject.Object
+	result := &types.KEDAScaledObject{
+		Name:            scaledObject.GetName(),
+		MinReplicas:     int(nestedInt64(spec, 0, "spec", "minReplicaCount")),
+		MaxReplicas:     int(nestedInt64(spec, kedaDefaultMaxReplicas, "spec", "maxReplicaCount")),
+		PollingInterval: int32(nestedInt64(spec, kedaDefaultPollingInterval, "spec", "pollingInterval")),
+		CooldownPeriod:  int32(nestedInt64(spec, kedaDefaultCooldownPeriod, "spec", "cooldownPeriod")),
+		Paused:          scaledObject.GetAnnotations()["autoscaling.keda.sh/paused"] == "true",
+	}
+
+	if _, found, _ := unstructured.NestedFieldNoCopy(spec, "spec", "idleReplicaCount"); found {
+		idle := int(nestedInt64(spec, 0, "spec", "idleReplicaCount"))
+		result.IdleReplicas = &idle
+	}
+
+	// Nome do HPA gerado: status.hpaName, o nome configurado em advanced ou o padrão keda-hpa-<nome>
+	result.HPAName, _, _ = unstructured.NestedString(spec, "status", "hpaName")
+	if result.HPAName == "" {
+		result.HPAName, _, _ = unstructured.NestedString(spec, "spec", "advanced", "horizontalPodAutoscalerConfig", "name")
+	}
+	if result.HPAName == "" {
+		result.HPAName = "keda-hpa-" + result.Name
+	}
+
+	triggers, _, _ := unstructured.NestedSlice(spec, "spec", "triggers")
+	for _, item := range triggers {
+		trigger, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		parsed := types.KEDATrigger{}
+		parsed.Type, _, _ = unstructured.NestedString(trigger, "type")
+		parsed.Name, _, _ = unstructured.NestedString(trigger, "name")
+		parsed.MetricType, _, _ = unstructured.NestedString(trigger, "metricType")
+		parsed.Metadata, _, _ = unstructured.NestedStringMap(trigger, "metadata")
+		result.Triggers = append(result.Triggers, parsed)
+	}
+
+	return result
+}
+
+// nestedInt64 lê um inteiro de um objeto não estruturado, retornando o padrão quando ausente
+func nestedInt64(obj map[string]interface{}, fallback int64, fields ...string) int64 {
+	value, found, err := unstructured.NestedInt64(obj, fields...)
+	if !found || err != nil {
+		return fallback
+	}
+	return value
+}
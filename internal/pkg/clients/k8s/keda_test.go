@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newScaledObject cria um ScaledObject não estruturado com o spec informado
+func newScaledObject(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "keda.sh/v1alpha1",
+		"kind":       "ScaledObject",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec":       spec,
+	}}
+}
+
+func TestFindScaledObject(t *testing.T) {
+	consumer := newScaledObject("consumer", map[string]interface{}{
+		"scaleTargetRef":   map[string]interface{}{"name": "consumer"},
+		"minReplicaCount":  int64(0),
+		"maxReplicaCount":  int64(20),
+		"idleReplicaCount": int64(0),
+		"cooldownPeriod":   int64(120),
+		"triggers": []interface{}{
+			map[string]interface{}{
+				"type":     "rabbitmq",
+				"name":     "orders",
+				"metadata": map[string]interface{}{"queueName": "orders", "mode": "QueueLength", "value": "50"},
+			},
+		},
+	})
+	consumer.SetAnnotations(map[string]string{"autoscaling.keda.sh/paused": "true"})
+	worker := newScaledObject("worker", map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{"kind": "StatefulSet", "name": "worker"},
+		"advanced": map[string]interface{}{
+			"horizontalPodAutoscalerConfig": map[string]interface{}{"name": "worker-hpa"},
+		},
+		"triggers": []interface{}{
+			map[string]interface{}{"type": "cpu", "metricType": "Utilization", "metadata": map[string]interface{}{"value": "70"}},
+		},
+	})
+
+	client := &Client{
+		dynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{scaledObjectResource: "ScaledObjectList"},
+			consumer, worker,
+		),
+	}
+
+	t.Run("scaleTargetRef sem kind assume Deployment", func(t *testing.T) {
+		scaledObject, err := client.findScaledObject(context.Background(), "default", types.WorkloadKindDeployment, "consumer")
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, scaledObject) {
+			assert.Equal(t, 0, scaledObject.MinReplicas)
+			assert.Equal(t, 20, scaledObject.MaxReplicas)
+			assert.Equal(t, 0, *scaledObject.IdleReplicas)
+			assert.Equal(t, int32(30), scaledObject.PollingInterval)
+			assert.Equal(t, int32(120), scaledObject.CooldownPeriod)
+			assert.Equal(t, "keda-hpa-consumer", scaledObject.HPAName)
+			assert.True(t, scaledObject.Paused)
+			assert.Equal(t, []types.KEDATrigger{{
+				Type:     "rabbitmq",
+				Name:     "orders",
+				Metadata: map[string]string{"queueName": "orders", "mode": "QueueLength", "value": "50"},
+			}}, scaledObject.Triggers)
+			assert.True(t, scaledObject.EventDriven())
+		}
+	})
+
+	t.Run("valores padrão e HPA com nome configurado", func(t *testing.T) {
+		scaledObject, err := client.findScaledObject(context.Background(), "default", types.WorkloadKindStatefulSet, "worker")
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, scaledObject) {
+			assert.Equal(t, 0, scaledObject.MinReplicas)
+			assert.Equal(t, 100, scaledObject.MaxReplicas)
+			assert.Nil(t, scaledObject.IdleReplicas)
+			assert.Equal(t, int32(300), scaledObject.CooldownPeriod)
+			assert.Equal(t, "worker-hpa", scaledObject.HPAName)
+			assert.False(t, scaledObject.EventDriven())
+		}
+	})
+
+	t.Run("workload sem ScaledObject", func(t *testing.T) {
+		scaledObject, err := client.findScaledObject(context.Background(), "default", types.WorkloadKindDeployment, "worker")
+
+		assert.NoError(t, err)
+		assert.Nil(t, scaledObject)
+	})
+}
@@ -0,0 +1,191 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// defaultNodePool é o pool atribuído a nodes sem label de node pool conhecido
+const defaultNodePool = "default"
+
+// nodePoolLabels são os labels usados pelos provedores para identificar o node pool, em ordem de prioridade
+var nodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"karpenter.sh/nodepool",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"node-pool",
+}
+
+// GetNodeInventory retorna a capacidade alocável, os requests e o uso atual de cada node,
+// além dos pods agendados em cada um
+func (c *Client) GetNodeInventory(ctx context.Context) (*types.NodeInventory, error) {
+	logger.Info("Obtendo inventário de nodes")
+
+	nodes, err := c.listNodes(ctx)
+	if err != nil {
+		logger.Error("Erro ao listar nodes", err)
+		return nil, errors.NewResourceNotFoundError("nodes", "erro ao listar nodes")
+	}
+
+	pods, err := c.listPods(ctx, metav1.NamespaceAll, labels.Everything())
+	if err != nil {
+		logger.Error("Erro ao listar pods", err)
+		return nil, errors.NewResourceNotFoundError("pods", "erro ao listar pods")
+	}
+
+	inventory := &types.NodeInventory{
+		Nodes: make([]types.NodeCapacity, 0, len(nodes)),
+	}
+	byName := make(map[string]int, len(nodes))
+	for _, node := range nodes {
+		capacity := types.NodeCapacity{
+			Name:         node.Name,
+			Pool:         nodePool(node.Labels),
+			InstanceType: node.Labels[corev1.LabelInstanceTypeStable],
+			Schedulable:  !node.Spec.Unschedulable,
+		}
+		capacity.CPU.Allocatable = float64(node.Status.Allocatable.Cpu().MilliValue())
+		capacity.Memory.Allocatable = float64(node.Status.Allocatable.Memory().Value()) / (1024 * 1024)
+		byName[node.Name] = len(inventory.Nodes)
+		inventory.Nodes = append(inventory.Nodes, capacity)
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		// Pods finalizados não ocupam mais capacidade
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		index, ok := byName[pod.Spec.NodeName]
+		if !ok {
+			// Pods pendentes ainda não ocupam capacidade de nenhum node
+			continue
+		}
+
+		cpuRequest, memoryRequest := podRequests(pod)
+		inventory.Pods = append(inventory.Pods, types.NodePod{
+			Namespace:     pod.Namespace,
+			Name:          pod.Name,
+			Node:          pod.Spec.NodeName,
+			Owner:         podOwner(pod),
+			CPURequest:    cpuRequest,
+			MemoryRequest: memoryRequest,
+		})
+
+		node := &inventory.Nodes[index]
+		node.Pods++
+		node.CPU.Requested += cpuRequest
+		node.Memory.Requested += memoryRequest
+	}
+
+	// O uso atual vem da metrics API; sem ela o inventário segue apenas com requests
+	nodeMetrics, err := c.metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("Erro ao obter métricas dos nodes", err)
+	} else {
+		for _, metrics := range nodeMetrics.Items {
+			index, ok := byName[metrics.Name]
+			if !ok {
+				continue
+			}
+			inventory.Nodes[index].CPU.Used = float64(metrics.Usage.Cpu().MilliValue())
+			inventory.Nodes[index].Memory.Used = float64(metrics.Usage.Memory().Value()) / (1024 * 1024)
+		}
+	}
+
+	for i := range inventory.Nodes {
+		CalculateNodeWaste(&inventory.Nodes[i])
+	}
+
+	logger.Info("Inventário de nodes obtido",
+		logger.NewField("nodes", len(inventory.Nodes)),
+		logger.NewField("pods", len(inventory.Pods)),
+	)
+	return inventory, nil
+}
+
+// CalculateNodeWaste calcula a capacidade não alocada, ociosa e encalhada de um node
+func CalculateNodeWaste(node *types.NodeCapacity) {
+	node.CPU.Unallocated = nonNegative(node.CPU.Allocatable - node.CPU.Requested)
+	node.CPU.Idle = nonNegative(node.CPU.Requested - node.CPU.Used)
+	node.Memory.Unallocated = nonNegative(node.Memory.Allocatable - node.Memory.Requested)
+	node.Memory.Idle = nonNegative(node.Memory.Requested - node.Memory.Used)
+
+	if node.CPU.Allocatable <= 0 || node.Memory.Allocatable <= 0 {
+		return
+	}
+
+	// A fração livre do recurso menos comprometido que excede a do outro recurso
+	// não pode ser consumida por pods com perfil proporcional ao node
+	freeCPU := node.CPU.Unallocated / node.CPU.Allocatable
+	freeMemory := node.Memory.Unallocated / node.Memory.Allocatable
+	node.CPU.Stranded = node.CPU.Allocatable * nonNegative(freeCPU-freeMemory)
+	node.Memory.Stranded = node.Memory.Allocatable * nonNegative(freeMemory-freeCPU)
+}
+
+// nodePool identifica o node pool a partir dos labels do node
+func nodePool(labels map[string]string) string {
+	for _, label := range nodePoolLabels {
+		if value := labels[label]; value != "" {
+			return value
+		}
+	}
+	return defaultNodePool
+}
+
+// podRequests calcula os requests efetivos de um pod: o maior valor entre a soma dos
+// containers e o maior init container, como faz o scheduler
+func podRequests(pod *corev1.Pod) (cpu, memory float64) {
+	for _, container := range pod.Spec.Containers {
+		cpu += float64(container.Resources.Requests.Cpu().MilliValue())
+		memory += float64(container.Resources.Requests.Memory().Value()) / (1024 * 1024)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		cpu = max(cpu, float64(container.Resources.Requests.Cpu().MilliValue()))
+		memory = max(memory, float64(container.Resources.Requests.Memory().Value())/(1024*1024))
+	}
+	return cpu, memory
+}
+
+// podOwner identifica o workload que controla o pod.
+// Pods de ReplicaSets criados por Deployments são atribuídos ao Deployment.
+func podOwner(pod *corev1.Pod) *types.WorkloadRef {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return nil
+	}
+
+	kind, ok := types.ParseWorkloadKind(controller.Kind)
+	if !ok {
+		return nil
+	}
+
+	name := controller.Name
+	if hash := pod.Labels["pod-template-hash"]; kind == types.WorkloadKindReplicaSet && hash != "" && strings.HasSuffix(name, "-"+hash) {
+		kind = types.WorkloadKindDeployment
+		name = strings.TrimSuffix(name, "-"+hash)
+	}
+
+	return &types.WorkloadRef{
+		Kind:      kind,
+		Namespace: pod.Namespace,
+		Name:      name,
+	}
+}
+
+// nonNegative retorna o valor ou zero quando negativo
+func nonNegative(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNode(name, pool string, cpu, memory float64) types.NodeCapacity {
+	node := types.NodeCapacity{Name: name, Pool: pool, Schedulable: true}
+	node.CPU.Allocatable = cpu
+	node.Memory.Allocatable = memory
+	return node
+}
+
+func TestCalculateNodeWaste(t *testing.T) {
+	node := newNode("a", "general", 4000, 8192)
+	node.CPU.Requested = 1000
+	node.CPU.Used = 400
+	node.Memory.Requested = 6144
+	node.Memory.Used = 7000
+
+	CalculateNodeWaste(&node)
+
+	assert.Equal(t, float64(3000), node.CPU.Unallocated)
+	assert.Equal(t, float64(600), node.CPU.Idle)
+	assert.Equal(t, float64(2048), node.Memory.Unallocated)
+	assert.Equal(t, float64(0), node.Memory.Idle)
+	// 75% da CPU livre contra 25% da memória: 50% da CPU está encalhada
+	assert.Equal(t, float64(2000), node.CPU.Stranded)
+	assert.Equal(t, float64(0), node.Memory.Stranded)
+}
+
+func TestNodePool(t *testing.T) {
+	assert.Equal(t, "pool-a", nodePool(map[string]string{"cloud.google.com/gke-nodepool": "pool-a"}))
+	assert.Equal(t, "ng-1", nodePool(map[string]string{"eks.amazonaws.com/nodegroup": "ng-1"}))
+	assert.Equal(t, defaultNodePool, nodePool(map[string]string{}))
+}
@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// podOwners identifica os pods de um workload pelos controladores que os criam:
+// o próprio workload ou, para Deployments, os ReplicaSets que ele controla
+type podOwners struct {
+	uids map[k8stypes.UID]bool
+}
+
+// selector converte o selector completo do workload, incluindo matchExpressions
+func (wl *workload) selector() (labels.Selector, error) {
+	if wl.Selector == nil {
+		return labels.Nothing(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(wl.Selector)
+	if err != nil {
+		return nil, errors.NewInvalidConfigurationError(string(wl.Kind), "selector inválido: "+err.Error())
+	}
+	return selector, nil
+}
+
+// resolvePodOwners resolve os controladores diretos dos pods do workload seguindo os owner
+// references (Deployment → ReplicaSet → Pod)
+func (c *Client) resolvePodOwners(ctx context.Context, wl *workload, selector labels.Selector) (*podOwners, error) {
+	owners := &podOwners{uids: make(map[k8stypes.UID]bool)}
+	if wl.Kind != types.WorkloadKindDeployment {
+		owners.uids[wl.UID] = true
+		return owners, nil
+	}
+
+	replicaSets, err := c.listReplicaSets(ctx, wl.Namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	for i := range replicaSets {
+		ref := metav1.GetControllerOf(&replicaSets[i])
+		if ref == nil || ref.UID != wl.UID {
+			continue
+		}
+		owners.uids[replicaSets[i].UID] = true
+	}
+	return owners, nil
+}
+
+// filter retorna apenas os pods controlados por um dos owners
+func (o *podOwners) filter(pods []corev1.Pod) []corev1.Pod {
+	result := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		ref := metav1.GetControllerOf(&pods[i])
+		if ref != nil && o.uids[ref.UID] {
+			result = append(result, pods[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// controllerRef cria um owner reference de controlador
+func controllerRef(kind, name string, uid k8stypes.UID) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid, Controller: &controller}}
+}
+
+func TestResolvePodOwners(t *testing.T) {
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "core"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"api", "api-worker"}},
+			{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+		},
+	}
+	podLabels := func(app string) map[string]string {
+		return map[string]string{"team": "core", "app": app}
+	}
+	pod := func(name string, podLabels map[string]string, owner []metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels, OwnerReferences: owner}}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", UID: "deploy-api"},
+			Spec:       appsv1.DeploymentSpec{Selector: selector},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-7d9f8", Namespace: "default", UID: "rs-api", Labels: podLabels("api"),
+			OwnerReferences: controllerRef("Deployment", "api", "deploy-api"),
+		}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-worker-5c4b6", Namespace: "default", UID: "rs-worker", Labels: podLabels("api-worker"),
+			OwnerReferences: controllerRef("Deployment", "api-worker", "deploy-worker"),
+		}},
+		pod("api-7d9f8-x7k2p", podLabels("api"), controllerRef("ReplicaSet", "api-7d9f8", "rs-api")),
+		pod("api-worker-5c4b6-q9w8e", podLabels("api-worker"), controllerRef("ReplicaSet", "api-worker-5c4b6", "rs-worker")),
+		pod("api-canary", map[string]string{"team": "core", "app": "api", "canary": "true"}, nil),
+		pod("api-standalone", podLabels("api"), nil),
+	)
+	client := &Client{clientset: clientset}
+	ctx := context.Background()
+
+	wl, err := client.getWorkload(ctx, "default", types.WorkloadKindDeployment, "api")
+	assert.NoError(t, err)
+	podSelector, err := wl.selector()
+	assert.NoError(t, err)
+
+	pods, err := client.listPods(ctx, "default", podSelector)
+	assert.NoError(t, err)
+	assert.Len(t, pods, 3, "matchExpressions excluem o pod canary")
+
+	owners, err := client.resolvePodOwners(ctx, wl, podSelector)
+	assert.NoError(t, err)
+
+	owned := owners.filter(pods)
+	if assert.Len(t, owned, 1) {
+		assert.Equal(t, "api-7d9f8-x7k2p", owned[0].Name)
+	}
+}
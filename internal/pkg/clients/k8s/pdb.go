@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"math"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// findPDBs retorna os PodDisruptionBudgets cujo selector cobre os pods do workload
+func (c *Client) findPDBs(ctx context.Context, wl *workload) ([]types.PDBConfig, error) {
+	pdbs, err := c.clientset.PolicyV1().PodDisruptionBudgets(wl.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	podLabels := labels.Set(wl.Template.Labels)
+	var result []types.PDBConfig
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		// Um selector nulo não seleciona nenhum pod; um selector vazio seleciona todos do namespace
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(podLabels) {
+			continue
+		}
+		result = append(result, parsePDB(pdb))
+	}
+	return result, nil
+}
+
+// parsePDB converte um PodDisruptionBudget e calcula o menor número de réplicas que permite
+// uma interrupção voluntária, arredondando percentuais como o controlador de disrupção
+// (minAvailable e maxUnavailable para cima)
+func parsePDB(pdb *policyv1.PodDisruptionBudget) types.PDBConfig {
+	config := types.PDBConfig{Name: pdb.Name}
+
+	switch {
+	case pdb.Spec.MinAvailable != nil:
+		minAvailable := pdb.Spec.MinAvailable
+		config.MinAvailable = minAvailable.String()
+		if minAvailable.Type == intstr.Int {
+			config.RequiredReplicas = minAvailable.IntValue() + 1
+			break
+		}
+		percent, ok := intOrPercent(minAvailable)
+		if !ok || percent >= 100 {
+			config.BlocksDisruptions = true
+			break
+		}
+		// Menor N com N - ceil(N * p) >= 1; com p < 100%, N = 100 sempre atende
+		for replicas := 1; replicas <= 100; replicas++ {
+			if replicas-int(math.Ceil(float64(replicas)*float64(percent)/100)) >= 1 {
+				config.RequiredReplicas = replicas
+				break
+			}
+		}
+	case pdb.Spec.MaxUnavailable != nil:
+		config.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+		value, ok := intOrPercent(pdb.Spec.MaxUnavailable)
+		if !ok || value <= 0 {
+			config.BlocksDisruptions = true
+			break
+		}
+		config.RequiredReplicas = 1
+	default:
+		// Sem minAvailable nem maxUnavailable, o PDB não restringe interrupções
+		config.RequiredReplicas = 1
+	}
+	return config
+}
+
+// intOrPercent retorna o valor numérico de um IntOrString, aceitando percentuais ("50%")
+func intOrPercent(value *intstr.IntOrString) (int, bool) {
+	if value.Type == intstr.Int {
+		return value.IntValue(), true
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	if err != nil {
+		return 0, false
+	}
+	return scaled, true
+}
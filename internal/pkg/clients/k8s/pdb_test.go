@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParsePDB(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     policyv1.PodDisruptionBudgetSpec
+		expected types.PDBConfig
+	}{
+		{
+			name:     "minAvailable absoluto",
+			spec:     policyv1.PodDisruptionBudgetSpec{MinAvailable: intOrStringPtr(intstr.FromInt(2))},
+			expected: types.PDBConfig{Name: "pdb", MinAvailable: "2", RequiredReplicas: 3},
+		},
+		{
+			name:     "minAvailable percentual",
+			spec:     policyv1.PodDisruptionBudgetSpec{MinAvailable: intOrStringPtr(intstr.FromString("50%"))},
+			expected: types.PDBConfig{Name: "pdb", MinAvailable: "50%", RequiredReplicas: 2},
+		},
+		{
+			name:     "minAvailable 100%",
+			spec:     policyv1.PodDisruptionBudgetSpec{MinAvailable: intOrStringPtr(intstr.FromString("100%"))},
+			expected: types.PDBConfig{Name: "pdb", MinAvailable: "100%", BlocksDisruptions: true},
+		},
+		{
+			name:     "maxUnavailable 1",
+			spec:     policyv1.PodDisruptionBudgetSpec{MaxUnavailable: intOrStringPtr(intstr.FromInt(1))},
+			expected: types.PDBConfig{Name: "pdb", MaxUnavailable: "1", RequiredReplicas: 1},
+		},
+		{
+			name:     "maxUnavailable 0",
+			spec:     policyv1.PodDisruptionBudgetSpec{MaxUnavailable: intOrStringPtr(intstr.FromInt(0))},
+			expected: types.PDBConfig{Name: "pdb", MaxUnavailable: "0", BlocksDisruptions: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "pdb"}, Spec: tt.spec}
+			assert.Equal(t, tt.expected, parsePDB(pdb))
+		})
+	}
+}
+
+func TestFindPDBs(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: intOrStringPtr(intstr.FromInt(2)),
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "team-a"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: intOrStringPtr(intstr.FromInt(1)),
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+			},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "sem-selector", Namespace: "team-a"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{MaxUnavailable: intOrStringPtr(intstr.FromInt(0))},
+		},
+	)
+	client := &Client{clientset: clientset}
+	wl := &workload{
+		Namespace: "team-a",
+		Name:      "api",
+		Template:  corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api", "tier": "web"}}},
+	}
+
+	pdbs, err := client.findPDBs(context.Background(), wl)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.PDBConfig{{Name: "api", MinAvailable: "2", RequiredReplicas: 3}}, pdbs)
+}
+
+func intOrStringPtr(value intstr.IntOrString) *intstr.IntOrString {
+	return &value
+}
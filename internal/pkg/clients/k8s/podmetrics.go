@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// podMetricsConcurrency limita quantas consultas individuais de métricas de pod rodam em paralelo
+const podMetricsConcurrency = 8
+
+// getPodMetrics obtém as métricas atuais dos pods com um único List pelo selector do workload.
+// Pods ausentes no List (por exemplo, ainda sem coleta do metrics-server) ou todos eles, quando o
+// List falha, são consultados individualmente com no máximo podMetricsConcurrency em paralelo.
+// Pods sem métricas disponíveis ficam fora do mapa retornado.
+func (c *Client) getPodMetrics(ctx context.Context, namespace string, selector labels.Selector, pods []corev1.Pod) map[string]*metricsapi.PodMetrics {
+	result := make(map[string]*metricsapi.PodMetrics, len(pods))
+
+	list, err := c.metricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		logger.Error("Erro ao listar métricas dos pods", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("selector", selector.String()),
+		)
+	} else {
+		for i := range list.Items {
+			result[list.Items[i].Name] = &list.Items[i]
+		}
+	}
+
+	var missing []string
+	for _, pod := range pods {
+		if _, ok := result[pod.Name]; !ok {
+			missing = append(missing, pod.Name)
+		}
+	}
+	if len(missing) == 0 {
+		return result
+	}
+
+	logger.Info("Consultando métricas de pods individualmente",
+		logger.NewField("namespace", namespace),
+		logger.NewField("count", len(missing)),
+	)
+
+	fetched := make([]*metricsapi.PodMetrics, len(missing))
+	sem := make(chan struct{}, podMetricsConcurrency)
+	var wg sync.WaitGroup
+
+	for i, name := range missing {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			podMetrics, err := c.metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				logger.Error("Erro ao obter métricas do pod", err,
+					logger.NewField("pod", name),
+				)
+				return
+			}
+			fetched[i] = podMetrics
+		}(i, name)
+	}
+	wg.Wait()
+
+	for i, name := range missing {
+		if fetched[i] != nil {
+			result[name] = fetched[i]
+		}
+	}
+	return result
+}
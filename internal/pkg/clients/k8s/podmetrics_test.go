@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// podMetricsResource é o recurso usado pelo clientset fake da metrics API
+var podMetricsResource = metricsapi.SchemeGroupVersion.WithResource("pods")
+
+func newPodMetrics(name string, podLabels map[string]string, cpu string) *metricsapi.PodMetrics {
+	return &metricsapi.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Containers: []metricsapi.ContainerMetrics{
+			{
+				Name: "app",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+			},
+		},
+	}
+}
+
+func runningPods(names ...string) []corev1.Pod {
+	pods := make([]corev1.Pod, 0, len(names))
+	for _, name := range names {
+		pods = append(pods, corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+	return pods
+}
+
+// countVerbs conta as chamadas à metrics API por verbo
+func countVerbs(actions []k8stesting.Action) map[string]int {
+	counts := make(map[string]int)
+	for _, action := range actions {
+		counts[action.GetVerb()]++
+	}
+	return counts
+}
+
+func TestGetPodMetrics(t *testing.T) {
+	apiLabels := map[string]string{"app": "api"}
+	selector := labels.SelectorFromSet(apiLabels)
+
+	t.Run("um único List para todos os pods do selector", func(t *testing.T) {
+		metricsClient := metricsfake.NewSimpleClientset()
+		var names []string
+		for i := 0; i < 200; i++ {
+			name := fmt.Sprintf("api-%d", i)
+			names = append(names, name)
+			assert.NoError(t, metricsClient.Tracker().Create(podMetricsResource, newPodMetrics(name, apiLabels, "100m"), "default"))
+		}
+		assert.NoError(t, metricsClient.Tracker().Create(podMetricsResource, newPodMetrics("worker-1", map[string]string{"app": "worker"}, "1"), "default"))
+		client := &Client{metricsClient: metricsClient}
+
+		result := client.getPodMetrics(context.Background(), "default", selector, runningPods(names...))
+
+		assert.Len(t, result, 200)
+		assert.NotContains(t, result, "worker-1")
+		assert.Equal(t, map[string]int{"list": 1}, countVerbs(metricsClient.Actions()))
+	})
+
+	t.Run("pods ausentes no List são consultados individualmente", func(t *testing.T) {
+		metricsClient := metricsfake.NewSimpleClientset()
+		assert.NoError(t, metricsClient.Tracker().Create(podMetricsResource, newPodMetrics("api-1", apiLabels, "100m"), "default"))
+		// Métricas sem os labels do pod não aparecem no List pelo selector
+		assert.NoError(t, metricsClient.Tracker().Create(podMetricsResource, newPodMetrics("api-2", nil, "200m"), "default"))
+		client := &Client{metricsClient: metricsClient}
+
+		result := client.getPodMetrics(context.Background(), "default", selector, runningPods("api-1", "api-2", "api-3"))
+
+		assert.Len(t, result, 2)
+		if assert.Contains(t, result, "api-2") {
+			assert.Equal(t, int64(200), result["api-2"].Containers[0].Usage.Cpu().MilliValue())
+		}
+		assert.Equal(t, map[string]int{"list": 1, "get": 2}, countVerbs(metricsClient.Actions()))
+	})
+
+	t.Run("falha no List consulta cada pod", func(t *testing.T) {
+		metricsClient := metricsfake.NewSimpleClientset()
+		assert.NoError(t, metricsClient.Tracker().Create(podMetricsResource, newPodMetrics("api-1", apiLabels, "100m"), "default"))
+		metricsClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("metrics-server indisponível")
+		})
+		client := &Client{metricsClient: metricsClient}
+
+		result := client.getPodMetrics(context.Background(), "default", selector, runningPods("api-1"))
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, map[string]int{"list": 1, "get": 1}, countVerbs(metricsClient.Actions()))
+	})
+}
@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetNamespaceQuotas retorna os ResourceQuotas e LimitRanges de um namespace e os containers
+// dos workloads que não declaram request e recebem o padrão do LimitRange
+func (c *Client) GetNamespaceQuotas(ctx context.Context, namespace string) (*types.NamespaceQuotas, error) {
+	logger.Info("Obtendo quotas do namespace",
+		logger.NewField("namespace", namespace),
+	)
+
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("Erro ao listar resourcequotas", err, logger.NewField("namespace", namespace))
+		return nil, errors.NewResourceNotFoundError("resourcequotas", "erro ao listar resourcequotas")
+	}
+
+	limitRanges, err := c.clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("Erro ao listar limitranges", err, logger.NewField("namespace", namespace))
+		return nil, errors.NewResourceNotFoundError("limitranges", "erro ao listar limitranges")
+	}
+
+	workloads, err := c.listWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.NamespaceQuotas{
+		Namespace:      namespace,
+		ResourceQuotas: make([]types.ResourceQuotaConfig, 0, len(quotas.Items)),
+		LimitRanges:    make([]types.LimitRangeConfig, 0, len(limitRanges.Items)),
+	}
+	for _, quota := range quotas.Items {
+		result.ResourceQuotas = append(result.ResourceQuotas, resourceQuotaConfig(&quota))
+	}
+	for _, limitRange := range limitRanges.Items {
+		if config, ok := limitRangeConfig(&limitRange); ok {
+			result.LimitRanges = append(result.LimitRanges, config)
+		}
+	}
+	for _, wl := range workloads {
+		result.DefaultedContainers = append(result.DefaultedContainers, defaultedContainers(wl)...)
+	}
+
+	logger.Info("Quotas do namespace obtidas",
+		logger.NewField("namespace", namespace),
+		logger.NewField("resource_quotas", len(result.ResourceQuotas)),
+		logger.NewField("limit_ranges", len(result.LimitRanges)),
+		logger.NewField("defaulted_containers", len(result.DefaultedContainers)),
+	)
+	return result, nil
+}
+
+// resourceQuotaConfig converte um ResourceQuota, com os recursos ordenados por nome
+func resourceQuotaConfig(quota *corev1.ResourceQuota) types.ResourceQuotaConfig {
+	config := types.ResourceQuotaConfig{
+		Name:      quota.Name,
+		Resources: make([]types.QuotaResource, 0, len(quota.Status.Hard)),
+	}
+
+	// O status reflete o spec já aplicado; sem status (quota recém-criado), usa o spec
+	hard := quota.Status.Hard
+	if len(hard) == 0 {
+		hard = quota.Spec.Hard
+	}
+	for name, value := range hard {
+		used := quota.Status.Used[name]
+		config.Resources = append(config.Resources, types.QuotaResource{
+			Resource: string(name),
+			Hard:     quantityValue(name, value),
+			Used:     quantityValue(name, used),
+		})
+	}
+	sort.Slice(config.Resources, func(i, j int) bool {
+		return config.Resources[i].Resource < config.Resources[j].Resource
+	})
+	return config
+}
+
+// quantityValue converte a quantidade de um recurso de quota para milicores (CPU),
+// Mi (memória) ou unidades (contagens)
+func quantityValue(name corev1.ResourceName, quantity resource.Quantity) float64 {
+	switch {
+	case name == corev1.ResourceCPU || strings.HasSuffix(string(name), ".cpu"):
+		return float64(quantity.MilliValue())
+	case name == corev1.ResourceMemory || strings.HasSuffix(string(name), ".memory"):
+		return float64(quantity.Value()) / (1024 * 1024)
+	default:
+		return float64(quantity.Value())
+	}
+}
+
+// limitRangeConfig extrai os limites de containers de um LimitRange.
+// Retorna false quando o LimitRange não possui limites de containers.
+func limitRangeConfig(limitRange *corev1.LimitRange) (types.LimitRangeConfig, bool) {
+	for _, item := range limitRange.Spec.Limits {
+		if item.Type != corev1.LimitTypeContainer {
+			continue
+		}
+		return types.LimitRangeConfig{
+			Name:   limitRange.Name,
+			CPU:    limitRangeValues(item, corev1.ResourceCPU),
+			Memory: limitRangeValues(item, corev1.ResourceMemory),
+		}, true
+	}
+	return types.LimitRangeConfig{}, false
+}
+
+// limitRangeValues extrai os valores de um recurso. Sem defaultRequest, a admissão usa o limit
+// padrão como request, e o valor é refletido em DefaultRequest.
+func limitRangeValues(item corev1.LimitRangeItem, name corev1.ResourceName) types.LimitRangeValues {
+	value := func(list corev1.ResourceList) float64 {
+		quantity, ok := list[name]
+		if !ok {
+			return 0
+		}
+		return quantityValue(name, quantity)
+	}
+
+	values := types.LimitRangeValues{
+		DefaultRequest: value(item.DefaultRequest),
+		DefaultLimit:   value(item.Default),
+		Min:            value(item.Min),
+		Max:            value(item.Max),
+	}
+	if values.DefaultRequest == 0 {
+		values.DefaultRequest = values.DefaultLimit
+	}
+	return values
+}
+
+// defaultedContainers retorna os containers do pod template sem request de CPU ou memória.
+// Containers com limit mas sem request recebem o próprio limit como request e não dependem do LimitRange.
+func defaultedContainers(wl listedWorkload) []types.DefaultedContainer {
+	var containers []types.DefaultedContainer
+	for _, container := range wl.template.Spec.Containers {
+		missing := func(name corev1.ResourceName) bool {
+			_, hasRequest := container.Resources.Requests[name]
+			_, hasLimit := container.Resources.Limits[name]
+			return !hasRequest && !hasLimit
+		}
+
+		defaulted := types.DefaultedContainer{
+			Workload:  wl.ref,
+			Container: container.Name,
+			CPU:       missing(corev1.ResourceCPU),
+			Memory:    missing(corev1.ResourceMemory),
+		}
+		if defaulted.CPU || defaulted.Memory {
+			containers = append(containers, defaulted)
+		}
+	}
+	return containers
+}
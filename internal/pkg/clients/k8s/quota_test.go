@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNamespaceQuotas(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "budget", Namespace: "team-a"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{
+					corev1.ResourceRequestsCPU:    resource.MustParse("4"),
+					corev1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+					corev1.ResourcePods:           resource.MustParse("20"),
+				},
+				Used: corev1.ResourceList{
+					corev1.ResourceRequestsCPU:    resource.MustParse("2500m"),
+					corev1.ResourceRequestsMemory: resource.MustParse("6Gi"),
+					corev1.ResourcePods:           resource.MustParse("7"),
+				},
+			},
+		},
+		&corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					Default:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+					DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				},
+			}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "app", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+				}},
+				{Name: "sidecar"},
+				{Name: "proxy", Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				}},
+			}}}},
+		},
+	)
+	client := &Client{clientset: clientset}
+
+	quotas, err := client.GetNamespaceQuotas(context.Background(), "team-a")
+
+	assert.NoError(t, err)
+	if assert.Len(t, quotas.ResourceQuotas, 1) {
+		assert.Equal(t, []types.QuotaResource{
+			{Resource: "pods", Hard: 20, Used: 7},
+			{Resource: "requests.cpu", Hard: 4000, Used: 2500},
+			{Resource: "requests.memory", Hard: 8192, Used: 6144},
+		}, quotas.ResourceQuotas[0].Resources)
+	}
+
+	if assert.Len(t, quotas.LimitRanges, 1) {
+		assert.Equal(t, float64(500), quotas.LimitRanges[0].CPU.DefaultRequest)
+		// Sem defaultRequest de memória, a admissão usa o limit padrão como request
+		assert.Equal(t, float64(1024), quotas.LimitRanges[0].Memory.DefaultRequest)
+	}
+
+	// O container com limit de CPU recebe o próprio limit como request
+	assert.Equal(t, []types.DefaultedContainer{
+		{Workload: types.WorkloadRef{Kind: types.WorkloadKindDeployment, Namespace: "team-a", Name: "api"}, Container: "sidecar", CPU: true, Memory: true},
+		{Workload: types.WorkloadRef{Kind: types.WorkloadKindDeployment, Namespace: "team-a", Name: "api"}, Container: "proxy", Memory: true},
+	}, quotas.DefaultedContainers)
+}
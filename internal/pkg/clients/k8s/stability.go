@@ -0,0 +1,134 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// podStability agrega reinícios, últimas terminações e evictions a partir do status dos pods.
+// Considera pods em qualquer fase, já que pods removidos por eviction ficam como Failed.
+func podStability(pods []corev1.Pod) types.WorkloadStability {
+	var result types.WorkloadStability
+	containers := make(map[string]*types.ContainerStability)
+	lastFinishedAt := make(map[string]metav1.Time)
+	var containerOrder []string
+
+	for _, pod := range pods {
+		if pod.Status.Reason == types.PodReasonEvicted {
+			result.Evictions = append(result.Evictions, types.PodEviction{
+				Pod:       pod.Name,
+				Reason:    pod.Status.Reason,
+				Message:   pod.Status.Message,
+				Timestamp: formatTime(podFinishedAt(pod)),
+			})
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			container, ok := containers[status.Name]
+			if !ok {
+				container = &types.ContainerStability{Name: status.Name}
+				containers[status.Name] = container
+				containerOrder = append(containerOrder, status.Name)
+			}
+			container.Restarts += status.RestartCount
+			result.Restarts += status.RestartCount
+
+			terminated := lastTermination(status)
+			if terminated == nil {
+				continue
+			}
+			if terminated.Reason == types.TerminationReasonOOMKilled {
+				container.OOMKills++
+				result.OOMKills++
+			}
+			if container.LastTermination == nil || terminated.FinishedAt.After(lastFinishedAt[status.Name].Time) {
+				lastFinishedAt[status.Name] = terminated.FinishedAt
+				container.LastTermination = &types.ContainerTermination{
+					Pod:        pod.Name,
+					Reason:     terminated.Reason,
+					ExitCode:   terminated.ExitCode,
+					FinishedAt: formatTime(terminated.FinishedAt),
+				}
+			}
+		}
+	}
+
+	for _, name := range containerOrder {
+		result.Containers = append(result.Containers, *containers[name])
+	}
+	return result
+}
+
+// lastTermination retorna a terminação mais recente de um container: o estado atual, quando
+// o container está terminado, ou o último estado antes do reinício
+func lastTermination(status corev1.ContainerStatus) *corev1.ContainerStateTerminated {
+	if status.State.Terminated != nil {
+		return status.State.Terminated
+	}
+	return status.LastTerminationState.Terminated
+}
+
+// podFinishedAt retorna o horário da última transição de condição do pod
+func podFinishedAt(pod corev1.Pod) metav1.Time {
+	var finishedAt metav1.Time
+	for _, condition := range pod.Status.Conditions {
+		if condition.LastTransitionTime.After(finishedAt.Time) {
+			finishedAt = condition.LastTransitionTime
+		}
+	}
+	return finishedAt
+}
+
+// collectEvictionEvents adiciona as evictions registradas em Events dos pods do workload, associando
+// cada evento ao pod pelo involvedObject.uid (nomes de pods são reutilizados por StatefulSets e podem
+// coincidir com pods de outros workloads). Pods já reportados pelo status não são duplicados.
+func (c *Client) collectEvictionEvents(ctx context.Context, namespace string, pods []corev1.Pod, stability *types.WorkloadStability) error {
+	events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("involvedObject.kind", "Pod"),
+			fields.OneTermEqualSelector("reason", types.PodReasonEvicted),
+		).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[k8stypes.UID]bool, len(pods))
+	for i := range pods {
+		owned[pods[i].UID] = true
+	}
+	seen := make(map[string]bool, len(stability.Evictions))
+	for _, eviction := range stability.Evictions {
+		seen[eviction.Pod] = true
+	}
+	for _, event := range events.Items {
+		pod := event.InvolvedObject.Name
+		if seen[pod] || !owned[event.InvolvedObject.UID] {
+			continue
+		}
+		seen[pod] = true
+		stability.Evictions = append(stability.Evictions, types.PodEviction{
+			Pod:       pod,
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Timestamp: formatTime(eventTime(event)),
+		})
+	}
+	return nil
+}
+
+// eventTime retorna o horário mais recente de um Event
+func eventTime(event corev1.Event) metav1.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp
+	}
+	if !event.EventTime.IsZero() {
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.FirstTimestamp
+}
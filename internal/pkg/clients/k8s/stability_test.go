@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func terminatedStatus(name string, restarts int32, reason string, finishedAt time.Time) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:         name,
+		RestartCount: restarts,
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: reason, ExitCode: 137, FinishedAt: metav1.NewTime(finishedAt)},
+		},
+	}
+}
+
+func TestPodStability(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					terminatedStatus("app", 3, types.TerminationReasonOOMKilled, now.Add(-2*time.Hour)),
+					{Name: "istio-proxy"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-2"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					terminatedStatus("app", 1, types.TerminationReasonError, now.Add(-time.Hour)),
+					{Name: "istio-proxy", RestartCount: 1},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-3"},
+			Status: corev1.PodStatus{
+				Phase:   corev1.PodFailed,
+				Reason:  types.PodReasonEvicted,
+				Message: "The node was low on resource: memory.",
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, LastTransitionTime: metav1.NewTime(now)},
+				},
+			},
+		},
+	}
+
+	stability := podStability(pods)
+
+	assert.Equal(t, int32(5), stability.Restarts)
+	assert.Equal(t, 1, stability.OOMKills)
+	if assert.Len(t, stability.Containers, 2) {
+		app := stability.Containers[0]
+		assert.Equal(t, "app", app.Name)
+		assert.Equal(t, int32(4), app.Restarts)
+		assert.Equal(t, 1, app.OOMKills)
+		// A última terminação é a mais recente entre os pods
+		assert.Equal(t, &types.ContainerTermination{
+			Pod:        "api-2",
+			Reason:     types.TerminationReasonError,
+			ExitCode:   137,
+			FinishedAt: formatTime(metav1.NewTime(now.Add(-time.Hour))),
+		}, app.LastTermination)
+		assert.Nil(t, stability.Containers[1].LastTermination)
+	}
+	if assert.Len(t, stability.Evictions, 1) {
+		assert.Equal(t, "api-3", stability.Evictions[0].Pod)
+		assert.Equal(t, "The node was low on resource: memory.", stability.Evictions[0].Message)
+	}
+}
+
+func TestCollectEvictionEvents(t *testing.T) {
+	evictionEvent := func(name, pod string, uid k8stypes.UID) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: pod, UID: uid},
+			Reason:         types.PodReasonEvicted,
+			Message:        "The node was low on resource: memory.",
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		evictionEvent("db-0.1", "db-0", "db-0-atual"),
+		// Pod homônimo de uma geração anterior do StatefulSet
+		evictionEvent("db-0.2", "db-0", "db-0-anterior"),
+		// Pod de outro workload cujo nome começa com o nome do workload
+		evictionEvent("db-1.1", "db-1", "outro-workload"),
+		evictionEvent("db-2.1", "db-2", "db-2-atual"),
+	)
+	client := &Client{clientset: clientset}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "db-0", UID: "db-0-atual"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db-2", UID: "db-2-atual"}},
+	}
+	// A eviction de db-2 já foi reportada pelo status do pod
+	stability := types.WorkloadStability{Evictions: []types.PodEviction{{Pod: "db-2", Reason: types.PodReasonEvicted}}}
+
+	err := client.collectEvictionEvents(context.Background(), "default", pods, &stability)
+
+	assert.NoError(t, err)
+	if assert.Len(t, stability.Evictions, 2) {
+		assert.Equal(t, "db-2", stability.Evictions[0].Pod)
+		assert.Equal(t, "db-0", stability.Evictions[1].Pod)
+		assert.Equal(t, "The node was low on resource: memory.", stability.Evictions[1].Message)
+	}
+}
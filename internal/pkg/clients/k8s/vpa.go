@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// vpaResource identifica o recurso VerticalPodAutoscaler, que é uma CRD e por isso
+// é lido pelo cliente dinâmico
+var vpaResource = schema.GroupVersionResource{
+	Group:    "autoscaling.k8s.io",
+	Version:  "v1",
+	Resource: "verticalpodautoscalers",
+}
+
+// findVPA procura o VPA cujo targetRef aponta para o workload.
+// Retorna nil quando nenhum VPA tem o workload como alvo ou quando a CRD não está instalada.
+func (c *Client) findVPA(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*types.VPAConfig, error) {
+	if c.dynamicClient == nil {
+		return nil, nil
+	}
+
+	vpas, err := c.dynamicClient.Resource(vpaResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("CRD de VPA não instalada no cluster")
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for i := range vpas.Items {
+		if targetsWorkload(&vpas.Items[i], kind, name) {
+			return parseVPA(&vpas.Items[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+// targetsWorkload verifica se o spec.targetRef de um VPA aponta para o workload
+func targetsWorkload(vpa *unstructured.Unstructured, kind types.WorkloadKind, name string) bool {
+	targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+	targetName, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+	return targetKind == string(kind) && targetName == name
+}
+
+// parseVPA converte um VPA autoscaling.k8s.io/v1 para o modelo do domínio
+func parseVPA(vpa *unstructured.Unstructured) *types.VPAConfig {
+	result := &types.VPAConfig{
+		Name: vpa.GetName(),
+		// Sem updatePolicy, o VPA opera no modo Auto
+		UpdateMode: "Auto",
+	}
+	if mode, found, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode"); found && mode != "" {
+		result.UpdateMode = mode
+	}
+
+	recommendations, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	for _, item := range recommendations {
+		recommendation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		container, _, _ := unstructured.NestedString(recommendation, "containerName")
+		result.Containers = append(result.Containers, types.VPAContainerRecommendation{
+			Container:      container,
+			Target:         parseVPAResources(recommendation, "target"),
+			LowerBound:     parseVPAResources(recommendation, "lowerBound"),
+			UpperBound:     parseVPAResources(recommendation, "upperBound"),
+			UncappedTarget: parseVPAResources(recommendation, "uncappedTarget"),
+		})
+	}
+	return result
+}
+
+// parseVPAResources converte um mapa de recursos do VPA (cpu e memory como quantities)
+func parseVPAResources(recommendation map[string]interface{}, field string) types.VPAResources {
+	values, _, _ := unstructured.NestedStringMap(recommendation, field)
+
+	var result types.VPAResources
+	if cpu, err := resource.ParseQuantity(values["cpu"]); err == nil {
+		result.CPU = float64(cpu.MilliValue()) // Converte para milicores
+	}
+	if memory, err := resource.ParseQuantity(values["memory"]); err == nil {
+		result.Memory = float64(memory.Value()) / (1024 * 1024) // Converte bytes para Mi
+	}
+	return result
+}
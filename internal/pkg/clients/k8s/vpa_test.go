@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newVPA cria um VPA não estruturado com o targetRef e a recomendação de um container
+func newVPA(name, targetKind, targetName string, status map[string]interface{}) *unstructured.Unstructured {
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "autoscaling.k8s.io/v1",
+		"kind":       "VerticalPodAutoscaler",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec": map[string]interface{}{
+			"targetRef":    map[string]interface{}{"apiVersion": "apps/v1", "kind": targetKind, "name": targetName},
+			"updatePolicy": map[string]interface{}{"updateMode": "Off"},
+		},
+	}}
+	if status != nil {
+		vpa.Object["status"] = status
+	}
+	return vpa
+}
+
+func TestFindVPA(t *testing.T) {
+	status := map[string]interface{}{
+		"recommendation": map[string]interface{}{
+			"containerRecommendations": []interface{}{
+				map[string]interface{}{
+					"containerName":  "app",
+					"target":         map[string]interface{}{"cpu": "250m", "memory": "256Mi"},
+					"lowerBound":     map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+					"upperBound":     map[string]interface{}{"cpu": "1", "memory": "1Gi"},
+					"uncappedTarget": map[string]interface{}{"cpu": "250m", "memory": "262144k"},
+				},
+			},
+		},
+	}
+
+	client := &Client{
+		dynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{vpaResource: "VerticalPodAutoscalerList"},
+			newVPA("worker-vpa", "Deployment", "worker", nil),
+			newVPA("api-vpa", "Deployment", "api", status),
+		),
+	}
+
+	vpa, err := client.findVPA(context.Background(), "default", types.WorkloadKindDeployment, "api")
+	assert.NoError(t, err)
+	if assert.NotNil(t, vpa) {
+		assert.Equal(t, "api-vpa", vpa.Name)
+		assert.Equal(t, "Off", vpa.UpdateMode)
+		assert.Equal(t, []types.VPAContainerRecommendation{{
+			Container:      "app",
+			Target:         types.VPAResources{CPU: 250, Memory: 256},
+			LowerBound:     types.VPAResources{CPU: 100, Memory: 128},
+			UpperBound:     types.VPAResources{CPU: 1000, Memory: 1024},
+			UncappedTarget: types.VPAResources{CPU: 250, Memory: 250},
+		}}, vpa.Containers)
+	}
+
+	// O targetRef precisa coincidir em tipo e nome
+	vpa, err = client.findVPA(context.Background(), "default", types.WorkloadKindStatefulSet, "api")
+	assert.NoError(t, err)
+	assert.Nil(t, vpa)
+
+	// Sem cliente dinâmico, não há VPA
+	vpa, err = (&Client{}).findVPA(context.Background(), "default", types.WorkloadKindDeployment, "api")
+	assert.NoError(t, err)
+	assert.Nil(t, vpa)
+}
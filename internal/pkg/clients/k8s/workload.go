@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// workload é a visão normalizada de um controlador Kubernetes,
+// independente do tipo (Deployment, StatefulSet, DaemonSet ou ReplicaSet)
+type workload struct {
+	Kind      types.WorkloadKind
+	Namespace string
+	Name      string
+	// UID identifica o workload nos owner references dos objetos que ele controla
+	UID      k8stypes.UID
+	Selector *metav1.LabelSelector
+	Template corev1.PodTemplateSpec
+	// Replicas é o número de réplicas desejado (spec)
+	Replicas int
+	// StatusReplicas é o número de réplicas reportado no status
+	StatusReplicas int
+}
+
+// getWorkload obtém um workload do tipo informado e o normaliza
+func (c *Client) getWorkload(ctx context.Context, namespace string, kind types.WorkloadKind, name string) (*workload, error) {
+	result := &workload{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	var err error
+	switch kind {
+	case types.WorkloadKindDeployment:
+		deployment, getErr := c.getDeployment(ctx, namespace, name)
+		if err = getErr; err == nil {
+			result.UID = deployment.UID
+			result.Selector = deployment.Spec.Selector
+			result.Template = deployment.Spec.Template
+			result.Replicas = int32Value(deployment.Spec.Replicas, 1)
+			result.StatusReplicas = int(deployment.Status.Replicas)
+		}
+	case types.WorkloadKindStatefulSet:
+		statefulSet, getErr := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			result.UID = statefulSet.UID
+			result.Selector = statefulSet.Spec.Selector
+			result.Template = statefulSet.Spec.Template
+			result.Replicas = int32Value(statefulSet.Spec.Replicas, 1)
+			result.StatusReplicas = int(statefulSet.Status.Replicas)
+		}
+	case types.WorkloadKindDaemonSet:
+		daemonSet, getErr := c.clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			// DaemonSets não possuem réplicas: uma por node elegível
+			result.UID = daemonSet.UID
+			result.Selector = daemonSet.Spec.Selector
+			result.Template = daemonSet.Spec.Template
+			result.Replicas = int(daemonSet.Status.DesiredNumberScheduled)
+			result.StatusReplicas = int(daemonSet.Status.CurrentNumberScheduled)
+		}
+	case types.WorkloadKindReplicaSet:
+		replicaSet, getErr := c.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			result.UID = replicaSet.UID
+			result.Selector = replicaSet.Spec.Selector
+			result.Template = replicaSet.Spec.Template
+			result.Replicas = int32Value(replicaSet.Spec.Replicas, 1)
+			result.StatusReplicas = int(replicaSet.Status.Replicas)
+		}
+	default:
+		return nil, errors.NewInvalidConfigurationError("workload", "tipo de workload não suportado: "+string(kind))
+	}
+
+	if err != nil {
+		logger.Error("Erro ao obter workload", err,
+			logger.NewField("namespace", namespace),
+			logger.NewField("kind", kind),
+			logger.NewField("name", name),
+		)
+		if errors.IsUnavailableMetrics(err) {
+			return nil, err
+		}
+		return nil, errors.NewResourceNotFoundError(string(kind), "erro ao obter "+string(kind))
+	}
+
+	logger.Info("Workload encontrado",
+		logger.NewField("kind", kind),
+		logger.NewField("name", name),
+	)
+	return result, nil
+}
+
+// int32Value retorna o valor de um *int32 ou o padrão quando nil
+func int32Value(value *int32, fallback int) int {
+	if value == nil {
+		return fallback
+	}
+	return int(*value)
+}
+
+// ListWorkloads lista os workloads de um namespace (Deployments, StatefulSets, DaemonSets
+// e ReplicaSets sem controlador). ReplicaSets gerenciados por um Deployment são ignorados,
+// pois são analisados através do Deployment.
+func (c *Client) ListWorkloads(ctx context.Context, namespace string) ([]types.WorkloadRef, error) {
+	logger.Info("Listando workloads do namespace",
+		logger.NewField("namespace", namespace),
+	)
+
+	listed, err := c.listWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make([]types.WorkloadRef, 0, len(listed))
+	for _, wl := range listed {
+		workloads = append(workloads, wl.ref)
+	}
+
+	logger.Info("Workloads encontrados",
+		logger.NewField("namespace", namespace),
+		logger.NewField("count", len(workloads)),
+	)
+	return workloads, nil
+}
+
+// listedWorkload é um workload listado no namespace, com o pod template usado na admissão dos pods
+type listedWorkload struct {
+	ref      types.WorkloadRef
+	template corev1.PodTemplateSpec
+}
+
+// listWorkloads lista os workloads analisáveis de um namespace com seus pod templates
+func (c *Client) listWorkloads(ctx context.Context, namespace string) ([]listedWorkload, error) {
+	var workloads []listedWorkload
+	add := func(kind types.WorkloadKind, meta metav1.ObjectMeta, template corev1.PodTemplateSpec) {
+		workloads = append(workloads, listedWorkload{
+			ref: types.WorkloadRef{
+				Kind:      kind,
+				Namespace: meta.Namespace,
+				Name:      meta.Name,
+				Labels:    meta.Labels,
+			},
+			template: template,
+		})
+	}
+
+	deployments, err := c.listDeployments(ctx, namespace)
+	if err != nil {
+		logger.Error("Erro ao listar deployments", err, logger.NewField("namespace", namespace))
+		return nil, errors.NewResourceNotFoundError("deployments", "erro ao listar deployments")
+	}
+	for _, item := range deployments {
+		add(types.WorkloadKindDeployment, item.ObjectMeta, item.Spec.Template)
+	}
+
+	statefulSets, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("Erro ao listar statefulsets", err, logger.NewField("namespace", namespace))
+		return nil, errors.NewResourceNotFoundError("statefulsets", "erro ao listar statefulsets")
+	}
+	for _, item := range statefulSets.Items {
+		add(types.WorkloadKindStatefulSet, item.ObjectMeta, item.Spec.Template)
+	}
+
+	daemonSets, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("Erro ao listar daemonsets", err, logger.NewField("namespace", namespace))
+		return nil, errors.NewResourceNotFoundError("daemonsets", "erro ao listar daemonsets")
+	}
+	for _, item := range daemonSets.Items {
+		add(types.WorkloadKindDaemonSet, item.ObjectMeta, item.Spec.Template)
+	}
+
+	replicaSets, err := c.listReplicaSets(ctx, namespace, labels.Everything())
+	if err != nil {
+		logger.Error("Erro ao listar replicasets", err, logger.NewField("namespace", namespace))
+		return nil, errors.NewResourceNotFoundError("replicasets", "erro ao listar replicasets")
+	}
+	for _, item := range replicaSets {
+		if metav1.GetControllerOf(&item) != nil {
+			continue
+		}
+		add(types.WorkloadKindReplicaSet, item.ObjectMeta, item.Spec.Template)
+	}
+
+	return workloads, nil
+}
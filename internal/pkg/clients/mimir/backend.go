@@ -0,0 +1,161 @@
+package mimir
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Backends de métricas compatíveis com a API HTTP do Prometheus
+const (
+	// BackendMimir usa o prefixo /prometheus/api/v1 e o tenant no header X-Scope-OrgID
+	BackendMimir = "mimir"
+	// BackendPrometheus usa o prefixo /api/v1, sem tenant
+	BackendPrometheus = "prometheus"
+	// BackendThanos usa o prefixo /api/v1 e o tenant no header THANOS-TENANT
+	BackendThanos = "thanos"
+	// BackendVictoriaMetrics usa o prefixo /api/v1 (single-node), sem tenant, e aceita extra_label
+	BackendVictoriaMetrics = "victoriametrics"
+)
+
+// Modos de envio do tenant (OrgID) ao backend
+const (
+	// TenantModeNone não envia o tenant
+	TenantModeNone = "none"
+	// TenantModeHeader envia o tenant no header TenantHeader
+	TenantModeHeader = "header"
+	// TenantModeExtraLabel restringe as queries às séries com TenantLabel igual ao tenant, via
+	// parâmetro extra_label (requer a capacidade ExtraLabel)
+	TenantModeExtraLabel = "extra-label"
+)
+
+// BackendCapabilities descreve recursos específicos do fornecedor além da API do Prometheus
+type BackendCapabilities struct {
+	// ExtraLabel indica suporte ao parâmetro extra_label do VictoriaMetrics
+	ExtraLabel bool
+}
+
+// MetricsBackend descreve como acessar um backend de métricas compatível com a API HTTP do
+// Prometheus: o prefixo da API, o envio do tenant e as capacidades do fornecedor
+type MetricsBackend struct {
+	Type string
+	// APIPrefix é o caminho da API a partir da URL base (ex: /prometheus/api/v1)
+	APIPrefix string
+	// TenantMode define como o tenant é enviado (none, header ou extra-label)
+	TenantMode string
+	// TenantHeader é o header do tenant no modo header
+	TenantHeader string
+	// TenantLabel é o label filtrado pelo tenant no modo extra-label
+	TenantLabel  string
+	Capabilities BackendCapabilities
+}
+
+// BackendConfig contém as configurações do backend; campos vazios usam os valores padrão do tipo
+type BackendConfig struct {
+	Type         string
+	APIPrefix    string
+	TenantMode   string
+	TenantHeader string
+	TenantLabel  string
+}
+
+// DefaultBackend retorna as configurações padrão de um tipo de backend
+func DefaultBackend(backendType string) (MetricsBackend, error) {
+	switch backendType {
+	case BackendMimir:
+		return MetricsBackend{
+			Type:         BackendMimir,
+			APIPrefix:    "/prometheus/api/v1",
+			TenantMode:   TenantModeHeader,
+			TenantHeader: "X-Scope-OrgID",
+		}, nil
+	case BackendPrometheus:
+		return MetricsBackend{
+			Type:       BackendPrometheus,
+			APIPrefix:  "/api/v1",
+			TenantMode: TenantModeNone,
+		}, nil
+	case BackendThanos:
+		return MetricsBackend{
+			Type:         BackendThanos,
+			APIPrefix:    "/api/v1",
+			TenantMode:   TenantModeHeader,
+			TenantHeader: "THANOS-TENANT",
+		}, nil
+	case BackendVictoriaMetrics:
+		return MetricsBackend{
+			Type:         BackendVictoriaMetrics,
+			APIPrefix:    "/api/v1",
+			TenantMode:   TenantModeNone,
+			TenantLabel:  "tenant",
+			Capabilities: BackendCapabilities{ExtraLabel: true},
+		}, nil
+	}
+	return MetricsBackend{}, fmt.Errorf("unknown metrics backend %q", backendType)
+}
+
+// NewMetricsBackend cria o backend a partir das configurações, sobrescrevendo os valores padrão
+// do tipo (mimir quando vazio) e validando o modo de tenant contra as capacidades do fornecedor
+func NewMetricsBackend(cfg BackendConfig) (MetricsBackend, error) {
+	if cfg.Type == "" {
+		cfg.Type = BackendMimir
+	}
+	backend, err := DefaultBackend(cfg.Type)
+	if err != nil {
+		return MetricsBackend{}, err
+	}
+
+	if cfg.APIPrefix != "" {
+		backend.APIPrefix = cfg.APIPrefix
+	}
+	if cfg.TenantMode != "" {
+		backend.TenantMode = cfg.TenantMode
+	}
+	if cfg.TenantHeader != "" {
+		backend.TenantHeader = cfg.TenantHeader
+	}
+	if cfg.TenantLabel != "" {
+		backend.TenantLabel = cfg.TenantLabel
+	}
+	backend.APIPrefix = "/" + strings.Trim(backend.APIPrefix, "/")
+
+	switch backend.TenantMode {
+	case TenantModeNone:
+	case TenantModeHeader:
+		if backend.TenantHeader == "" {
+			return MetricsBackend{}, fmt.Errorf("tenant mode %q requires a tenant header", TenantModeHeader)
+		}
+	case TenantModeExtraLabel:
+		if !backend.Capabilities.ExtraLabel {
+			return MetricsBackend{}, fmt.Errorf("metrics backend %q does not support extra_label", backend.Type)
+		}
+		if backend.TenantLabel == "" {
+			return MetricsBackend{}, fmt.Errorf("tenant mode %q requires a tenant label", TenantModeExtraLabel)
+		}
+	default:
+		return MetricsBackend{}, fmt.Errorf("unknown tenant mode %q", backend.TenantMode)
+	}
+
+	return backend, nil
+}
+
+// path retorna o caminho de um endpoint da API (ex: query, query_range)
+func (b MetricsBackend) path(endpoint string) string {
+	return strings.TrimSuffix(b.APIPrefix, "/") + "/" + endpoint
+}
+
+// applyTenant envia o tenant na requisição conforme o modo configurado
+func (b MetricsBackend) applyTenant(req *http.Request, tenant string) {
+	if tenant == "" {
+		return
+	}
+
+	switch b.TenantMode {
+	case TenantModeHeader:
+		req.Header.Set(b.TenantHeader, tenant)
+	case TenantModeExtraLabel:
+		q := req.URL.Query()
+		q.Add("extra_label", b.TenantLabel+"="+tenant)
+		req.URL.RawQuery = q.Encode()
+	}
+}
@@ -0,0 +1,121 @@
+package mimir
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetricsBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   BackendConfig
+		expected MetricsBackend
+		wantErr  bool
+	}{
+		{
+			name:     "padrão é o Mimir",
+			config:   BackendConfig{},
+			expected: MetricsBackend{Type: BackendMimir, APIPrefix: "/prometheus/api/v1", TenantMode: TenantModeHeader, TenantHeader: "X-Scope-OrgID"},
+		},
+		{
+			name:     "Thanos com prefixo customizado",
+			config:   BackendConfig{Type: BackendThanos, APIPrefix: "thanos/api/v1/"},
+			expected: MetricsBackend{Type: BackendThanos, APIPrefix: "/thanos/api/v1", TenantMode: TenantModeHeader, TenantHeader: "THANOS-TENANT"},
+		},
+		{
+			name:   "VictoriaMetrics com extra_label",
+			config: BackendConfig{Type: BackendVictoriaMetrics, TenantMode: TenantModeExtraLabel, TenantLabel: "cluster"},
+			expected: MetricsBackend{
+				Type:         BackendVictoriaMetrics,
+				APIPrefix:    "/api/v1",
+				TenantMode:   TenantModeExtraLabel,
+				TenantLabel:  "cluster",
+				Capabilities: BackendCapabilities{ExtraLabel: true},
+			},
+		},
+		{
+			name:    "extra_label sem suporte do fornecedor",
+			config:  BackendConfig{Type: BackendPrometheus, TenantMode: TenantModeExtraLabel},
+			wantErr: true,
+		},
+		{
+			name:    "modo header sem header no Prometheus",
+			config:  BackendConfig{Type: BackendPrometheus, TenantMode: TenantModeHeader},
+			wantErr: true,
+		},
+		{
+			name:    "backend desconhecido",
+			config:  BackendConfig{Type: "influxdb"},
+			wantErr: true,
+		},
+		{
+			name:    "modo de tenant desconhecido",
+			config:  BackendConfig{TenantMode: "path"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewMetricsBackend(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, backend)
+		})
+	}
+}
+
+func TestClientBackendRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     BackendConfig
+		path       string
+		header     string
+		extraLabel string
+	}{
+		{name: "Mimir", config: BackendConfig{Type: BackendMimir}, path: "/prometheus/api/v1/query", header: "X-Scope-OrgID"},
+		{name: "Prometheus", config: BackendConfig{Type: BackendPrometheus}, path: "/api/v1/query"},
+		{name: "Thanos", config: BackendConfig{Type: BackendThanos}, path: "/api/v1/query", header: "THANOS-TENANT"},
+		{
+			name:       "VictoriaMetrics cluster",
+			config:     BackendConfig{Type: BackendVictoriaMetrics, APIPrefix: "/select/0/prometheus/api/v1", TenantMode: TenantModeExtraLabel},
+			path:       "/select/0/prometheus/api/v1/query",
+			extraLabel: "tenant=prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				_, _ = w.Write([]byte(vectorResponse))
+			}))
+			defer server.Close()
+
+			backend, err := NewMetricsBackend(tt.config)
+			assert.NoError(t, err)
+			client := NewClient(&ClientConfig{BaseURL: server.URL + "/", OrgID: "prod", Backend: backend})
+
+			_, err = client.Query(context.Background(), "up")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.path, req.URL.Path)
+			assert.Equal(t, "up", req.URL.Query().Get("query"))
+			assert.Equal(t, tt.extraLabel, req.URL.Query().Get("extra_label"))
+			if tt.header != "" {
+				assert.Equal(t, "prod", req.Header.Get(tt.header))
+			}
+			if tt.header != "X-Scope-OrgID" {
+				assert.Empty(t, req.Header.Get("X-Scope-OrgID"))
+			}
+		})
+	}
+}
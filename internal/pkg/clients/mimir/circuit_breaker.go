@@ -0,0 +1,139 @@
+package mimir
+
+import (
+	"sync"
+	"time"
+)
+
+// Estados do circuit breaker
+const (
+	// CircuitClosed indica que as requisições ao Mimir são executadas normalmente
+	CircuitClosed = "closed"
+	// CircuitOpen indica que as requisições são rejeitadas até o fim do ResetTimeout
+	CircuitOpen = "open"
+	// CircuitHalfOpen indica que um número limitado de requisições de teste é permitido
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreaker interrompe as requisições ao Mimir após MaxFailures falhas transitórias
+// consecutivas. Após ResetTimeout, até HalfOpenMaxCalls requisições de teste são permitidas:
+// o circuito fecha quando todas têm sucesso e volta a abrir na primeira falha.
+type CircuitBreaker struct {
+	mu     sync.Mutex
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	state             string
+	failures          int
+	openedAt          time.Time
+	halfOpenCalls     int
+	halfOpenSuccesses int
+}
+
+// NewCircuitBreaker cria um circuit breaker fechado, usando valores padrão para as
+// configurações não informadas
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = 5
+	}
+	if cfg.ResetTimeout <= 0 {
+		cfg.ResetTimeout = 60 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = 2
+	}
+
+	return &CircuitBreaker{
+		config: cfg,
+		now:    time.Now,
+		state:  CircuitClosed,
+	}
+}
+
+// Allow verifica se uma requisição pode ser executada. Cada requisição permitida deve ser
+// finalizada com Success, Failure ou Release.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.expireOpen()
+	switch cb.state {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if cb.halfOpenCalls < cb.config.HalfOpenMaxCalls {
+			cb.halfOpenCalls++
+			return true
+		}
+	}
+	return false
+}
+
+// Success registra uma requisição bem-sucedida
+func (cb *CircuitBreaker) Success() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		cb.failures = 0
+	case CircuitHalfOpen:
+		cb.halfOpenSuccesses++
+		if cb.halfOpenSuccesses >= cb.config.HalfOpenMaxCalls {
+			cb.state = CircuitClosed
+			cb.failures = 0
+		}
+	}
+}
+
+// Failure registra uma falha transitória
+func (cb *CircuitBreaker) Failure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		cb.failures++
+		if cb.failures >= cb.config.MaxFailures {
+			cb.open()
+		}
+	case CircuitHalfOpen:
+		cb.open()
+	}
+}
+
+// Release devolve uma requisição permitida sem registrar resultado (ex: cancelada pelo cliente)
+func (cb *CircuitBreaker) Release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen && cb.halfOpenCalls > cb.halfOpenSuccesses {
+		cb.halfOpenCalls--
+	}
+}
+
+// State retorna o estado atual do circuit breaker
+func (cb *CircuitBreaker) State() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.expireOpen()
+	return cb.state
+}
+
+// open abre o circuito a partir do instante atual
+func (cb *CircuitBreaker) open() {
+	cb.state = CircuitOpen
+	cb.openedAt = cb.now()
+	cb.halfOpenCalls = 0
+	cb.halfOpenSuccesses = 0
+}
+
+// expireOpen passa o circuito aberto para meio-aberto após o ResetTimeout
+func (cb *CircuitBreaker) expireOpen() {
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.config.ResetTimeout {
+		cb.state = CircuitHalfOpen
+		cb.halfOpenCalls = 0
+		cb.halfOpenSuccesses = 0
+	}
+}
@@ -0,0 +1,48 @@
+package mimir
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MaxFailures: 2, ResetTimeout: time.Minute, HalfOpenMaxCalls: 2})
+	cb.now = func() time.Time { return now }
+
+	// Um sucesso zera as falhas consecutivas
+	cb.Failure()
+	cb.Success()
+	cb.Failure()
+	assert.Equal(t, CircuitClosed, cb.State())
+
+	cb.Failure()
+	assert.Equal(t, CircuitOpen, cb.State())
+	assert.False(t, cb.Allow())
+
+	// Após o ResetTimeout, até HalfOpenMaxCalls requisições de teste são permitidas
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, cb.State())
+	assert.True(t, cb.Allow())
+	assert.True(t, cb.Allow())
+	assert.False(t, cb.Allow())
+
+	// Uma requisição cancelada devolve a vaga de teste
+	cb.Release()
+	assert.True(t, cb.Allow())
+
+	cb.Success()
+	assert.Equal(t, CircuitHalfOpen, cb.State())
+	cb.Success()
+	assert.Equal(t, CircuitClosed, cb.State())
+
+	// Uma falha no estado meio-aberto reabre o circuito
+	cb.Failure()
+	cb.Failure()
+	now = now.Add(time.Minute)
+	assert.True(t, cb.Allow())
+	cb.Failure()
+	assert.Equal(t, CircuitOpen, cb.State())
+}
@@ -3,10 +3,10 @@ package mimir
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
@@ -14,32 +14,42 @@ import (
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
 )
 
-// Client é o cliente para o Mimir
+// Client é o cliente para o Mimir e demais backends compatíveis com a API HTTP do Prometheus
+// (ver MetricsBackend). Falhas transitórias (erros de rede, 5xx e 429) são repetidas com backoff
+// exponencial e contabilizadas no circuit breaker.
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	config     *ClientConfig
+	breaker    *CircuitBreaker
 }
 
 // ClientConfig contém as configurações do cliente
 type ClientConfig struct {
-	BaseURL     string
+	BaseURL string
+	// Timeout é o timeout padrão das operações sem valor em Timeouts
 	Timeout     time.Duration
 	ServiceName string
 	Namespace   string
 	OrgID       string
+	// Retry configura as novas tentativas em falhas transitórias
+	Retry RetryConfig
+	// CircuitBreaker configura a interrupção das requisições após falhas consecutivas
+	CircuitBreaker CircuitBreakerConfig
+	// Timeouts configura o timeout de cada tentativa por tipo de operação e o de conexão
+	Timeouts TimeoutConfig
+	// Backend configura o prefixo da API, o envio do OrgID e as capacidades do fornecedor;
+	// vazio usa o padrão do Mimir
+	Backend MetricsBackend
 }
 
-// QueryResponse representa a resposta de uma query do Mimir
+// QueryResponse representa a resposta de uma query do Mimir. O formato de Result depende de
+// ResultType (vector, matrix, scalar ou string) e é decodificado por decodeSeries.
 type QueryResponse struct {
 	Status string `json:"status"`
 	Data   struct {
-		ResultType string `json:"resultType"`
-		Result     []struct {
-			Metric map[string]string `json:"metric"`
-			Value  []interface{}     `json:"value"`
-			Values [][]interface{}   `json:"values,omitempty"`
-		} `json:"result"`
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
 	} `json:"data"`
 }
 
@@ -47,158 +57,135 @@ type QueryResponse struct {
 func NewClient(cfg *ClientConfig) *Client {
 	logger.Info("Criando cliente Mimir",
 		logger.NewField("base_url", cfg.BaseURL),
+		logger.NewField("backend", cfg.Backend.Type),
 		logger.NewField("service_name", cfg.ServiceName),
 		logger.NewField("namespace", cfg.Namespace),
-		logger.NewField("timeout", cfg.Timeout),
+		logger.NewField("timeout_query", cfg.Timeouts.Query),
+		logger.NewField("timeout_query_range", cfg.Timeouts.QueryRange),
+		logger.NewField("max_retries", cfg.Retry.MaxRetries),
 	)
 
+	if cfg.Backend.Type == "" {
+		cfg.Backend, _ = DefaultBackend(BackendMimir)
+	}
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 10 * time.Second
 	}
+	if cfg.Timeouts.Query <= 0 {
+		cfg.Timeouts.Query = cfg.Timeout
+	}
+	if cfg.Timeouts.QueryRange <= 0 {
+		cfg.Timeouts.QueryRange = cfg.Timeout
+	}
+	if cfg.Timeouts.Connect <= 0 {
+		cfg.Timeouts.Connect = min(5*time.Second, cfg.Timeout)
+	}
+	if cfg.Retry.InitialBackoff <= 0 {
+		cfg.Retry.InitialBackoff = time.Second
+	}
+	if cfg.Retry.MaxBackoff <= 0 {
+		cfg.Retry.MaxBackoff = 10 * time.Second
+	}
+
+	// O timeout de cada tentativa vem do contexto (Timeouts.Query e Timeouts.QueryRange); o
+	// transporte limita apenas o estabelecimento da conexão
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   cfg.Timeouts.Connect,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = cfg.Timeouts.Connect
 
 	return &Client{
 		baseURL: cfg.BaseURL,
 		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
+			Transport: transport,
 		},
-		config: cfg,
+		config:  cfg,
+		breaker: NewCircuitBreaker(cfg.CircuitBreaker),
 	}
 }
 
-func parseValue(value string) (float64, error) {
-	if value == "NaN" || value == "Inf" || value == "-Inf" || value == "" {
-		return 0, nil
-	}
-	var floatValue float64
-	_, err := fmt.Sscanf(value, "%f", &floatValue)
+// CircuitBreakerState retorna o estado do circuit breaker (closed, open ou half-open)
+func (c *Client) CircuitBreakerState() string {
+	return c.breaker.State()
+}
+
+// Query executa uma query instantânea no Mimir e retorna a primeira amostra da primeira série.
+// Sem resultado, retorna uma amostra Missing. Para consultas com várias séries (ex: by (pod)),
+// use QuerySeries.
+func (c *Client) Query(ctx context.Context, query string) (*types.QueryResult, error) {
+	result, err := c.QuerySeries(ctx, query)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	if floatValue != floatValue || floatValue < 0 || floatValue > 1e6 { // Verifica NaN e valores absurdos
-		return 0, nil
+
+	if len(result.Series) == 0 || len(result.Series[0].Samples) == 0 {
+		logger.Info("No results found")
+		return &types.QueryResult{
+			Value:     0,
+			Timestamp: time.Now(),
+			Missing:   true,
+		}, nil
 	}
-	return floatValue, nil
+
+	sample := result.Series[0].Samples[0]
+	logger.Info("Query executed successfully",
+		logger.NewField("query", query),
+		logger.NewField("value", sample.Value),
+		logger.NewField("missing", sample.Missing),
+	)
+	return &sample, nil
 }
 
-// Query executa uma query instantânea no Mimir
-func (c *Client) Query(ctx context.Context, query string) (*types.QueryResult, error) {
+// QuerySeries executa uma query instantânea no Mimir e retorna todas as séries com os seus labels
+func (c *Client) QuerySeries(ctx context.Context, query string) (*types.SeriesResult, error) {
 	logger.Info("Executing instant query",
 		logger.NewField("base_url", c.baseURL),
 		logger.NewField("query", query),
 	)
 
-	u, err := url.Parse(c.baseURL + "/prometheus/api/v1/query")
+	u, err := c.endpoint("query")
 	if err != nil {
-		logger.Error("Failed to parse URL", err,
-			logger.NewField("url", c.baseURL),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to parse URL")
+		return nil, err
 	}
 
 	q := u.Query()
 	q.Set("query", query)
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		logger.Error("Failed to create request", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to create request")
-	}
-
-	req.Header.Set("X-Scope-OrgID", c.config.OrgID)
-	logger.Info("Sending request",
-		logger.NewField("url", req.URL.String()),
-		logger.NewField("org_id", c.config.OrgID),
-	)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		logger.Error("Failed to execute request", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to execute request")
-	}
-	defer resp.Body.Close()
+	return c.fetchSeries(ctx, u, c.config.Timeouts.Query)
+}
 
-	body, err := io.ReadAll(resp.Body)
+// QueryRange executa uma query de intervalo no Mimir e retorna as amostras da primeira série.
+// Para consultas com várias séries (ex: by (pod)), use QueryRangeSeries.
+func (c *Client) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.QueryRangeResult, error) {
+	result, err := c.QueryRangeSeries(ctx, query, start, end, step)
 	if err != nil {
-		logger.Error("Failed to read response", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to read response")
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		logger.Error("Unexpected status code", nil,
-			logger.NewField("status_code", resp.StatusCode),
-			logger.NewField("body", string(body)),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "unexpected status code")
-	}
-
-	var queryResp QueryResponse
-	if err := json.Unmarshal(body, &queryResp); err != nil {
-		logger.Error("Failed to unmarshal response", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to unmarshal response")
-	}
-
-	if queryResp.Status != "success" {
-		logger.Error("Query failed", nil,
-			logger.NewField("status", queryResp.Status),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "query failed")
+		return nil, err
 	}
 
-	if len(queryResp.Data.Result) == 0 {
+	values := []types.QueryResult{}
+	if len(result.Series) == 0 {
 		logger.Info("No results found")
-		return &types.QueryResult{
-			Value:     0,
-			Timestamp: time.Now(),
-		}, nil
-	}
-
-	// Extrai o valor e timestamp
-	result := queryResp.Data.Result[0]
-	if len(result.Value) != 2 {
-		logger.Error("Unexpected value format", nil,
-			logger.NewField("value", result.Value),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "unexpected value format")
-	}
-
-	timestamp, ok := result.Value[0].(float64)
-	if !ok {
-		logger.Error("Invalid timestamp format", nil,
-			logger.NewField("timestamp", result.Value[0]),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "invalid timestamp format")
+	} else {
+		values = result.Series[0].Samples
 	}
 
-	value, ok := result.Value[1].(string)
-	if !ok {
-		logger.Error("Invalid value format", nil,
-			logger.NewField("value", result.Value[1]),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "invalid value format")
-	}
-
-	floatValue, err := parseValue(value)
-	if err != nil {
-		logger.Error("Failed to parse value", err,
-			logger.NewField("value", value),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to parse value")
-	}
-
-	logger.Info("Query executed successfully",
-		logger.NewField("query", query),
-		logger.NewField("value", floatValue),
+	logger.Info("Range query executed successfully",
+		logger.NewField("series_count", len(result.Series)),
+		logger.NewField("values_count", len(values)),
 	)
 
-	return &types.QueryResult{
-		Value:     floatValue,
-		Timestamp: time.Unix(int64(timestamp), 0),
+	return &types.QueryRangeResult{
+		Values:    values,
+		StartTime: start,
+		EndTime:   end,
 	}, nil
 }
 
-// QueryRange executa uma query de intervalo no Mimir
-func (c *Client) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.QueryRangeResult, error) {
+// QueryRangeSeries executa uma query de intervalo no Mimir e retorna todas as séries com os seus labels
+func (c *Client) QueryRangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) (*types.SeriesResult, error) {
 	logger.Info("Executing range query",
 		logger.NewField("base_url", c.baseURL),
 		logger.NewField("query", query),
@@ -207,12 +194,9 @@ func (c *Client) QueryRange(ctx context.Context, query string, start, end time.T
 		logger.NewField("step", step),
 	)
 
-	u, err := url.Parse(c.baseURL + "/prometheus/api/v1/query_range")
+	u, err := c.endpoint("query_range")
 	if err != nil {
-		logger.Error("Failed to parse URL", err,
-			logger.NewField("url", c.baseURL),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to parse URL")
+		return nil, err
 	}
 
 	q := u.Query()
@@ -222,37 +206,26 @@ func (c *Client) QueryRange(ctx context.Context, query string, start, end time.T
 	q.Set("step", step.String())
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		logger.Error("Failed to create request", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to create request")
-	}
-
-	req.Header.Set("X-Scope-OrgID", c.config.OrgID)
-	logger.Info("Sending request",
-		logger.NewField("url", req.URL.String()),
-		logger.NewField("org_id", c.config.OrgID),
-	)
+	return c.fetchSeries(ctx, u, c.config.Timeouts.QueryRange)
+}
 
-	resp, err := c.httpClient.Do(req)
+// endpoint retorna a URL de um endpoint da API (ex: query), sob o prefixo do backend
+func (c *Client) endpoint(name string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSuffix(c.baseURL, "/") + c.config.Backend.path(name))
 	if err != nil {
-		logger.Error("Failed to execute request", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to execute request")
+		logger.Error("Failed to parse URL", err,
+			logger.NewField("url", c.baseURL),
+		)
+		return nil, errors.NewInvalidConfigurationError("mimir", "failed to parse URL")
 	}
-	defer resp.Body.Close()
+	return u, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// fetchSeries executa a query da URL e decodifica as séries da resposta
+func (c *Client) fetchSeries(ctx context.Context, u *url.URL, timeout time.Duration) (*types.SeriesResult, error) {
+	body, err := c.get(ctx, u, timeout)
 	if err != nil {
-		logger.Error("Failed to read response", err)
-		return nil, errors.NewInvalidConfigurationError("mimir", "failed to read response")
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		logger.Error("Unexpected status code", nil,
-			logger.NewField("status_code", resp.StatusCode),
-			logger.NewField("body", string(body)),
-		)
-		return nil, errors.NewInvalidConfigurationError("mimir", "unexpected status code")
+		return nil, err
 	}
 
 	var queryResp QueryResponse
@@ -268,70 +241,19 @@ func (c *Client) QueryRange(ctx context.Context, query string, start, end time.T
 		return nil, errors.NewInvalidConfigurationError("mimir", "query failed")
 	}
 
-	if len(queryResp.Data.Result) == 0 {
-		logger.Info("No results found")
-		return &types.QueryRangeResult{
-			Values:    []types.QueryResult{},
-			StartTime: start,
-			EndTime:   end,
-		}, nil
+	result, err := decodeSeries(queryResp.Data.ResultType, queryResp.Data.Result)
+	if err != nil {
+		logger.Error("Failed to decode result", err,
+			logger.NewField("result_type", queryResp.Data.ResultType),
+		)
+		return nil, errors.NewInvalidConfigurationError("mimir", "unexpected result format")
 	}
 
 	logger.Info("Processing results",
-		logger.NewField("count", len(queryResp.Data.Result)),
+		logger.NewField("result_type", result.ResultType),
+		logger.NewField("count", len(result.Series)),
 	)
-
-	// Processa os valores
-	result := queryResp.Data.Result[0]
-	values := make([]types.QueryResult, 0, len(result.Values))
-
-	for _, v := range result.Values {
-		if len(v) != 2 {
-			logger.Error("Unexpected value format", nil,
-				logger.NewField("value", v),
-			)
-			continue
-		}
-
-		timestamp, ok := v[0].(float64)
-		if !ok {
-			logger.Error("Invalid timestamp format", nil,
-				logger.NewField("timestamp", v[0]),
-			)
-			continue
-		}
-
-		value, ok := v[1].(string)
-		if !ok {
-			logger.Error("Invalid value format", nil,
-				logger.NewField("value", v[1]),
-			)
-			continue
-		}
-
-		floatValue, err := parseValue(value)
-		if err != nil {
-			logger.Error("Failed to parse value", err,
-				logger.NewField("value", value),
-			)
-			continue
-		}
-
-		values = append(values, types.QueryResult{
-			Value:     floatValue,
-			Timestamp: time.Unix(int64(timestamp), 0),
-		})
-	}
-
-	logger.Info("Range query executed successfully",
-		logger.NewField("values_count", len(values)),
-	)
-
-	return &types.QueryRangeResult{
-		Values:    values,
-		StartTime: start,
-		EndTime:   end,
-	}, nil
+	return result, nil
 }
 
 // CheckConnection verifica a conexão com o Mimir
@@ -340,37 +262,19 @@ func (c *Client) CheckConnection(ctx context.Context) error {
 		logger.NewField("base_url", c.baseURL),
 	)
 
-	u, err := url.Parse(c.baseURL + "/prometheus/api/v1/query")
+	u, err := c.endpoint("query")
 	if err != nil {
-		logger.Error("Failed to parse URL", err,
-			logger.NewField("base_url", c.baseURL),
-		)
-		return errors.NewInvalidConfigurationError("mimir", "failed to parse URL")
+		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		logger.Error("Failed to create request", err,
-			logger.NewField("url", u.String()),
-		)
-		return errors.NewInvalidConfigurationError("mimir", "failed to create request")
-	}
-
-	req.Header.Set("X-Scope-OrgID", c.config.OrgID)
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		logger.Error("Failed to execute request", err,
-			logger.NewField("url", req.URL.String()),
-		)
-		return errors.NewInvalidConfigurationError("mimir", "failed to execute request")
-	}
-	defer resp.Body.Close()
+	q := u.Query()
+	q.Set("query", "vector(1)")
+	u.RawQuery = q.Encode()
 
-	if resp.StatusCode != http.StatusOK {
-		logger.Error("Unexpected status code", nil,
-			logger.NewField("status_code", resp.StatusCode),
-		)
-		return errors.NewInvalidConfigurationError("mimir", "unexpected status code")
+	// O health check faz uma única tentativa, sem retry nem circuit breaker, para refletir o
+	// estado atual do Mimir
+	if _, _, err := c.do(ctx, u, c.config.Timeouts.Query); err != nil {
+		return err
 	}
 
 	logger.Info("Successfully connected to Mimir")
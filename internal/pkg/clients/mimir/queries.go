@@ -10,11 +10,18 @@ const (
 	// Formato da query para uso de memória por pod
 	podMemoryUsageQuery = `container_memory_working_set_bytes{container!="POD",container!="",pod=~"%s.*"}`
 
+	// Formato da série com valor 1 por pod de um deployment, resolvida pelos owner references do
+	// kube-state-metrics (Deployment → ReplicaSet → Pod)
+	deploymentPodsQuery = `max by (namespace, pod) (label_replace(kube_pod_owner{namespace="%[1]s",owner_kind="ReplicaSet"}, "replicaset", "$1", "owner_name", "(.*)") * on (namespace, replicaset) group_left max by (namespace, replicaset) (kube_replicaset_owner{namespace="%[1]s",owner_kind="Deployment",owner_name="%[2]s"}))`
+
 	// Formato da query para uso de CPU por deployment (taxa de 5 minutos)
-	deploymentCPUUsageQuery = `sum(rate(container_cpu_usage_seconds_total{container!="POD",container!="",namespace="%s",pod=~"%s.*"}[5m])) by (pod)`
+	deploymentCPUUsageQuery = `sum(rate(container_cpu_usage_seconds_total{container!="POD",container!="",namespace="%s"}[5m]) * on (namespace, pod) group_left %s) by (pod)`
 
 	// Formato da query para uso de memória por deployment
-	deploymentMemoryUsageQuery = `sum(container_memory_working_set_bytes{container!="POD",container!="",namespace="%s",pod=~"%s.*"}) by (pod)`
+	deploymentMemoryUsageQuery = `sum(container_memory_working_set_bytes{container!="POD",container!="",namespace="%s"} * on (namespace, pod) group_left %s) by (pod)`
+
+	// Formato da query para o percentual de períodos do CFS com throttling por container de um deployment
+	deploymentCPUThrottlingQuery = `sum(rate(container_cpu_cfs_throttled_periods_total{container!="POD",container!="",namespace="%[1]s"}[5m]) * on (namespace, pod) group_left %[2]s) by (container) / sum(rate(container_cpu_cfs_periods_total{container!="POD",container!="",namespace="%[1]s"}[5m]) * on (namespace, pod) group_left %[2]s) by (container) * 100`
 )
 
 // GetPodCPUUsageQuery retorna a query para uso de CPU de um pod
@@ -29,10 +36,20 @@ func GetPodMemoryUsageQuery(podNamePrefix string) string {
 
 // GetDeploymentCPUUsageQuery retorna a query para uso de CPU de um deployment
 func GetDeploymentCPUUsageQuery(namespace, deploymentName string) string {
-	return fmt.Sprintf(deploymentCPUUsageQuery, namespace, deploymentName)
+	return fmt.Sprintf(deploymentCPUUsageQuery, namespace, deploymentPods(namespace, deploymentName))
 }
 
 // GetDeploymentMemoryUsageQuery retorna a query para uso de memória de um deployment
 func GetDeploymentMemoryUsageQuery(namespace, deploymentName string) string {
-	return fmt.Sprintf(deploymentMemoryUsageQuery, namespace, deploymentName)
+	return fmt.Sprintf(deploymentMemoryUsageQuery, namespace, deploymentPods(namespace, deploymentName))
+}
+
+// GetDeploymentCPUThrottlingQuery retorna a query para o percentual de throttling de CPU por container de um deployment
+func GetDeploymentCPUThrottlingQuery(namespace, deploymentName string) string {
+	return fmt.Sprintf(deploymentCPUThrottlingQuery, namespace, deploymentPods(namespace, deploymentName))
+}
+
+// deploymentPods retorna a série dos pods de um deployment, usada para restringir as queries
+func deploymentPods(namespace, deploymentName string) string {
+	return fmt.Sprintf(deploymentPodsQuery, namespace, deploymentName)
 }
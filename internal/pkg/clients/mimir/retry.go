@@ -0,0 +1,155 @@
+package mimir
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// get executa uma requisição GET ao Mimir e retorna o corpo da resposta. Cada tentativa é limitada
+// por timeout. Falhas transitórias são repetidas até Retry.MaxRetries vezes com backoff exponencial
+// e jitter, desde que a espera caiba no prazo do contexto; com o circuit breaker aberto, a
+// requisição falha imediatamente.
+func (c *Client) get(ctx context.Context, u *url.URL, timeout time.Duration) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if !c.breaker.Allow() {
+			logger.Warn("Circuit breaker open, skipping request",
+				logger.NewField("url", u.String()),
+			)
+			return nil, errors.NewUnavailableMetricsError("mimir", "circuit breaker open")
+		}
+
+		body, retryable, err := c.do(ctx, u, timeout)
+		switch {
+		case err == nil:
+			c.breaker.Success()
+			return body, nil
+		case ctx.Err() != nil:
+			// Requisições canceladas pelo cliente não indicam indisponibilidade do Mimir
+			c.breaker.Release()
+			return nil, err
+		case !retryable:
+			// O Mimir respondeu; falhas permanentes (ex: query inválida) não abrem o circuito
+			c.breaker.Success()
+			return nil, err
+		}
+
+		c.breaker.Failure()
+		if attempt >= c.config.Retry.MaxRetries {
+			return nil, err
+		}
+
+		wait := c.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+			// Sem prazo para outra tentativa, falha agora em vez de esperar o fim do prazo
+			return nil, err
+		}
+		logger.Warn("Retrying request",
+			logger.NewField("url", u.String()),
+			logger.NewField("attempt", attempt+1),
+			logger.NewField("backoff", wait),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		}
+	}
+}
+
+// do executa uma única requisição GET ao Mimir, limitada por timeout. O retorno retryable indica
+// se a falha é transitória: erros de rede, timeouts, falhas na leitura da resposta, 5xx e 429.
+func (c *Client) do(ctx context.Context, u *url.URL, timeout time.Duration) (body []byte, retryable bool, err error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		logger.Error("Failed to create request", err,
+			logger.NewField("url", u.String()),
+		)
+		return nil, false, errors.NewInvalidConfigurationError("mimir", "failed to create request")
+	}
+
+	c.config.Backend.applyTenant(req, c.config.OrgID)
+	logger.Info("Sending request",
+		logger.NewField("url", req.URL.String()),
+		logger.NewField("org_id", c.config.OrgID),
+		logger.NewField("tenant_mode", c.config.Backend.TenantMode),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.Error("Failed to execute request", err,
+			logger.NewField("url", req.URL.String()),
+		)
+		if timeoutErr := deadlineError(ctx, attemptCtx, timeout); timeoutErr != nil {
+			return nil, true, timeoutErr
+		}
+		return nil, true, errors.NewUnavailableMetricsError("mimir", "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("Failed to read response", err)
+		if timeoutErr := deadlineError(ctx, attemptCtx, timeout); timeoutErr != nil {
+			return nil, true, timeoutErr
+		}
+		return nil, true, errors.NewUnavailableMetricsError("mimir", "failed to read response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("Unexpected status code", nil,
+			logger.NewField("status_code", resp.StatusCode),
+			logger.NewField("body", string(body)),
+		)
+		if isRetryableStatus(resp.StatusCode) {
+			return nil, true, errors.NewUnavailableMetricsError("mimir", "unexpected status code")
+		}
+		return nil, false, errors.NewInvalidConfigurationError("mimir", "unexpected status code")
+	}
+
+	return body, false, nil
+}
+
+// deadlineError retorna o erro de uma tentativa interrompida pelo prazo da requisição ou pelo
+// timeout da operação, ou nil quando a falha não foi causada por prazo
+func deadlineError(ctx, attemptCtx context.Context, timeout time.Duration) error {
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return errors.NewUnavailableMetricsError("mimir", "request deadline exceeded")
+	case attemptCtx.Err() == context.DeadlineExceeded:
+		return errors.NewUnavailableMetricsError("mimir", fmt.Sprintf("query timed out after %s", timeout))
+	}
+	return nil
+}
+
+// isRetryableStatus verifica se o status HTTP indica uma falha transitória
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// backoff retorna a espera antes da próxima tentativa: InitialBackoff dobrado a cada tentativa,
+// limitado a MaxBackoff, com jitter entre metade e o valor cheio para espalhar as tentativas
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.config.Retry.MaxBackoff
+	if attempt < 32 {
+		wait = min(c.config.Retry.InitialBackoff<<attempt, wait)
+	}
+	if wait <= 0 {
+		return c.config.Retry.MaxBackoff
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
@@ -0,0 +1,144 @@
+package mimir
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const vectorResponse = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1613765411,"42"]}]}}`
+
+func newTestClient(url string, maxRetries int, cb CircuitBreakerConfig) *Client {
+	return NewClient(&ClientConfig{
+		BaseURL:        url,
+		Retry:          RetryConfig{MaxRetries: maxRetries, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+		CircuitBreaker: cb,
+	})
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(vectorResponse))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 3, CircuitBreakerConfig{})
+	result, err := client.Query(context.Background(), "up")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), result.Value)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, CircuitClosed, client.CircuitBreakerState())
+}
+
+func TestClientDoesNotRetryPermanentFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 3, CircuitBreakerConfig{MaxFailures: 1})
+	_, err := client.Query(context.Background(), "up{")
+
+	assert.True(t, errors.IsInvalidConfiguration(err))
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, CircuitClosed, client.CircuitBreakerState(), "falhas permanentes não abrem o circuito")
+}
+
+func TestClientOpensCircuitAfterConsecutiveFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 1, CircuitBreakerConfig{MaxFailures: 3, ResetTimeout: time.Hour})
+
+	_, err := client.Query(context.Background(), "up")
+	assert.True(t, errors.IsUnavailableMetrics(err))
+	assert.Equal(t, int32(2), calls.Load())
+
+	// A terceira falha abre o circuito e a tentativa seguinte não é enviada
+	_, err = client.QueryRange(context.Background(), "up", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+	assert.True(t, errors.IsUnavailableMetrics(err))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, CircuitOpen, client.CircuitBreakerState())
+
+	_, err = client.Query(context.Background(), "up")
+	assert.True(t, errors.IsUnavailableMetrics(err))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestBackoff(t *testing.T) {
+	client := NewClient(&ClientConfig{Retry: RetryConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}})
+
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		wait := client.backoff(attempt)
+		assert.GreaterOrEqual(t, wait, expected/2)
+		assert.LessOrEqual(t, wait, expected)
+	}
+}
+
+func TestClientQueryTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(&ClientConfig{
+		BaseURL:  server.URL,
+		Timeouts: TimeoutConfig{QueryRange: 20 * time.Millisecond},
+	})
+
+	started := time.Now()
+	_, err := client.QueryRange(context.Background(), "up", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+
+	assert.True(t, errors.IsUnavailableMetrics(err))
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func TestClientSkipsRetryBeyondDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(&ClientConfig{
+		BaseURL: server.URL,
+		Retry:   RetryConfig{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	started := time.Now()
+	_, err := client.Query(ctx, "up")
+
+	assert.True(t, errors.IsUnavailableMetrics(err))
+	assert.Equal(t, int32(1), calls.Load(), "a espera do retry não cabe no prazo")
+	assert.Less(t, time.Since(started), time.Second)
+}
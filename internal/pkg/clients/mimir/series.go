@@ -0,0 +1,132 @@
+package mimir
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
+)
+
+// decodeSeries decodifica o resultado de uma query conforme o tipo. Vetores têm uma amostra por
+// série e matrizes uma série temporal por série; amostras inválidas de matrizes são descartadas.
+// Escalares e strings viram uma única série sem labels.
+func decodeSeries(resultType string, raw json.RawMessage) (*types.SeriesResult, error) {
+	result := &types.SeriesResult{
+		ResultType: resultType,
+		Series:     []types.Series{},
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return result, nil
+	}
+
+	switch resultType {
+	case types.ResultTypeVector:
+		var vector []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &vector); err != nil {
+			return nil, err
+		}
+		for _, item := range vector {
+			sample, err := parseSample(item.Value)
+			if err != nil {
+				return nil, err
+			}
+			result.Series = append(result.Series, types.Series{
+				Labels:  item.Metric,
+				Samples: []types.QueryResult{sample},
+			})
+		}
+
+	case types.ResultTypeMatrix:
+		var matrix []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]interface{}   `json:"values"`
+		}
+		if err := json.Unmarshal(raw, &matrix); err != nil {
+			return nil, err
+		}
+		for _, item := range matrix {
+			samples := make([]types.QueryResult, 0, len(item.Values))
+			for _, v := range item.Values {
+				sample, err := parseSample(v)
+				if err != nil {
+					logger.Error("Invalid sample", err,
+						logger.NewField("value", v),
+					)
+					continue
+				}
+				samples = append(samples, sample)
+			}
+			result.Series = append(result.Series, types.Series{
+				Labels:  item.Metric,
+				Samples: samples,
+			})
+		}
+
+	case types.ResultTypeScalar:
+		var scalar []interface{}
+		if err := json.Unmarshal(raw, &scalar); err != nil {
+			return nil, err
+		}
+		sample, err := parseSample(scalar)
+		if err != nil {
+			return nil, err
+		}
+		result.Series = append(result.Series, types.Series{Samples: []types.QueryResult{sample}})
+
+	case types.ResultTypeString:
+		var str []interface{}
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, err
+		}
+		if len(str) != 2 {
+			return nil, fmt.Errorf("unexpected string format: %v", str)
+		}
+		text, ok := str[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid string value: %v", str[1])
+		}
+		result.Series = append(result.Series, types.Series{Text: text})
+
+	default:
+		return nil, fmt.Errorf("unsupported result type: %q", resultType)
+	}
+
+	return result, nil
+}
+
+// parseSample converte um par [timestamp, "valor"] da API em uma amostra, sem perda de precisão
+// no valor nem no timestamp (milissegundos). NaN, inclusive stale markers, e ±Inf são mantidos
+// em Value e marcados como Missing.
+func parseSample(pair []interface{}) (types.QueryResult, error) {
+	if len(pair) != 2 {
+		return types.QueryResult{}, fmt.Errorf("unexpected value format: %v", pair)
+	}
+
+	timestamp, ok := pair[0].(float64)
+	if !ok {
+		return types.QueryResult{}, fmt.Errorf("invalid timestamp format: %v", pair[0])
+	}
+
+	value, ok := pair[1].(string)
+	if !ok {
+		return types.QueryResult{}, fmt.Errorf("invalid value format: %v", pair[1])
+	}
+
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return types.QueryResult{}, fmt.Errorf("failed to parse value %q: %w", value, err)
+	}
+
+	return types.QueryResult{
+		Value:     floatValue,
+		Timestamp: time.UnixMilli(int64(math.Round(timestamp * 1000))),
+		Missing:   math.IsNaN(floatValue) || math.IsInf(floatValue, 0),
+	}, nil
+}
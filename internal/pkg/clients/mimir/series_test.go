@@ -0,0 +1,125 @@
+package mimir
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSeries(t *testing.T) {
+	tests := []struct {
+		name       string
+		resultType string
+		raw        string
+		expected   []types.Series
+		wantErr    bool
+	}{
+		{
+			name:       "vector com várias séries",
+			resultType: types.ResultTypeVector,
+			raw:        `[{"metric":{"pod":"api-1"},"value":[1700000000,"1.5"]},{"metric":{"pod":"api-2"},"value":[1700000000,"2"]}]`,
+			expected: []types.Series{
+				{Labels: map[string]string{"pod": "api-1"}, Samples: []types.QueryResult{{Value: 1.5, Timestamp: time.Unix(1700000000, 0)}}},
+				{Labels: map[string]string{"pod": "api-2"}, Samples: []types.QueryResult{{Value: 2, Timestamp: time.Unix(1700000000, 0)}}},
+			},
+		},
+		{
+			name:       "matrix descarta amostras inválidas",
+			resultType: types.ResultTypeMatrix,
+			raw:        `[{"metric":{"container":"app"},"values":[[1700000000,"1"],[1700000300,2],[1700000600,"3"]]}]`,
+			expected: []types.Series{
+				{Labels: map[string]string{"container": "app"}, Samples: []types.QueryResult{
+					{Value: 1, Timestamp: time.Unix(1700000000, 0)},
+					{Value: 3, Timestamp: time.Unix(1700000600, 0)},
+				}},
+			},
+		},
+		{
+			name:       "matrix mantém valores grandes e marca lacunas como ausentes",
+			resultType: types.ResultTypeMatrix,
+			raw:        `[{"metric":{"container":"app"},"values":[[1700000000.5,"2147483648"],[1700000300,"+Inf"],[1700000600,"-0.25"]]}]`,
+			expected: []types.Series{
+				{Labels: map[string]string{"container": "app"}, Samples: []types.QueryResult{
+					{Value: 2147483648, Timestamp: time.UnixMilli(1700000000500)},
+					{Value: math.Inf(1), Timestamp: time.Unix(1700000300, 0), Missing: true},
+					{Value: -0.25, Timestamp: time.Unix(1700000600, 0)},
+				}},
+			},
+		},
+		{
+			name:       "scalar",
+			resultType: types.ResultTypeScalar,
+			raw:        `[1700000000,"42"]`,
+			expected:   []types.Series{{Samples: []types.QueryResult{{Value: 42, Timestamp: time.Unix(1700000000, 0)}}}},
+		},
+		{
+			name:       "string",
+			resultType: types.ResultTypeString,
+			raw:        `[1700000000,"ok"]`,
+			expected:   []types.Series{{Text: "ok"}},
+		},
+		{
+			name:       "vector vazio",
+			resultType: types.ResultTypeVector,
+			raw:        `[]`,
+			expected:   []types.Series{},
+		},
+		{
+			name:       "tipo desconhecido",
+			resultType: "histogram",
+			raw:        `[]`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := decodeSeries(tt.resultType, json.RawMessage(tt.raw))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.resultType, result.ResultType)
+			assert.Equal(t, tt.expected, result.Series)
+		})
+	}
+}
+
+func TestParseSample(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		missing bool
+		wantErr bool
+	}{
+		{name: "bytes acima de 1e6", value: "5368709120"},
+		{name: "notação científica", value: "1.5e+09"},
+		{name: "negativo", value: "-3"},
+		{name: "NaN", value: "NaN", missing: true},
+		{name: "-Inf", value: "-Inf", missing: true},
+		{name: "vazio", value: "", wantErr: true},
+		{name: "inválido", value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := parseSample([]interface{}{float64(1700000000), tt.value})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.missing, sample.Missing)
+			if !tt.missing {
+				expected, _ := strconv.ParseFloat(tt.value, 64)
+				assert.Equal(t, expected, sample.Value)
+			}
+		})
+	}
+}
@@ -3,25 +3,49 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/domain/errors"
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/mimir"
 	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/logger"
 	"github.com/joho/godotenv"
 )
 
 // Config contém todas as configurações da aplicação
 type Config struct {
-	Server  ServerConfig
-	Logging LoggingConfig
-	Mimir   MimirConfig
-	K8s     K8sConfig
-	Pricing PricingConfig
+	Server   ServerConfig
+	Logging  LoggingConfig
+	Mimir    MimirConfig
+	K8s      K8sConfig
+	Pricing  PricingConfig
+	Analyzer AnalyzerConfig
+	// Clusters são os clusters analisados; sem CLUSTERS, um único cluster com as configurações de K8s e Mimir
+	Clusters []ClusterConfig
+	// DefaultCluster é o cluster usado quando a requisição não informa o parâmetro cluster
+	DefaultCluster string
 }
 
+// ServerWriteTimeout é o WriteTimeout mínimo do servidor HTTP; o prazo das requisições deve ser
+// menor para que falhas de timeout cheguem ao cliente como resposta de erro
+const ServerWriteTimeout = 2 * time.Minute
+
 type ServerConfig struct {
 	Port    string
 	GinMode string
+	// RequestTimeout é o prazo de cada requisição, dividido entre as etapas da análise; zero
+	// desativa o prazo
+	RequestTimeout time.Duration
+	// AggregateRequestTimeout é o prazo das análises agregadas (namespace, cluster e nodes), que
+	// analisam vários workloads; zero desativa o prazo
+	AggregateRequestTimeout time.Duration
+}
+
+// WriteTimeout retorna o WriteTimeout do servidor HTTP: ServerWriteTimeout ou, se maior, o
+// prazo das análises agregadas com a mesma folga que ServerWriteTimeout dá a REQUEST_TIMEOUT
+// padrão (30s)
+func (s ServerConfig) WriteTimeout() time.Duration {
+	return max(ServerWriteTimeout, s.AggregateRequestTimeout+30*time.Second)
 }
 
 type LoggingConfig struct {
@@ -45,11 +69,32 @@ type MimirConfig struct {
 	CBMaxFailures  int
 	CBResetTimeout time.Duration
 	CBHalfOpenMax  int
+	// Backend é o backend de métricas padrão dos clusters (mimir, prometheus, thanos ou
+	// victoriametrics), com prefixo da API e modo de tenant opcionais
+	Backend mimir.BackendConfig
 }
 
 type K8sConfig struct {
 	KubeconfigPath string
 	InCluster      bool
+	// CacheResync é o intervalo de resincronização do cache de informers
+	CacheResync time.Duration
+}
+
+// ClusterConfig descreve como acessar um cluster e o tenant do Mimir com as suas métricas
+type ClusterConfig struct {
+	Name string
+	// KubeconfigPath aponta para o kubeconfig do cluster (por exemplo, montado a partir de um Secret)
+	KubeconfigPath string
+	// Context é o contexto do kubeconfig; vazio usa o contexto atual
+	Context   string
+	InCluster bool
+	// MimirOrgID é o tenant do Mimir com as métricas do cluster
+	MimirOrgID string
+	// MetricsURL é a URL base do backend de métricas do cluster
+	MetricsURL string
+	// MetricsBackend é o backend de métricas do cluster
+	MetricsBackend mimir.BackendConfig
 }
 
 type PricingConfig struct {
@@ -57,6 +102,26 @@ type PricingConfig struct {
 	Timeout     time.Duration
 }
 
+type AnalyzerConfig struct {
+	MaxConcurrency int
+	GroupLabel     string
+	TopOffenders   int
+	// HPAUtilizationCeiling é o teto de utilização de CPU (percentual) da recomendação de HPA
+	HPAUtilizationCeiling int
+	// ThrottlingThreshold é o percentual de períodos do CFS com throttling que gera alerta
+	ThrottlingThreshold int
+	// CPULimitPolicy define a recomendação para limits de CPU com throttling (keep ou remove)
+	CPULimitPolicy string
+	// CPULimitRatio e MemoryLimitRatio são as razões limit/request dos limits recomendados
+	// (0 preserva a razão atual de cada container)
+	CPULimitRatio    float64
+	MemoryLimitRatio float64
+	// QuotaHeadroom é a folga (percentual) sobre o uso máximo do namespace nas quotas recomendadas
+	QuotaHeadroom int
+	// WorkloadTimeout é o prazo da análise de cada workload nas análises agregadas
+	WorkloadTimeout time.Duration
+}
+
 // LoadConfig carrega e valida todas as configurações
 func LoadConfig() (*Config, error) {
 	// Carrega o ambiente correto
@@ -80,8 +145,10 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:    getEnvOrDefault("PORT", "9000"),
-			GinMode: getEnvOrDefault("GIN_MODE", "debug"),
+			Port:                    getEnvOrDefault("PORT", "9000"),
+			GinMode:                 getEnvOrDefault("GIN_MODE", "debug"),
+			RequestTimeout:          getEnvAsDurationOrDefault("REQUEST_TIMEOUT", 90*time.Second),
+			AggregateRequestTimeout: getEnvAsDurationOrDefault("AGGREGATE_REQUEST_TIMEOUT", 10*time.Minute),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnvOrDefault("LOG_LEVEL", "info"),
@@ -103,17 +170,40 @@ func LoadConfig() (*Config, error) {
 			CBMaxFailures:  getEnvAsIntOrDefault("MIMIR_CB_MAX_FAILURES", 5),
 			CBResetTimeout: getEnvAsDurationOrDefault("MIMIR_CB_RESET_TIMEOUT", 60*time.Second),
 			CBHalfOpenMax:  getEnvAsIntOrDefault("MIMIR_CB_HALF_OPEN_MAX", 2),
+			Backend: mimir.BackendConfig{
+				Type:         getEnvOrDefault("METRICS_BACKEND", mimir.BackendMimir),
+				APIPrefix:    getEnvOrDefault("METRICS_API_PREFIX", ""),
+				TenantMode:   getEnvOrDefault("METRICS_TENANT_MODE", ""),
+				TenantHeader: getEnvOrDefault("METRICS_TENANT_HEADER", ""),
+				TenantLabel:  getEnvOrDefault("METRICS_TENANT_LABEL", ""),
+			},
 		},
 		K8s: K8sConfig{
 			KubeconfigPath: getKubeconfigPath(),
 			InCluster:      getEnvOrDefault("IN_CLUSTER", "false") == "true",
+			CacheResync:    getEnvAsDurationOrDefault("K8S_CACHE_RESYNC", 10*time.Minute),
 		},
 		Pricing: PricingConfig{
 			ExchangeURL: getEnvOrDefault("EXCHANGE_URL", "https://api.exchangerate.host"),
 			Timeout:     30 * time.Second,
 		},
+		Analyzer: AnalyzerConfig{
+			MaxConcurrency:        getEnvAsIntOrDefault("ANALYZER_MAX_CONCURRENCY", 4),
+			GroupLabel:            getEnvOrDefault("ANALYZER_GROUP_LABEL", "team"),
+			TopOffenders:          getEnvAsIntOrDefault("ANALYZER_TOP_OFFENDERS", 10),
+			HPAUtilizationCeiling: getEnvAsIntOrDefault("ANALYZER_HPA_UTILIZATION_CEILING", 90),
+			ThrottlingThreshold:   getEnvAsIntOrDefault("ANALYZER_THROTTLING_THRESHOLD", 25),
+			CPULimitPolicy:        getEnvOrDefault("ANALYZER_CPU_LIMIT_POLICY", "keep"),
+			CPULimitRatio:         getEnvAsFloatOrDefault("ANALYZER_CPU_LIMIT_RATIO", 0),
+			MemoryLimitRatio:      getEnvAsFloatOrDefault("ANALYZER_MEMORY_LIMIT_RATIO", 0),
+			QuotaHeadroom:         getEnvAsIntOrDefault("ANALYZER_QUOTA_HEADROOM", 20),
+			WorkloadTimeout:       getEnvAsDurationOrDefault("ANALYZER_WORKLOAD_TIMEOUT", 60*time.Second),
+		},
 	}
 
+	config.Clusters = loadClusters(config.K8s, config.Mimir)
+	config.DefaultCluster = getEnvOrDefault("DEFAULT_CLUSTER", config.Clusters[0].Name)
+
 	// Valida a configuração
 	if err := config.validate(); err != nil {
 		return nil, err
@@ -130,10 +220,49 @@ func (c *Config) validate() error {
 		return errors.NewInvalidConfigurationError("port", "PORT is required")
 	}
 
+	if c.Server.RequestTimeout < 0 || c.Server.RequestTimeout >= ServerWriteTimeout {
+		return errors.NewInvalidConfigurationError("request_timeout", "REQUEST_TIMEOUT must be less than "+ServerWriteTimeout.String())
+	}
+
+	if c.Server.AggregateRequestTimeout < 0 {
+		return errors.NewInvalidConfigurationError("aggregate_request_timeout", "AGGREGATE_REQUEST_TIMEOUT must not be negative")
+	}
+
 	if c.Mimir.URL == "" {
 		return errors.NewInvalidConfigurationError("mimir_url", "MIMIR_URL is required")
 	}
 
+	switch c.Analyzer.CPULimitPolicy {
+	case "", "keep", "remove":
+	default:
+		return errors.NewInvalidConfigurationError("analyzer_cpu_limit_policy", "ANALYZER_CPU_LIMIT_POLICY must be keep or remove")
+	}
+
+	if ratio := c.Analyzer.CPULimitRatio; ratio != 0 && ratio < 1 {
+		return errors.NewInvalidConfigurationError("analyzer_cpu_limit_ratio", "ANALYZER_CPU_LIMIT_RATIO must be 0 or at least 1")
+	}
+	if ratio := c.Analyzer.MemoryLimitRatio; ratio != 0 && ratio < 1 {
+		return errors.NewInvalidConfigurationError("analyzer_memory_limit_ratio", "ANALYZER_MEMORY_LIMIT_RATIO must be 0 or at least 1")
+	}
+
+	seen := make(map[string]bool, len(c.Clusters))
+	for _, cluster := range c.Clusters {
+		if cluster.Name == "" {
+			return errors.NewInvalidConfigurationError("clusters", "CLUSTERS must not contain empty names")
+		}
+		if seen[cluster.Name] {
+			return errors.NewInvalidConfigurationError("clusters", "CLUSTERS must not contain duplicated names: "+cluster.Name)
+		}
+		seen[cluster.Name] = true
+
+		if _, err := mimir.NewMetricsBackend(cluster.MetricsBackend); err != nil {
+			return errors.NewInvalidConfigurationError("metrics_backend", "cluster "+cluster.Name+": "+err.Error())
+		}
+	}
+	if len(c.Clusters) > 0 && !seen[c.DefaultCluster] {
+		return errors.NewInvalidConfigurationError("default_cluster", "DEFAULT_CLUSTER must be one of CLUSTERS")
+	}
+
 	return nil
 }
 
@@ -144,10 +273,80 @@ func (c *Config) logConfig() {
 		logger.NewField("log_level", c.Logging.Level),
 		logger.NewField("log_format", c.Logging.Format),
 		logger.NewField("mimir_url", c.Mimir.URL),
+		logger.NewField("metrics_backend", c.Mimir.Backend.Type),
 		logger.NewField("in_cluster", c.K8s.InCluster),
+		logger.NewField("clusters", clusterNames(c.Clusters)),
+		logger.NewField("default_cluster", c.DefaultCluster),
+		logger.NewField("analyzer_max_concurrency", c.Analyzer.MaxConcurrency),
+		logger.NewField("analyzer_group_label", c.Analyzer.GroupLabel),
 	)
 }
 
+// loadClusters carrega os clusters listados em CLUSTERS (separados por vírgula). Cada cluster
+// é configurado por variáveis CLUSTER_<NOME>_*, com o nome em maiúsculas e hífens trocados por
+// underscores: KUBECONFIG (padrão: o KUBECONFIG global), CONTEXT (padrão: o nome do cluster),
+// IN_CLUSTER, MIMIR_ORG_ID (padrão: MIMIR_ORG_ID), METRICS_URL (padrão: MIMIR_URL) e o backend
+// de métricas (ver loadMetricsBackend). Sem CLUSTERS, retorna um único cluster chamado
+// CLUSTER_NAME com as configurações de K8s e Mimir.
+func loadClusters(k8s K8sConfig, mimirCfg MimirConfig) []ClusterConfig {
+	names := strings.Split(getEnvOrDefault("CLUSTERS", ""), ",")
+	var clusters []ClusterConfig
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "CLUSTER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		clusters = append(clusters, ClusterConfig{
+			Name:           name,
+			KubeconfigPath: getEnvOrDefault(prefix+"KUBECONFIG", k8s.KubeconfigPath),
+			Context:        getEnvOrDefault(prefix+"CONTEXT", name),
+			InCluster:      getEnvOrDefault(prefix+"IN_CLUSTER", "false") == "true",
+			MimirOrgID:     getEnvOrDefault(prefix+"MIMIR_ORG_ID", mimirCfg.OrgID),
+			MetricsURL:     getEnvOrDefault(prefix+"METRICS_URL", mimirCfg.URL),
+			MetricsBackend: loadMetricsBackend(prefix, mimirCfg.Backend),
+		})
+	}
+	if len(clusters) > 0 {
+		return clusters
+	}
+
+	return []ClusterConfig{{
+		Name:           getEnvOrDefault("CLUSTER_NAME", "default"),
+		KubeconfigPath: k8s.KubeconfigPath,
+		InCluster:      k8s.InCluster,
+		MimirOrgID:     mimirCfg.OrgID,
+		MetricsURL:     mimirCfg.URL,
+		MetricsBackend: mimirCfg.Backend,
+	}}
+}
+
+// loadMetricsBackend carrega o backend de métricas de um cluster das variáveis
+// <prefix>METRICS_BACKEND, METRICS_API_PREFIX, METRICS_TENANT_MODE, METRICS_TENANT_HEADER e
+// METRICS_TENANT_LABEL. As não definidas herdam o backend padrão; quando o cluster define o
+// próprio METRICS_BACKEND, herdam os valores padrão desse tipo.
+func loadMetricsBackend(prefix string, fallback mimir.BackendConfig) mimir.BackendConfig {
+	if backendType, exists := os.LookupEnv(prefix + "METRICS_BACKEND"); exists && backendType != fallback.Type {
+		fallback = mimir.BackendConfig{Type: backendType}
+	}
+	return mimir.BackendConfig{
+		Type:         fallback.Type,
+		APIPrefix:    getEnvOrDefault(prefix+"METRICS_API_PREFIX", fallback.APIPrefix),
+		TenantMode:   getEnvOrDefault(prefix+"METRICS_TENANT_MODE", fallback.TenantMode),
+		TenantHeader: getEnvOrDefault(prefix+"METRICS_TENANT_HEADER", fallback.TenantHeader),
+		TenantLabel:  getEnvOrDefault(prefix+"METRICS_TENANT_LABEL", fallback.TenantLabel),
+	}
+}
+
+// clusterNames retorna os nomes dos clusters configurados
+func clusterNames(clusters []ClusterConfig) []string {
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return names
+}
+
 // Funções auxiliares
 func getEnvOrDefault(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -165,6 +364,15 @@ func getEnvAsIntOrDefault(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvAsFloatOrDefault(key string, fallback float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return fallback
+}
+
 func getEnvAsDurationOrDefault(key string, fallback time.Duration) time.Duration {
 	if value, exists := os.LookupEnv(key); exists {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -2,8 +2,11 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/ElizCarvalho/k8s-resource-analyzer-api/internal/pkg/clients/mimir"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -106,6 +109,32 @@ func TestConfig_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "prazo da requisição maior que o WriteTimeout",
+			config: &Config{
+				Server: ServerConfig{
+					Port:           "8080",
+					RequestTimeout: 3 * time.Minute,
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prazo das análises agregadas negativo",
+			config: &Config{
+				Server: ServerConfig{
+					Port:                    "8080",
+					AggregateRequestTimeout: -time.Minute,
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "URL do Mimir vazia",
 			config: &Config{
@@ -118,6 +147,81 @@ func TestConfig_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "política de limit de CPU inválida",
+			config: &Config{
+				Server: ServerConfig{
+					Port: "8080",
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+				Analyzer: AnalyzerConfig{
+					CPULimitPolicy: "drop",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "clusters com nome duplicado",
+			config: &Config{
+				Server: ServerConfig{
+					Port: "8080",
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+				Clusters:       []ClusterConfig{{Name: "prod"}, {Name: "prod"}},
+				DefaultCluster: "prod",
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster padrão não configurado",
+			config: &Config{
+				Server: ServerConfig{
+					Port: "8080",
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+				Clusters:       []ClusterConfig{{Name: "staging"}},
+				DefaultCluster: "prod",
+			},
+			wantErr: true,
+		},
+		{
+			name: "backend de métricas sem suporte a extra_label",
+			config: &Config{
+				Server: ServerConfig{
+					Port: "8080",
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+				Clusters: []ClusterConfig{{
+					Name:           "prod",
+					MetricsBackend: mimir.BackendConfig{Type: mimir.BackendThanos, TenantMode: mimir.TenantModeExtraLabel},
+				}},
+				DefaultCluster: "prod",
+			},
+			wantErr: true,
+		},
+		{
+			name: "razão limit/request de memória abaixo de 1",
+			config: &Config{
+				Server: ServerConfig{
+					Port: "8080",
+				},
+				Mimir: MimirConfig{
+					URL: "http://mimir:9090",
+				},
+				Analyzer: AnalyzerConfig{
+					MemoryLimitRatio: 0.5,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,6 +234,70 @@ func TestConfig_validate(t *testing.T) {
 	}
 }
 
+func TestLoadClusters(t *testing.T) {
+	k8s := K8sConfig{KubeconfigPath: "/home/user/.kube/config", InCluster: true}
+	mimirCfg := MimirConfig{OrgID: "anonymous"}
+
+	t.Run("sem CLUSTERS usa um único cluster", func(t *testing.T) {
+		t.Setenv("CLUSTERS", "")
+		t.Setenv("CLUSTER_NAME", "prod-br")
+
+		clusters := loadClusters(k8s, mimirCfg)
+
+		want := []ClusterConfig{{Name: "prod-br", KubeconfigPath: k8s.KubeconfigPath, InCluster: true, MimirOrgID: "anonymous"}}
+		if !reflect.DeepEqual(clusters, want) {
+			t.Errorf("loadClusters() = %+v, want %+v", clusters, want)
+		}
+	})
+
+	t.Run("clusters configurados por contexto e secret", func(t *testing.T) {
+		t.Setenv("CLUSTERS", "staging, prod-us")
+		t.Setenv("CLUSTER_STAGING_MIMIR_ORG_ID", "staging")
+		t.Setenv("CLUSTER_PROD_US_KUBECONFIG", "/etc/clusters/prod-us/kubeconfig")
+		t.Setenv("CLUSTER_PROD_US_CONTEXT", "arn:aws:eks:us-east-1:123:cluster/prod")
+		t.Setenv("CLUSTER_PROD_US_MIMIR_ORG_ID", "prod-us")
+
+		clusters := loadClusters(k8s, mimirCfg)
+
+		want := []ClusterConfig{
+			{Name: "staging", KubeconfigPath: k8s.KubeconfigPath, Context: "staging", MimirOrgID: "staging"},
+			{Name: "prod-us", KubeconfigPath: "/etc/clusters/prod-us/kubeconfig", Context: "arn:aws:eks:us-east-1:123:cluster/prod", MimirOrgID: "prod-us"},
+		}
+		if !reflect.DeepEqual(clusters, want) {
+			t.Errorf("loadClusters() = %+v, want %+v", clusters, want)
+		}
+	})
+
+	t.Run("clusters com backends de métricas diferentes", func(t *testing.T) {
+		mimirCfg := MimirConfig{
+			URL:     "http://mimir:8080",
+			OrgID:   "anonymous",
+			Backend: mimir.BackendConfig{Type: mimir.BackendMimir, APIPrefix: "/custom/api/v1"},
+		}
+		t.Setenv("CLUSTERS", "legacy,edge")
+		t.Setenv("CLUSTER_LEGACY_METRICS_URL", "http://thanos-query:9090")
+		t.Setenv("CLUSTER_LEGACY_METRICS_BACKEND", mimir.BackendThanos)
+		t.Setenv("CLUSTER_EDGE_METRICS_TENANT_HEADER", "X-Tenant")
+
+		clusters := loadClusters(k8s, mimirCfg)
+
+		want := []ClusterConfig{
+			{
+				Name: "legacy", KubeconfigPath: k8s.KubeconfigPath, Context: "legacy", MimirOrgID: "anonymous",
+				MetricsURL: "http://thanos-query:9090", MetricsBackend: mimir.BackendConfig{Type: mimir.BackendThanos},
+			},
+			{
+				Name: "edge", KubeconfigPath: k8s.KubeconfigPath, Context: "edge", MimirOrgID: "anonymous",
+				MetricsURL:     "http://mimir:8080",
+				MetricsBackend: mimir.BackendConfig{Type: mimir.BackendMimir, APIPrefix: "/custom/api/v1", TenantHeader: "X-Tenant"},
+			},
+		}
+		if !reflect.DeepEqual(clusters, want) {
+			t.Errorf("loadClusters() = %+v, want %+v", clusters, want)
+		}
+	})
+}
+
 func TestGetEnvOrDefault(t *testing.T) {
 	// Backup e restauração da variável de ambiente
 	key := "TEST_ENV_VAR"
@@ -224,6 +392,56 @@ func TestGetEnvAsIntOrDefault(t *testing.T) {
 	}
 }
 
+func TestGetEnvAsFloatOrDefault(t *testing.T) {
+	key := "TEST_FLOAT_VAR"
+	originalValue := os.Getenv(key)
+	defer os.Setenv(key, originalValue)
+
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		fallback float64
+		want     float64
+	}{
+		{
+			name:     "valor decimal válido",
+			key:      key,
+			value:    "1.5",
+			fallback: 0,
+			want:     1.5,
+		},
+		{
+			name:     "valor inválido",
+			key:      key,
+			value:    "not_a_number",
+			fallback: 2,
+			want:     2,
+		},
+		{
+			name:     "variável não definida",
+			key:      key,
+			value:    "",
+			fallback: 1,
+			want:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.value != "" {
+				os.Setenv(tt.key, tt.value)
+			} else {
+				os.Unsetenv(tt.key)
+			}
+
+			if got := getEnvAsFloatOrDefault(tt.key, tt.fallback); got != tt.want {
+				t.Errorf("getEnvAsFloatOrDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetEnvAsDurationOrDefault(t *testing.T) {
 	key := "TEST_DURATION_VAR"
 	originalValue := os.Getenv(key)